@@ -90,6 +90,9 @@ const (
 	ConditionReasonFailedToGetKubeconfig   ConditionReason = "FailedToGetKubeconfig"
 )
 
+// AnnotationForceKubeconfigRotation makes the GardenerCluster controller fetch a new kubeconfig, it is removed once the kubeconfig is rotated
+const AnnotationForceKubeconfigRotation = "operator.kyma-project.io/force-kubeconfig-rotation"
+
 type ConditionType string
 
 const (
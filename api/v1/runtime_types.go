@@ -18,6 +18,7 @@ package v1
 
 import (
 	"fmt"
+	"time"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -34,6 +35,7 @@ import (
 const (
 	Finalizer                              = "runtime-controller.infrastructure-manager.kyma-project.io/deletion-hook"
 	AnnotationGardenerCloudDelConfirmation = "confirmation.gardener.cloud/deletion"
+	AnnotationGardenerOperation            = "gardener.cloud/operation"
 )
 
 const (
@@ -58,6 +60,15 @@ const (
 	RuntimeStateTerminating = "Terminating"
 )
 
+type HibernationState string
+
+const (
+	HibernationStateAwake       HibernationState = "Awake"
+	HibernationStateHibernating HibernationState = "Hibernating"
+	HibernationStateHibernated  HibernationState = "Hibernated"
+	HibernationStateWakingUp    HibernationState = "WakingUp"
+)
+
 type RuntimeConditionType string
 
 const (
@@ -66,6 +77,11 @@ const (
 	ConditionTypeOidcAndCMsConfigured   RuntimeConditionType = "OidcAndConfigMapConfigured"
 	ConditionTypeRuntimeConfigured      RuntimeConditionType = "Configured"
 	ConditionTypeRuntimeDeprovisioned   RuntimeConditionType = "Deprovisioned"
+	ConditionTypeControlPlaneHA         RuntimeConditionType = "ControlPlaneHighAvailability"
+	ConditionTypeCredentialsRotation    RuntimeConditionType = "CredentialsRotation"
+	ConditionTypeGardenerOperation      RuntimeConditionType = "GardenerOperation"
+	ConditionTypeSSHAccess              RuntimeConditionType = "SSHAccess"
+	ConditionTypeOIDCIssuerKeys         RuntimeConditionType = "OIDCIssuerKeys"
 )
 
 type RuntimeConditionReason string
@@ -94,11 +110,41 @@ const (
 	ConditionReasonAuditLogError = RuntimeConditionReason("AuditLogErr")
 
 	ConditionReasonAdministratorsConfigured = RuntimeConditionReason("AdministratorsConfigured")
+	ConditionReasonAdministratorsDrifted    = RuntimeConditionReason("AdministratorsDriftCorrected")
 	ConditionReasonOidcAndCMsConfigured     = RuntimeConditionReason("OidcAndConfigMapsConfigured")
 	ConditionReasonOidcError                = RuntimeConditionReason("OidcConfigurationErr")
+	ConditionReasonAuthorizationError       = RuntimeConditionReason("AuthorizationConfigurationErr")
 	ConditionReasonKymaSystemNSError        = RuntimeConditionReason("KymaSystemCreationErr")
 	ConditionReasonSeedNotFound             = RuntimeConditionReason("SeedNotFound")
 	ConditionReasonRegistryCacheError       = RuntimeConditionReason("RegistryCacheConfigurationErr")
+	ConditionReasonZonesSelectionError      = RuntimeConditionReason("ZonesSelectionErr")
+	ConditionReasonHibernating              = RuntimeConditionReason("Hibernating")
+	ConditionReasonHibernated               = RuntimeConditionReason("Hibernated")
+	ConditionReasonWakingUp                 = RuntimeConditionReason("WakingUp")
+
+	ConditionReasonControlPlaneHAMigrationPending = RuntimeConditionReason("HAMigrationPending")
+	ConditionReasonControlPlaneHAConfigured       = RuntimeConditionReason("HAConfigured")
+	ConditionReasonControlPlaneHAError            = RuntimeConditionReason("HAConfigurationErr")
+
+	ConditionReasonCredentialsRotationPreparing  = RuntimeConditionReason("CredentialsRotationPreparing")
+	ConditionReasonCredentialsRotationPrepared   = RuntimeConditionReason("CredentialsRotationPrepared")
+	ConditionReasonCredentialsRotationCompleting = RuntimeConditionReason("CredentialsRotationCompleting")
+	ConditionReasonCredentialsRotationCompleted  = RuntimeConditionReason("CredentialsRotationCompleted")
+	ConditionReasonCredentialsRotationError      = RuntimeConditionReason("CredentialsRotationErr")
+
+	ConditionReasonGardenerOperationForwarded = RuntimeConditionReason("GardenerOperationForwarded")
+	ConditionReasonGardenerOperationCompleted = RuntimeConditionReason("GardenerOperationCompleted")
+	ConditionReasonGardenerOperationRejected  = RuntimeConditionReason("GardenerOperationRejected")
+	ConditionReasonGardenerOperationFailed    = RuntimeConditionReason("GardenerOperationFailed")
+
+	ConditionReasonSSHAccessGranted = RuntimeConditionReason("SSHAccessGranted")
+	ConditionReasonSSHAccessRevoked = RuntimeConditionReason("SSHAccessRevoked")
+
+	ConditionReasonOIDCIssuerKeysPinned     = RuntimeConditionReason("KeysPinned")
+	ConditionReasonOIDCIssuerKeysRotated    = RuntimeConditionReason("KeysRotated")
+	ConditionReasonOIDCIssuerUnreachable    = RuntimeConditionReason("IssuerUnreachable")
+	ConditionReasonOIDCIssuerKeysInvalid    = RuntimeConditionReason("KeysInvalid")
+	ConditionReasonOIDCIssuerKeysNotApplied = RuntimeConditionReason("KeysNotApplied")
 )
 
 //+kubebuilder:object:root=true
@@ -149,6 +195,69 @@ type RuntimeStatus struct {
 
 	// ProvisioningCompleted indicates if the initial provisioning of the cluster is completed
 	ProvisioningCompleted bool `json:"provisioningCompleted,omitempty"`
+
+	// WorkerZones contains zones selected by KIM for the workers created without zones
+	// +optional
+	WorkerZones []WorkerZones `json:"workerZones,omitempty"`
+
+	// HibernationState reflects the hibernation state of the shoot
+	// +optional
+	// +kubebuilder:validation:Enum=Awake;Hibernating;Hibernated;WakingUp
+	HibernationState HibernationState `json:"hibernationState,omitempty"`
+
+	// GardenerOperation is the last Gardener operation forwarded to the shoot, its outcome is reported in the GardenerOperation condition
+	// +optional
+	GardenerOperation string `json:"gardenerOperation,omitempty"`
+
+	// GardenerOperationShootGeneration is the generation of the shoot before the Gardener operation was forwarded,
+	// the operation is finished once a newer shoot generation is reconciled
+	// +optional
+	GardenerOperationShootGeneration int64 `json:"gardenerOperationShootGeneration,omitempty"`
+
+	// AuditLog reflects the audit log tenant currently configured for the shoot
+	// +optional
+	AuditLog *AuditLogStatus `json:"auditLog,omitempty"`
+
+	// OIDCIssuers contains the signing keys pinned for the additional OIDC issuers with JWKS pinning enabled
+	// +optional
+	OIDCIssuers []OIDCIssuerStatus `json:"oidcIssuers,omitempty"`
+
+	// Administrators lists the administrators bound in the SKR by the last successful configuration
+	// +optional
+	Administrators []AdministratorStatus `json:"administrators,omitempty"`
+}
+
+type AdministratorStatus struct {
+	Administrator `json:",inline"`
+	// ClusterRoleBinding is the name of the binding applied in the SKR
+	ClusterRoleBinding string `json:"clusterRoleBinding"`
+}
+
+type OIDCIssuerStatus struct {
+	IssuerURL string `json:"issuerURL"`
+	// KeyIDs are the IDs of the signing keys published by the issuer
+	// +optional
+	KeyIDs []string `json:"keyIDs,omitempty"`
+	// KeysHash is the SHA-256 hash of the pinned key set, a changed hash means the issuer rotated its keys
+	// +optional
+	KeysHash string `json:"keysHash,omitempty"`
+	// JWKS is the pinned key set, it is rendered into the OpenIDConnect resource of the issuer in the SKR
+	// +optional
+	JWKS []byte `json:"jwks,omitempty"`
+	// LastRefreshTime is the time of the last attempt to refresh the keys
+	LastRefreshTime metav1.Time `json:"lastRefreshTime"`
+}
+
+type AuditLogStatus struct {
+	// TenantID is the audit log tenant configured in the auditlog extension
+	TenantID string `json:"tenantID"`
+	// SecretName is the name of the secret in the Gardener project referenced as the audit log credentials
+	SecretName string `json:"secretName"`
+}
+
+type WorkerZones struct {
+	WorkerName string   `json:"workerName"`
+	Zones      []string `json:"zones"`
 }
 
 type RuntimeShoot struct {
@@ -163,44 +272,382 @@ type RuntimeShoot struct {
 	Provider            Provider               `json:"provider"`
 	Networking          Networking             `json:"networking"`
 	ControlPlane        *gardener.ControlPlane `json:"controlPlane,omitempty"`
+	// Hibernation overrides the hibernation settings configured for the broker plan, the plan settings are restored when it is removed
+	// +optional
+	Hibernation *Hibernation `json:"hibernation,omitempty"`
+	// SSHAccess grants temporary SSH access to the worker nodes, it is revoked automatically when it expires
+	// +optional
+	SSHAccess *SSHAccess `json:"sshAccess,omitempty"`
+	// AuditPolicyProfile selects the audit policy profile, overriding the profile configured for the broker plan
+	// +optional
+	AuditPolicyProfile *string `json:"auditPolicyProfile,omitempty"`
+}
+
+// SSHAccess defines a time-boxed break-glass SSH access to the worker nodes
+type SSHAccess struct {
+	// ExpiresAt is the time at which the SSH access is revoked
+	// +kubebuilder:validation:Required
+	ExpiresAt metav1.Time `json:"expiresAt"`
+	// Reason documents why the SSH access is needed
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+}
+
+// IsActive returns true when the SSH access is requested and has not expired yet
+func (a *SSHAccess) IsActive(now time.Time) bool {
+	return a != nil && now.Before(a.ExpiresAt.Time)
+}
+
+// Hibernation defines whether the cluster is hibernated and the schedules for hibernating and waking it up
+type Hibernation struct {
+	// Enabled hibernates the cluster when set to true and wakes it up when set to false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Schedules define when the cluster is regularly hibernated and woken up
+	// +optional
+	Schedules []HibernationSchedule `json:"schedules,omitempty"`
+}
+
+// HibernationSchedule defines cron specs for hibernating and waking up the cluster, at least one of them has to be set
+type HibernationSchedule struct {
+	// Start is a cron spec at which the cluster is hibernated
+	// +optional
+	Start *string `json:"start,omitempty"`
+	// End is a cron spec at which the cluster is woken up
+	// +optional
+	End *string `json:"end,omitempty"`
+	// Location is the time zone in which Start and End are evaluated, e.g. Europe/Berlin; UTC is used when not set
+	// +optional
+	Location *string `json:"location,omitempty"`
 }
 
 type Kubernetes struct {
 	Version       *string   `json:"version,omitempty"`
 	KubeAPIServer APIServer `json:"kubeAPIServer,omitempty"`
+	// ClusterAutoscaler overrides the cluster autoscaler settings configured for the broker plan
+	// +optional
+	ClusterAutoscaler *ClusterAutoscaler `json:"clusterAutoscaler,omitempty"`
+}
+
+// ClusterAutoscaler contains the cluster autoscaler settings which can be tuned per runtime
+type ClusterAutoscaler struct {
+	// ScaleDownDelayAfterAdd defines how long after scale up the scale down evaluation resumes
+	// +optional
+	ScaleDownDelayAfterAdd *metav1.Duration `json:"scaleDownDelayAfterAdd,omitempty"`
+	// ScaleDownUnneededTime defines how long a node should be unneeded before it is eligible for scale down
+	// +optional
+	ScaleDownUnneededTime *metav1.Duration `json:"scaleDownUnneededTime,omitempty"`
+	// ScaleDownUtilizationThreshold defines the threshold in fraction below which a node is considered for scale down
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	// +optional
+	ScaleDownUtilizationThreshold *float64 `json:"scaleDownUtilizationThreshold,omitempty"`
+	// Expander defines the algorithm used to select the worker pool to scale up
+	// +kubebuilder:validation:Enum=least-waste;most-pods;priority;random
+	// +optional
+	Expander *string `json:"expander,omitempty"`
+	// MaxNodeProvisionTime defines how long the autoscaler waits for a node to be provisioned
+	// +optional
+	MaxNodeProvisionTime *metav1.Duration `json:"maxNodeProvisionTime,omitempty"`
 }
 
 // OIDCConfig contains configuration settings for the OIDC provider.
 // Note: Descriptions were taken from the Kubernetes documentation.
 type OIDCConfig struct {
 	gardener.OIDCConfig `json:",omitempty"`
-	JWKS                []byte `json:"jwks,omitempty"`
+	// JWKS is the key set used to verify the tokens of the issuer instead of the keys discovered from the issuer.
+	// The kube-apiserver cannot use an inline key set for JWT authenticators, so the config is applied as an OpenIDConnect resource in the SKR.
+	// +optional
+	JWKS []byte `json:"jwks,omitempty"`
+	// Audiences contains the audiences accepted in addition to the client ID
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// AudienceMatchPolicy defines how the audiences are matched against the token, required when more than one audience is accepted
+	// +optional
+	// +kubebuilder:validation:Enum=MatchAny
+	AudienceMatchPolicy *AudienceMatchPolicy `json:"audienceMatchPolicy,omitempty"`
+	// ClaimValidationRules are checked in addition to the required claims before the token is accepted
+	// +optional
+	ClaimValidationRules []ClaimValidationRule `json:"claimValidationRules,omitempty"`
+	// ClaimMappings override the username and groups claims and map the token claims to the user attributes using CEL expressions
+	// +optional
+	ClaimMappings *ClaimMappings `json:"claimMappings,omitempty"`
+	// UserValidationRules are CEL expressions evaluated against the mapped user, all of them must evaluate to true.
+	// Rules rejecting usernames and groups with the reserved system: prefix are always added.
+	// +optional
+	UserValidationRules []UserValidationRule `json:"userValidationRules,omitempty"`
+
+	// PinJWKS enables the discovery of the issuer signing keys by KIM, the keys are validated against the signing algorithms and pinned in the Runtime status.
+	// The pinned keys are used to verify the tokens of the issuer, so the config is applied as an OpenIDConnect resource in the SKR, which is updated when the issuer rotates its keys.
+	// The keys are refreshed periodically and the OIDCIssuerKeys condition reports rotated keys and unreachable issuers.
+	// +optional
+	PinJWKS bool `json:"pinJWKS,omitempty"`
+}
+
+type AudienceMatchPolicy string
+
+const AudienceMatchPolicyMatchAny AudienceMatchPolicy = "MatchAny"
+
+// ClaimValidationRule requires either a claim with a value or a CEL expression evaluated against the token claims
+type ClaimValidationRule struct {
+	// +optional
+	Claim string `json:"claim,omitempty"`
+	// +optional
+	RequiredValue string `json:"requiredValue,omitempty"`
+	// +optional
+	Expression string `json:"expression,omitempty"`
+	// Message is returned when the expression evaluates to false
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type ClaimMappings struct {
+	// +optional
+	Username *PrefixedClaimOrExpression `json:"username,omitempty"`
+	// +optional
+	Groups *PrefixedClaimOrExpression `json:"groups,omitempty"`
+	// +optional
+	UID *ClaimOrExpression `json:"uid,omitempty"`
+	// +optional
+	Extra []ExtraMapping `json:"extra,omitempty"`
+}
+
+// PrefixedClaimOrExpression maps either a claim with an optional prefix or a CEL expression
+type PrefixedClaimOrExpression struct {
+	// +optional
+	Claim string `json:"claim,omitempty"`
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
+type ClaimOrExpression struct {
+	// +optional
+	Claim string `json:"claim,omitempty"`
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
+type ExtraMapping struct {
+	Key             string `json:"key"`
+	ValueExpression string `json:"valueExpression"`
+}
+
+type UserValidationRule struct {
+	Expression string `json:"expression"`
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type APIServer struct {
-	OidcConfig           gardener.OIDCConfig `json:"oidcConfig,omitempty"`
-	AdditionalOidcConfig *[]OIDCConfig       `json:"additionalOidcConfig,omitempty"`
+	OidcConfig gardener.OIDCConfig `json:"oidcConfig,omitempty"`
+	// AdditionalOidcConfig contains the OIDC providers rendered as additional JWT authenticators in the structured authentication configuration.
+	// Providers with an inline key set, or sharing an issuer with another provider but differing in settings other than the client ID,
+	// cannot be expressed as JWT authenticators and are applied as OpenIDConnect resources in the SKR through the shoot-oidc-service extension.
+	AdditionalOidcConfig *[]OIDCConfig `json:"additionalOidcConfig,omitempty"`
+	// Settings contains the kube-apiserver settings which can be customized per runtime
+	// +optional
+	Settings *KubeAPIServerSettings `json:"settings,omitempty"`
+	// StructuredAuthorization contains the webhook authorizers evaluated by the kube-apiserver before RBAC
+	// +optional
+	StructuredAuthorization *StructuredAuthorization `json:"structuredAuthorization,omitempty"`
+}
+
+type StructuredAuthorization struct {
+	// +kubebuilder:validation:MinItems=1
+	Webhooks []WebhookAuthorizer `json:"webhooks"`
+}
+
+// WebhookAuthorizer configures a webhook authorizer, the kubeconfig used to call the webhook is read from a secret in the Runtime namespace
+type WebhookAuthorizer struct {
+	// Name of the authorizer, unique within the Runtime
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// KubeconfigSecretName is the name of the secret in the Runtime namespace containing the webhook kubeconfig under the kubeconfig key
+	KubeconfigSecretName string `json:"kubeconfigSecretName"`
+	// Timeout of the webhook request
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// AuthorizedTTL is the duration to cache authorized responses
+	// +optional
+	AuthorizedTTL *metav1.Duration `json:"authorizedTTL,omitempty"`
+	// UnauthorizedTTL is the duration to cache unauthorized responses
+	// +optional
+	UnauthorizedTTL *metav1.Duration `json:"unauthorizedTTL,omitempty"`
+	// FailurePolicy defines the decision when the webhook cannot be reached, NoOpinion is used when not set
+	// +optional
+	// +kubebuilder:validation:Enum=NoOpinion;Deny
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+	// MatchConditions are CEL expressions deciding which requests are sent to the webhook
+	// +optional
+	MatchConditions []string `json:"matchConditions,omitempty"`
+}
+
+// KubeAPIServerSettings contains the allowlisted kube-apiserver settings merged into the shoot kube-apiserver configuration
+type KubeAPIServerSettings struct {
+	// AdmissionPlugins contains the admission plugins to be enabled, disabled or configured
+	// Only the admission plugins allowed in the infrastructure manager configuration can be used
+	// +optional
+	AdmissionPlugins []AdmissionPlugin `json:"admissionPlugins,omitempty"`
+	// Requests contains the limits of the requests processed in parallel
+	// +optional
+	Requests *gardener.APIServerRequests `json:"requests,omitempty"`
+	// FeatureGates contains the feature gates of the kube-apiserver
+	// Only the feature gates allowed in the infrastructure manager configuration can be used
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// EventTTL defines how long events are retained
+	// +optional
+	EventTTL *metav1.Duration `json:"eventTTL,omitempty"`
+	// EncryptedResources contains the resources, in addition to secrets, which are encrypted at rest
+	// +optional
+	EncryptedResources []string `json:"encryptedResources,omitempty"`
+}
+
+// AdmissionPlugin contains the settings of a kube-apiserver admission plugin
+type AdmissionPlugin struct {
+	// Name is the name of the admission plugin
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Config is the configuration of the admission plugin
+	// +optional
+	Config *runtime.RawExtension `json:"config,omitempty"`
+	// Disabled specifies whether the admission plugin is disabled
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 type Provider struct {
-	//+kubebuilder:validation:Enum=aws;azure;gcp;openstack
+	//+kubebuilder:validation:Enum=aws;azure;gcp;openstack;alicloud;local
 	Type                 string                `json:"type"`
 	Workers              []gardener.Worker     `json:"workers"`
 	AdditionalWorkers    *[]gardener.Worker    `json:"additionalWorkers,omitempty"`
 	ControlPlaneConfig   *runtime.RawExtension `json:"controlPlaneConfig,omitempty"`
 	InfrastructureConfig *runtime.RawExtension `json:"infrastructureConfig,omitempty"`
+	// WorkersTuning contains provider neutral settings of the worker pool machines, merged into the worker config of the provider extension
+	// +optional
+	WorkersTuning []WorkerTuning `json:"workersTuning,omitempty"`
+	// OpenStack overrides the floating pool and load balancer provider configured for the region
+	// +optional
+	OpenStack *OpenStackSettings `json:"openstack,omitempty"`
+	// GCP contains settings of the control plane zone and the CloudNAT
+	// +optional
+	GCP *GCPSettings `json:"gcp,omitempty"`
+}
+
+type OpenStackSettings struct {
+	// +optional
+	FloatingPoolName string `json:"floatingPoolName,omitempty"`
+	// +optional
+	LoadBalancerProvider string `json:"loadBalancerProvider,omitempty"`
+}
+
+// WorkerTuning contains volume and instance settings of a worker pool, not every provider supports all of them
+type WorkerTuning struct {
+	// WorkerName is the name of the main or an additional worker pool
+	WorkerName string `json:"workerName"`
+	// Volume tunes the root disk of the machines (AWS)
+	// +optional
+	Volume *VolumeTuning `json:"volume,omitempty"`
+	// DataVolumes tune the data volumes defined for the worker pool (AWS, Azure, GCP)
+	// +optional
+	DataVolumes []DataVolumeTuning `json:"dataVolumes,omitempty"`
+	// LocalSSDInterface is the interface of the local SSDs attached to the machines (GCP)
+	// +kubebuilder:validation:Enum=SCSI;NVME
+	// +optional
+	LocalSSDInterface *string `json:"localSSDInterface,omitempty"`
+}
+
+type VolumeTuning struct {
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IOPS *int64 `json:"iops,omitempty"`
+	// Throughput in MiB/s
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Throughput *int64 `json:"throughput,omitempty"`
+}
+
+type DataVolumeTuning struct {
+	// Name is the name of the data volume in the worker pool
+	Name         string `json:"name"`
+	VolumeTuning `json:",inline"`
+	// SourceImage is the image the data volume is created from, the image URN on Azure (Azure, GCP)
+	// +optional
+	SourceImage *string `json:"sourceImage,omitempty"`
+}
+
+type GCPSettings struct {
+	// ControlPlaneZone must be one of the worker zones, it cannot be changed after the cluster is created
+	// +optional
+	ControlPlaneZone string `json:"controlPlaneZone,omitempty"`
+	// +optional
+	CloudNAT *GCPCloudNAT `json:"cloudNAT,omitempty"`
+}
+
+type GCPCloudNAT struct {
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=65536
+	// +optional
+	MinPortsPerVM *int32 `json:"minPortsPerVM,omitempty"`
+	// +optional
+	EndpointIndependentMapping *bool `json:"endpointIndependentMapping,omitempty"`
+	// NatIPNames are names of the reserved external IP addresses used by the CloudNAT
+	// +optional
+	NatIPNames []string `json:"natIPNames,omitempty"`
 }
 
 type Networking struct {
-	Type     *string `json:"type,omitempty"`
-	Pods     string  `json:"pods"`
-	Nodes    string  `json:"nodes"`
-	Services string  `json:"services"`
+	Type         *string       `json:"type,omitempty"`
+	Pods         string        `json:"pods"`
+	Nodes        string        `json:"nodes"`
+	Services     string        `json:"services"`
+	SubnetLayout *SubnetLayout `json:"subnetLayout,omitempty"`
+}
+
+// SubnetLayout defines how the nodes CIDR is split into zone subnets, used only for AWS and Azure
+type SubnetLayout struct {
+	// +kubebuilder:validation:Enum=kyma-weighted;uniform;custom
+	Strategy string `json:"strategy"`
+	// ZonePrefixLengths contains prefix lengths of the workers subnets in the order of zones, required for the custom strategy
+	// +optional
+	ZonePrefixLengths []int `json:"zonePrefixLengths,omitempty"`
 }
 
 type Security struct {
-	Administrators []string           `json:"administrators"`
-	Networking     NetworkingSecurity `json:"networking"`
+	// Administrators are users bound to the cluster-admin ClusterRole
+	Administrators []string `json:"administrators"`
+	// AdministratorBindings are users, groups or service accounts bound to a chosen ClusterRole
+	// +optional
+	AdministratorBindings []Administrator    `json:"administratorBindings,omitempty"`
+	Networking            NetworkingSecurity `json:"networking"`
+}
+
+type AdministratorKind string
+
+const (
+	AdministratorKindUser           AdministratorKind = "User"
+	AdministratorKindGroup          AdministratorKind = "Group"
+	AdministratorKindServiceAccount AdministratorKind = "ServiceAccount"
+
+	DefaultAdministratorClusterRole = "cluster-admin"
+)
+
+type Administrator struct {
+	// +kubebuilder:validation:Enum=User;Group;ServiceAccount
+	Kind AdministratorKind `json:"kind"`
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the service account
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// ClusterRole bound to the administrator, for example cluster-admin, admin, view or a custom ClusterRole
+	// +kubebuilder:default=cluster-admin
+	// +optional
+	ClusterRole string `json:"clusterRole,omitempty"`
 }
 
 type NetworkingSecurity struct {
@@ -274,6 +721,18 @@ func (k *Runtime) UpdateStatePending(c RuntimeConditionType, r RuntimeConditionR
 	meta.SetStatusCondition(&k.Status.Conditions, condition)
 }
 
+// UpdateCondition sets the condition without changing the state of the Runtime
+func (k *Runtime) UpdateCondition(c RuntimeConditionType, r RuntimeConditionReason, status, msg string) {
+	condition := metav1.Condition{
+		Type:               string(c),
+		Status:             metav1.ConditionStatus(status),
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(r),
+		Message:            msg,
+	}
+	meta.SetStatusCondition(&k.Status.Conditions, condition)
+}
+
 func (k *Runtime) UpdateStateProvisioningCompleted() {
 	k.Status.ProvisioningCompleted = true
 }
@@ -41,6 +41,16 @@ func (in *APIServer) DeepCopyInto(out *APIServer) {
 			}
 		}
 	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(KubeAPIServerSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StructuredAuthorization != nil {
+		in, out := &in.StructuredAuthorization, &out.StructuredAuthorization
+		*out = new(StructuredAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServer.
@@ -53,6 +63,203 @@ func (in *APIServer) DeepCopy() *APIServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Administrator) DeepCopyInto(out *Administrator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Administrator.
+func (in *Administrator) DeepCopy() *Administrator {
+	if in == nil {
+		return nil
+	}
+	out := new(Administrator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdministratorStatus) DeepCopyInto(out *AdministratorStatus) {
+	*out = *in
+	out.Administrator = in.Administrator
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdministratorStatus.
+func (in *AdministratorStatus) DeepCopy() *AdministratorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdministratorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionPlugin) DeepCopyInto(out *AdmissionPlugin) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPlugin.
+func (in *AdmissionPlugin) DeepCopy() *AdmissionPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogStatus) DeepCopyInto(out *AuditLogStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogStatus.
+func (in *AuditLogStatus) DeepCopy() *AuditLogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimMappings) DeepCopyInto(out *ClaimMappings) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(PrefixedClaimOrExpression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = new(PrefixedClaimOrExpression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(ClaimOrExpression)
+		**out = **in
+	}
+	if in.Extra != nil {
+		in, out := &in.Extra, &out.Extra
+		*out = make([]ExtraMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimMappings.
+func (in *ClaimMappings) DeepCopy() *ClaimMappings {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimMappings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimOrExpression) DeepCopyInto(out *ClaimOrExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimOrExpression.
+func (in *ClaimOrExpression) DeepCopy() *ClaimOrExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimOrExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimValidationRule) DeepCopyInto(out *ClaimValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimValidationRule.
+func (in *ClaimValidationRule) DeepCopy() *ClaimValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscaler) DeepCopyInto(out *ClusterAutoscaler) {
+	*out = *in
+	if in.ScaleDownDelayAfterAdd != nil {
+		in, out := &in.ScaleDownDelayAfterAdd, &out.ScaleDownDelayAfterAdd
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownUnneededTime != nil {
+		in, out := &in.ScaleDownUnneededTime, &out.ScaleDownUnneededTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownUtilizationThreshold != nil {
+		in, out := &in.ScaleDownUtilizationThreshold, &out.ScaleDownUtilizationThreshold
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Expander != nil {
+		in, out := &in.Expander, &out.Expander
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxNodeProvisionTime != nil {
+		in, out := &in.MaxNodeProvisionTime, &out.MaxNodeProvisionTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscaler.
+func (in *ClusterAutoscaler) DeepCopy() *ClusterAutoscaler {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeTuning) DeepCopyInto(out *DataVolumeTuning) {
+	*out = *in
+	in.VolumeTuning.DeepCopyInto(&out.VolumeTuning)
+	if in.SourceImage != nil {
+		in, out := &in.SourceImage, &out.SourceImage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeTuning.
+func (in *DataVolumeTuning) DeepCopy() *DataVolumeTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Egress) DeepCopyInto(out *Egress) {
 	*out = *in
@@ -68,6 +275,21 @@ func (in *Egress) DeepCopy() *Egress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraMapping) DeepCopyInto(out *ExtraMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraMapping.
+func (in *ExtraMapping) DeepCopy() *ExtraMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Filter) DeepCopyInto(out *Filter) {
 	*out = *in
@@ -89,6 +311,56 @@ func (in *Filter) DeepCopy() *Filter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPCloudNAT) DeepCopyInto(out *GCPCloudNAT) {
+	*out = *in
+	if in.MinPortsPerVM != nil {
+		in, out := &in.MinPortsPerVM, &out.MinPortsPerVM
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EndpointIndependentMapping != nil {
+		in, out := &in.EndpointIndependentMapping, &out.EndpointIndependentMapping
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NatIPNames != nil {
+		in, out := &in.NatIPNames, &out.NatIPNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCloudNAT.
+func (in *GCPCloudNAT) DeepCopy() *GCPCloudNAT {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPCloudNAT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPSettings) DeepCopyInto(out *GCPSettings) {
+	*out = *in
+	if in.CloudNAT != nil {
+		in, out := &in.CloudNAT, &out.CloudNAT
+		*out = new(GCPCloudNAT)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSettings.
+func (in *GCPSettings) DeepCopy() *GCPSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GardenerCluster) DeepCopyInto(out *GardenerCluster) {
 	*out = *in
@@ -187,6 +459,63 @@ func (in *GardenerClusterStatus) DeepCopy() *GardenerClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hibernation) DeepCopyInto(out *Hibernation) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]HibernationSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hibernation.
+func (in *Hibernation) DeepCopy() *Hibernation {
+	if in == nil {
+		return nil
+	}
+	out := new(Hibernation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationSchedule) DeepCopyInto(out *HibernationSchedule) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = new(string)
+		**out = **in
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = new(string)
+		**out = **in
+	}
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationSchedule.
+func (in *HibernationSchedule) DeepCopy() *HibernationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageRegistryCache) DeepCopyInto(out *ImageRegistryCache) {
 	*out = *in
@@ -217,6 +546,50 @@ func (in *Ingress) DeepCopy() *Ingress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeAPIServerSettings) DeepCopyInto(out *KubeAPIServerSettings) {
+	*out = *in
+	if in.AdmissionPlugins != nil {
+		in, out := &in.AdmissionPlugins, &out.AdmissionPlugins
+		*out = make([]AdmissionPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(v1beta1.APIServerRequests)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EventTTL != nil {
+		in, out := &in.EventTTL, &out.EventTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.EncryptedResources != nil {
+		in, out := &in.EncryptedResources, &out.EncryptedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeAPIServerSettings.
+func (in *KubeAPIServerSettings) DeepCopy() *KubeAPIServerSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeAPIServerSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Kubeconfig) DeepCopyInto(out *Kubeconfig) {
 	*out = *in
@@ -242,6 +615,11 @@ func (in *Kubernetes) DeepCopyInto(out *Kubernetes) {
 		**out = **in
 	}
 	in.KubeAPIServer.DeepCopyInto(&out.KubeAPIServer)
+	if in.ClusterAutoscaler != nil {
+		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
+		*out = new(ClusterAutoscaler)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kubernetes.
@@ -262,6 +640,11 @@ func (in *Networking) DeepCopyInto(out *Networking) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SubnetLayout != nil {
+		in, out := &in.SubnetLayout, &out.SubnetLayout
+		*out = new(SubnetLayout)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Networking.
@@ -299,6 +682,31 @@ func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AudienceMatchPolicy != nil {
+		in, out := &in.AudienceMatchPolicy, &out.AudienceMatchPolicy
+		*out = new(AudienceMatchPolicy)
+		**out = **in
+	}
+	if in.ClaimValidationRules != nil {
+		in, out := &in.ClaimValidationRules, &out.ClaimValidationRules
+		*out = make([]ClaimValidationRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimMappings != nil {
+		in, out := &in.ClaimMappings, &out.ClaimMappings
+		*out = new(ClaimMappings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserValidationRules != nil {
+		in, out := &in.UserValidationRules, &out.UserValidationRules
+		*out = make([]UserValidationRule, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
@@ -311,6 +719,67 @@ func (in *OIDCConfig) DeepCopy() *OIDCConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCIssuerStatus) DeepCopyInto(out *OIDCIssuerStatus) {
+	*out = *in
+	if in.KeyIDs != nil {
+		in, out := &in.KeyIDs, &out.KeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JWKS != nil {
+		in, out := &in.JWKS, &out.JWKS
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	in.LastRefreshTime.DeepCopyInto(&out.LastRefreshTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCIssuerStatus.
+func (in *OIDCIssuerStatus) DeepCopy() *OIDCIssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCIssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackSettings) DeepCopyInto(out *OpenStackSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackSettings.
+func (in *OpenStackSettings) DeepCopy() *OpenStackSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefixedClaimOrExpression) DeepCopyInto(out *PrefixedClaimOrExpression) {
+	*out = *in
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixedClaimOrExpression.
+func (in *PrefixedClaimOrExpression) DeepCopy() *PrefixedClaimOrExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefixedClaimOrExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
@@ -342,6 +811,23 @@ func (in *Provider) DeepCopyInto(out *Provider) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WorkersTuning != nil {
+		in, out := &in.WorkersTuning, &out.WorkersTuning
+		*out = make([]WorkerTuning, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OpenStack != nil {
+		in, out := &in.OpenStack, &out.OpenStack
+		*out = new(OpenStackSettings)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provider.
@@ -434,6 +920,21 @@ func (in *RuntimeShoot) DeepCopyInto(out *RuntimeShoot) {
 		*out = new(v1beta1.ControlPlane)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(Hibernation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSHAccess != nil {
+		in, out := &in.SSHAccess, &out.SSHAccess
+		*out = new(SSHAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditPolicyProfile != nil {
+		in, out := &in.AuditPolicyProfile, &out.AuditPolicyProfile
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeShoot.
@@ -478,6 +979,30 @@ func (in *RuntimeStatus) DeepCopyInto(out *RuntimeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WorkerZones != nil {
+		in, out := &in.WorkerZones, &out.WorkerZones
+		*out = make([]WorkerZones, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLogStatus)
+		**out = **in
+	}
+	if in.OIDCIssuers != nil {
+		in, out := &in.OIDCIssuers, &out.OIDCIssuers
+		*out = make([]OIDCIssuerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Administrators != nil {
+		in, out := &in.Administrators, &out.Administrators
+		*out = make([]AdministratorStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeStatus.
@@ -490,6 +1015,22 @@ func (in *RuntimeStatus) DeepCopy() *RuntimeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHAccess) DeepCopyInto(out *SSHAccess) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHAccess.
+func (in *SSHAccess) DeepCopy() *SSHAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Secret) DeepCopyInto(out *Secret) {
 	*out = *in
@@ -513,6 +1054,11 @@ func (in *Security) DeepCopyInto(out *Security) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdministratorBindings != nil {
+		in, out := &in.AdministratorBindings, &out.AdministratorBindings
+		*out = make([]Administrator, len(*in))
+		copy(*out, *in)
+	}
 	in.Networking.DeepCopyInto(&out.Networking)
 }
 
@@ -540,3 +1086,172 @@ func (in *Shoot) DeepCopy() *Shoot {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StructuredAuthorization) DeepCopyInto(out *StructuredAuthorization) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]WebhookAuthorizer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StructuredAuthorization.
+func (in *StructuredAuthorization) DeepCopy() *StructuredAuthorization {
+	if in == nil {
+		return nil
+	}
+	out := new(StructuredAuthorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetLayout) DeepCopyInto(out *SubnetLayout) {
+	*out = *in
+	if in.ZonePrefixLengths != nil {
+		in, out := &in.ZonePrefixLengths, &out.ZonePrefixLengths
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetLayout.
+func (in *SubnetLayout) DeepCopy() *SubnetLayout {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetLayout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserValidationRule) DeepCopyInto(out *UserValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserValidationRule.
+func (in *UserValidationRule) DeepCopy() *UserValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(UserValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeTuning) DeepCopyInto(out *VolumeTuning) {
+	*out = *in
+	if in.IOPS != nil {
+		in, out := &in.IOPS, &out.IOPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Throughput != nil {
+		in, out := &in.Throughput, &out.Throughput
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeTuning.
+func (in *VolumeTuning) DeepCopy() *VolumeTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAuthorizer) DeepCopyInto(out *WebhookAuthorizer) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AuthorizedTTL != nil {
+		in, out := &in.AuthorizedTTL, &out.AuthorizedTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UnauthorizedTTL != nil {
+		in, out := &in.UnauthorizedTTL, &out.UnauthorizedTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MatchConditions != nil {
+		in, out := &in.MatchConditions, &out.MatchConditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAuthorizer.
+func (in *WebhookAuthorizer) DeepCopy() *WebhookAuthorizer {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAuthorizer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerTuning) DeepCopyInto(out *WorkerTuning) {
+	*out = *in
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(VolumeTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]DataVolumeTuning, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LocalSSDInterface != nil {
+		in, out := &in.LocalSSDInterface, &out.LocalSSDInterface
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerTuning.
+func (in *WorkerTuning) DeepCopy() *WorkerTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerZones) DeepCopyInto(out *WorkerZones) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerZones.
+func (in *WorkerZones) DeepCopy() *WorkerZones {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerZones)
+	in.DeepCopyInto(out)
+	return out
+}
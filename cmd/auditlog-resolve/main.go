@@ -0,0 +1,70 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// auditlog-resolve shows which rule of the audit log tenant configuration is used for a provider type and region
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+)
+
+func main() {
+	var configPath string
+	var providerType string
+	var region string
+
+	flag.StringVar(&configPath, "config", "", "A file path to the audit log tenant configuration.")
+	flag.StringVar(&providerType, "provider", "", "The provider type of the runtime, e.g. aws.")
+	flag.StringVar(&region, "region", "", "The region of the runtime, e.g. eu-central-1.")
+	flag.Parse()
+
+	if configPath == "" || providerType == "" || region == "" {
+		fmt.Fprintln(os.Stderr, "-config, -provider and -region must be set")
+		os.Exit(2)
+	}
+
+	file, err := os.Open(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to open audit log tenant configuration: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	configuration, err := auditlogs.LoadConfiguration(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid audit log tenant configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	match, err := configuration.Resolve(providerType, region)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("provider: %s\nregion: %s\nmatched rule: %s\n", providerType, region, match.Rule)
+
+	if match.AuditLogData.Disabled {
+		fmt.Println("audit log: disabled")
+		return
+	}
+
+	fmt.Printf("tenantID: %s\nserviceURL: %s\nsecretName: %s\n", match.AuditLogData.TenantID, match.AuditLogData.ServiceURL, match.AuditLogData.SecretName)
+}
@@ -18,7 +18,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	registrycachecontroller "github.com/kyma-project/infrastructure-manager/internal/controller/registrycache"
@@ -34,6 +33,7 @@ import (
 	"github.com/go-logr/logr"
 	validator "github.com/go-playground/validator/v10"
 	infrastructuremanagerv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/auditlogconfig"
 	kubeconfigcontroller "github.com/kyma-project/infrastructure-manager/internal/controller/kubeconfig"
 	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
 	runtimecontroller "github.com/kyma-project/infrastructure-manager/internal/controller/runtime"
@@ -41,7 +41,6 @@ import (
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/kubeconfig"
-	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -88,6 +87,9 @@ const (
 	defaultShootReconcileRequeueDuration = 30 * time.Second
 	defaultRuntimeCtrlWorkersCnt         = 25
 	defaultGardenerClusterCtrlWorkersCnt = 25
+	defaultAuditLogRepatchQPS            = 0.2
+	defaultAuditLogRepatchBurst          = 1
+	defaultJWKSRefreshInterval           = time.Hour
 )
 
 func main() {
@@ -108,6 +110,9 @@ func main() {
 	var auditLogMandatory bool
 	var structuredAuthEnabled bool
 	var registryCacheConfigControllerEnabled bool
+	var auditLogRepatchQPS float64
+	var auditLogRepatchBurst int
+	var jwksRefreshInterval time.Duration
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -126,6 +131,9 @@ func main() {
 	flag.IntVar(&gardenerClusterCtrlWorkersCnt, "gardener-cluster-ctrl-workers-cnt", defaultGardenerClusterCtrlWorkersCnt, "A number of workers running in parallel for Gardener Cluster Controller")
 	flag.StringVar(&converterConfigFilepath, "converter-config-filepath", "/converter-config/converter_config.json", "A file path to the gardener shoot converter configuration.")
 	flag.BoolVar(&auditLogMandatory, "audit-log-mandatory", true, "Feature flag to enable strict mode for audit log configuration")
+	flag.Float64Var(&auditLogRepatchQPS, "audit-log-repatch-qps", defaultAuditLogRepatchQPS, "Rate of runtimes patched per second after the audit log tenant configuration changed")
+	flag.IntVar(&auditLogRepatchBurst, "audit-log-repatch-burst", defaultAuditLogRepatchBurst, "Burst of runtimes patched after the audit log tenant configuration changed")
+	flag.DurationVar(&jwksRefreshInterval, "jwks-refresh-interval", defaultJWKSRefreshInterval, "Interval of refreshing the signing keys pinned for OIDC issuers, 0 disables the refresh")
 	flag.BoolVar(&structuredAuthEnabled, "structured-auth-enabled", false, "Feature flag to enable structured authentication")
 	flag.BoolVar(&registryCacheConfigControllerEnabled, "custom-config-controller-enabled", false, "Feature flag to custom config controller")
 
@@ -210,12 +218,27 @@ func main() {
 		os.Exit(1)
 	}
 
-	auditLogDataMap, err := loadAuditLogDataMap(config.ConverterConfig.AuditLog.TenantConfigPath)
-	if err != nil {
+	auditLogStore := auditlogconfig.NewStore()
+	auditLogReloader := auditlogconfig.NewReloader(
+		config.ConverterConfig.AuditLog.TenantConfigPath,
+		auditLogStore,
+		mgr.GetClient(),
+		metrics,
+		flowcontrol.NewTokenBucketRateLimiter(float32(auditLogRepatchQPS), auditLogRepatchBurst),
+		logger.WithName("auditlog-config-reloader"),
+	)
+
+	// runtimes which audit log status differs from the loaded configuration are patched once the reloader is started
+	if _, _, err = auditLogReloader.Load(); err != nil {
 		setupLog.Error(err, "invalid audit log tenant configuration")
 		os.Exit(1)
 	}
 
+	if err = mgr.Add(auditLogReloader); err != nil {
+		setupLog.Error(err, "unable to set up audit log tenant configuration reloader")
+		os.Exit(1)
+	}
+
 	cfg := fsm.RCCfg{
 		GardenerRequeueDuration:       defaultGardenerRequeueDuration,
 		RequeueDurationShootCreate:    defaultShootCreateRequeueDuration,
@@ -227,7 +250,8 @@ func main() {
 		Config:                        config,
 		AuditLogMandatory:             auditLogMandatory,
 		Metrics:                       metrics,
-		AuditLogging:                  auditLogDataMap,
+		AuditLogging:                  auditLogStore,
+		JWKSRefreshInterval:           jwksRefreshInterval,
 	}
 
 	runtimeReconciler := runtimecontroller.NewRuntimeReconciler(
@@ -314,29 +338,6 @@ func initGardenerClients(kubeconfigPath string, namespace string, timeout time.D
 	return gardenerClient, shootClient, dynamicKubeconfigAPI, nil
 }
 
-func loadAuditLogDataMap(p string) (auditlogs.Configuration, error) {
-	file, err := os.Open(p)
-	if err != nil {
-		return nil, err
-	}
-
-	var data auditlogs.Configuration
-	if err := json.NewDecoder(file).Decode(&data); err != nil {
-		return nil, err
-	}
-	validate := validator.New(validator.WithRequiredStructEnabled())
-
-	for _, nestedMap := range data {
-		for _, auditLogData := range nestedMap {
-			if err := validate.Struct(auditLogData); err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	return data, nil
-}
-
 func refreshRuntimeMetrics(restConfig *rest.Config, logger logr.Logger, metrics metrics.Metrics) {
 	k8sClient, err := client.New(restConfig, client.Options{})
 	if err != nil {
@@ -0,0 +1,280 @@
+package auditlogconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Store keeps the audit log tenant configuration used by the runtime controller, the configuration is swapped atomically on reload
+type Store struct {
+	configuration atomic.Pointer[auditlogs.Configuration]
+}
+
+func NewStore() *Store {
+	store := &Store{}
+	store.configuration.Store(&auditlogs.Configuration{})
+	return store
+}
+
+func (s *Store) GetAuditLogData(providerType, region string) (auditlogs.AuditLogData, error) {
+	return s.Get().GetAuditLogData(providerType, region)
+}
+
+func (s *Store) Get() auditlogs.Configuration {
+	return *s.configuration.Load()
+}
+
+func (s *Store) swap(configuration auditlogs.Configuration) auditlogs.Configuration {
+	return *s.configuration.Swap(&configuration)
+}
+
+// Reloader watches the audit log tenant configuration file and swaps the configuration in the Store when a valid change is found.
+// Runtimes which audit log data changed are annotated for a forced patch, at the pace allowed by the rate limiter.
+// The patches are requested by a worker running next to the file watcher, a newer configuration cancels the running worker.
+type Reloader struct {
+	path      string
+	store     *Store
+	kcpClient client.Client
+	metrics   metrics.Metrics
+	limiter   flowcontrol.RateLimiter
+	log       logr.Logger
+	hash      string
+
+	mu           sync.Mutex
+	patchRequest *patchRequest
+}
+
+// patchRequest is the worker requesting patches of the runtimes affected by a configuration change
+type patchRequest struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	// previous is the configuration the runtimes were patched with before the change
+	previous  auditlogs.Configuration
+	completed bool
+}
+
+func NewReloader(path string, store *Store, kcpClient client.Client, metrics metrics.Metrics, limiter flowcontrol.RateLimiter, log logr.Logger) *Reloader {
+	return &Reloader{
+		path:      path,
+		store:     store,
+		kcpClient: kcpClient,
+		metrics:   metrics,
+		limiter:   limiter,
+		log:       log,
+	}
+}
+
+// Start watches the directory of the configuration file until the context is cancelled.
+// The directory is watched, as the files of mounted ConfigMaps are replaced by swapping symlinks.
+func (r *Reloader) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "failed to create audit log configuration watcher")
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(r.path)); err != nil {
+		return errors.Wrap(err, "failed to watch audit log configuration")
+	}
+
+	defer r.stopPatch()
+
+	// runtimes which were not patched before the restart still report the audit log tenant of the previous configuration
+	r.startPatch(ctx, r.store.Get())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if event.Has(fsnotify.Chmod) {
+				continue
+			}
+
+			if err := r.Reload(ctx); err != nil {
+				r.log.Error(err, "Failed to reload audit log configuration")
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			r.log.Error(err, "Audit log configuration watcher error")
+		}
+	}
+}
+
+// Load reads and validates the configuration file, and swaps it into the Store when it has changed.
+// The replaced configuration is returned, an invalid configuration is rejected and the current one is kept.
+func (r *Reloader) Load() (previous auditlogs.Configuration, changed bool, err error) {
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		r.metrics.IncAuditLogConfigReloadErrorCounter()
+		return nil, false, errors.Wrap(err, "failed to read audit log configuration")
+	}
+
+	hash := configurationHash(content)
+	if hash == r.hash {
+		return nil, false, nil
+	}
+
+	configuration, err := auditlogs.LoadConfiguration(bytes.NewReader(content))
+	if err != nil {
+		r.metrics.IncAuditLogConfigReloadErrorCounter()
+		return nil, false, errors.Wrap(err, "invalid audit log configuration")
+	}
+
+	previous = r.store.swap(configuration)
+	r.hash = hash
+	r.metrics.SetAuditLogConfigHash(hash)
+	r.log.Info("Audit log configuration loaded", "hash", hash)
+
+	return previous, true, nil
+}
+
+// Reload loads the configuration file and starts the worker requesting a patch of the runtimes which audit log data changed
+func (r *Reloader) Reload(ctx context.Context) error {
+	previous, changed, err := r.Load()
+	if err != nil || !changed {
+		return err
+	}
+
+	r.startPatch(ctx, previous)
+	return nil
+}
+
+// startPatch cancels the running worker and starts a new one for the current configuration.
+// Runtimes not patched by a cancelled worker are compared with the configuration it was started for, so that none of them is skipped.
+func (r *Reloader) startPatch(ctx context.Context, previous auditlogs.Configuration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if running := r.patchRequest; running != nil {
+		running.cancel()
+		<-running.done
+
+		if !running.completed {
+			previous = running.previous
+		}
+	}
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	request := &patchRequest{
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		previous: previous,
+	}
+	r.patchRequest = request
+
+	current := r.store.Get()
+
+	go func() {
+		defer close(request.done)
+
+		err := r.requestPatch(workerCtx, previous, current)
+		if err == nil {
+			request.completed = true
+			return
+		}
+
+		if !errors.Is(err, context.Canceled) {
+			r.log.Error(err, "Failed to request patch of runtimes after audit log configuration change")
+		}
+	}()
+}
+
+// stopPatch cancels the running worker and waits until it is finished
+func (r *Reloader) stopPatch() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.patchRequest != nil {
+		r.patchRequest.cancel()
+		<-r.patchRequest.done
+	}
+}
+
+func (r *Reloader) requestPatch(ctx context.Context, previous, current auditlogs.Configuration) error {
+	var runtimes imv1.RuntimeList
+	if err := r.kcpClient.List(ctx, &runtimes); err != nil {
+		return errors.Wrap(err, "failed to list runtimes affected by audit log configuration change")
+	}
+
+	for _, runtime := range runtimes.Items {
+		if !runtime.DeletionTimestamp.IsZero() || reconciler.ShouldForceReconciliation(runtime.Annotations) {
+			continue
+		}
+
+		if !auditLogDataChanged(previous, current, runtime) && !auditLogStatusOutdated(current, runtime) {
+			continue
+		}
+
+		if err := r.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		patch := client.MergeFrom(runtime.DeepCopy())
+		if runtime.Annotations == nil {
+			runtime.Annotations = map[string]string{}
+		}
+		runtime.Annotations[reconciler.ForceReconcileAnnotation] = "true"
+
+		if err := r.kcpClient.Patch(ctx, &runtime, patch); err != nil {
+			r.log.Error(err, "Failed to request patch of runtime after audit log configuration change", "runtime", runtime.Name)
+			continue
+		}
+
+		r.log.Info("Requested patch of runtime after audit log configuration change", "runtime", runtime.Name)
+	}
+
+	return nil
+}
+
+func auditLogDataChanged(previous, current auditlogs.Configuration, runtime imv1.Runtime) bool {
+	providerType, region := runtime.Spec.Shoot.Provider.Type, runtime.Spec.Shoot.Region
+
+	previousData, previousErr := previous.GetAuditLogData(providerType, region)
+	currentData, currentErr := current.GetAuditLogData(providerType, region)
+
+	return previousData != currentData || (previousErr == nil) != (currentErr == nil)
+}
+
+// auditLogStatusOutdated checks if the audit log tenant reported in the runtime status differs from the configured one.
+// Runtimes which have no audit log configured yet are skipped, they are configured when they are provisioned.
+func auditLogStatusOutdated(current auditlogs.Configuration, runtime imv1.Runtime) bool {
+	status := runtime.Status.AuditLog
+	if status == nil {
+		return false
+	}
+
+	data, err := current.GetAuditLogData(runtime.Spec.Shoot.Provider.Type, runtime.Spec.Shoot.Region)
+	if err != nil || data == (auditlogs.AuditLogData{}) {
+		return false
+	}
+
+	return status.TenantID != data.TenantID || status.SecretName != data.SecretName
+}
+
+func configurationHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
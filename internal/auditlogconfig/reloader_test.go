@@ -0,0 +1,299 @@
+package auditlogconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	initialConfiguration = `{
+  "aws": {
+    "eu-central-1": {"tenantID": "tenant-1", "serviceURL": "https://auditlog.eu10.example.com", "secretName": "auditlog-secret"},
+    "us-east-1": {"tenantID": "tenant-2", "serviceURL": "https://auditlog.us10.example.com", "secretName": "auditlog-secret"}
+  }
+}`
+	usChangedConfiguration = `{
+  "aws": {
+    "eu-central-1": {"tenantID": "tenant-3", "serviceURL": "https://auditlog.eu10.example.com", "secretName": "auditlog-secret"},
+    "us-east-1": {"tenantID": "tenant-4", "serviceURL": "https://auditlog.us10.example.com", "secretName": "auditlog-secret"}
+  }
+}`
+	changedConfiguration = `{
+  "aws": {
+    "eu-central-1": {"tenantID": "tenant-3", "serviceURL": "https://auditlog.eu10.example.com", "secretName": "auditlog-secret"},
+    "us-east-1": {"tenantID": "tenant-2", "serviceURL": "https://auditlog.us10.example.com", "secretName": "auditlog-secret"}
+  }
+}`
+	invalidConfiguration = `{
+  "aws": {
+    "eu-central-1": {"tenantID": "", "serviceURL": "https://auditlog.eu10.example.com", "secretName": "auditlog-secret"}
+  }
+}`
+)
+
+func TestReloader(t *testing.T) {
+	t.Run("Should swap changed configuration and request patch of affected runtimes", func(t *testing.T) {
+		// given
+		metricsMock := &mocks.Metrics{}
+		metricsMock.On("SetAuditLogConfigHash", mock.Anything).Return().Twice()
+
+		path := writeConfiguration(t, t.TempDir(), initialConfiguration)
+		kcpClient := fixKcpClient(t,
+			fixRuntime("runtime-eu", "eu-central-1"),
+			fixRuntime("runtime-us", "us-east-1"))
+		store := NewStore()
+		reloader := NewReloader(path, store, kcpClient, metricsMock, flowcontrol.NewFakeAlwaysRateLimiter(), logr.Discard())
+
+		_, _, err := reloader.Load()
+		require.NoError(t, err)
+
+		// when
+		writeConfiguration(t, filepath.Dir(path), changedConfiguration)
+		err = reloader.Reload(context.Background())
+		waitForPatch(reloader)
+
+		// then
+		require.NoError(t, err)
+		metricsMock.AssertExpectations(t)
+
+		data, err := store.GetAuditLogData("aws", "eu-central-1")
+		require.NoError(t, err)
+		assert.Equal(t, "tenant-3", data.TenantID)
+
+		assert.True(t, forcePatchRequested(t, kcpClient, "runtime-eu"))
+		assert.False(t, forcePatchRequested(t, kcpClient, "runtime-us"))
+	})
+
+	t.Run("Should keep current configuration when reloaded configuration is invalid", func(t *testing.T) {
+		// given
+		metricsMock := &mocks.Metrics{}
+		metricsMock.On("SetAuditLogConfigHash", mock.Anything).Return().Once()
+		metricsMock.On("IncAuditLogConfigReloadErrorCounter").Return().Once()
+
+		path := writeConfiguration(t, t.TempDir(), initialConfiguration)
+		kcpClient := fixKcpClient(t, fixRuntime("runtime-eu", "eu-central-1"))
+		store := NewStore()
+		reloader := NewReloader(path, store, kcpClient, metricsMock, flowcontrol.NewFakeAlwaysRateLimiter(), logr.Discard())
+
+		_, _, err := reloader.Load()
+		require.NoError(t, err)
+
+		// when
+		writeConfiguration(t, filepath.Dir(path), invalidConfiguration)
+		err = reloader.Reload(context.Background())
+		waitForPatch(reloader)
+
+		// then
+		require.Error(t, err)
+		metricsMock.AssertExpectations(t)
+
+		data, err := store.GetAuditLogData("aws", "eu-central-1")
+		require.NoError(t, err)
+		assert.Equal(t, "tenant-1", data.TenantID)
+		assert.False(t, forcePatchRequested(t, kcpClient, "runtime-eu"))
+	})
+
+	t.Run("Should not request patch when configuration content did not change", func(t *testing.T) {
+		// given
+		metricsMock := &mocks.Metrics{}
+		metricsMock.On("SetAuditLogConfigHash", mock.Anything).Return().Once()
+
+		path := writeConfiguration(t, t.TempDir(), initialConfiguration)
+		kcpClient := fixKcpClient(t, fixRuntime("runtime-eu", "eu-central-1"))
+		reloader := NewReloader(path, NewStore(), kcpClient, metricsMock, flowcontrol.NewFakeAlwaysRateLimiter(), logr.Discard())
+
+		_, _, err := reloader.Load()
+		require.NoError(t, err)
+
+		// when
+		err = reloader.Reload(context.Background())
+		waitForPatch(reloader)
+
+		// then
+		require.NoError(t, err)
+		metricsMock.AssertExpectations(t)
+		assert.False(t, forcePatchRequested(t, kcpClient, "runtime-eu"))
+	})
+
+	t.Run("Should reload configuration when the watched file changes", func(t *testing.T) {
+		// given
+		metricsMock := &mocks.Metrics{}
+		metricsMock.On("SetAuditLogConfigHash", mock.Anything).Return()
+
+		path := writeConfiguration(t, t.TempDir(), initialConfiguration)
+		store := NewStore()
+		reloader := NewReloader(path, store, fixKcpClient(t), metricsMock, flowcontrol.NewFakeAlwaysRateLimiter(), logr.Discard())
+
+		_, _, err := reloader.Load()
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			_ = reloader.Start(ctx)
+		}()
+
+		// when
+		assert.Eventually(t, func() bool {
+			writeConfiguration(t, filepath.Dir(path), changedConfiguration)
+			data, err := store.GetAuditLogData("aws", "eu-central-1")
+			return err == nil && data.TenantID == "tenant-3"
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
+
+func TestReloaderPatchWorker(t *testing.T) {
+	t.Run("Should request patch of runtimes with outdated audit log status on start", func(t *testing.T) {
+		// given
+		metricsMock := &mocks.Metrics{}
+		metricsMock.On("SetAuditLogConfigHash", mock.Anything).Return()
+
+		path := writeConfiguration(t, t.TempDir(), changedConfiguration)
+		kcpClient := fixKcpClient(t,
+			fixRuntimeWithAuditLogStatus("runtime-outdated", "eu-central-1", "tenant-1"),
+			fixRuntimeWithAuditLogStatus("runtime-current", "us-east-1", "tenant-2"),
+			fixRuntime("runtime-provisioning", "eu-central-1"))
+		reloader := NewReloader(path, NewStore(), kcpClient, metricsMock, flowcontrol.NewFakeAlwaysRateLimiter(), logr.Discard())
+
+		_, _, err := reloader.Load()
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// when
+		go func() {
+			_ = reloader.Start(ctx)
+		}()
+
+		// then
+		assert.Eventually(t, func() bool {
+			return forcePatchRequested(t, kcpClient, "runtime-outdated")
+		}, 5*time.Second, 100*time.Millisecond)
+		waitForPatch(reloader)
+
+		assert.False(t, forcePatchRequested(t, kcpClient, "runtime-current"))
+		assert.False(t, forcePatchRequested(t, kcpClient, "runtime-provisioning"))
+	})
+
+	t.Run("Should cancel running patch worker when newer configuration is loaded", func(t *testing.T) {
+		// given
+		metricsMock := &mocks.Metrics{}
+		metricsMock.On("SetAuditLogConfigHash", mock.Anything).Return()
+
+		path := writeConfiguration(t, t.TempDir(), initialConfiguration)
+		kcpClient := fixKcpClient(t,
+			fixRuntime("runtime-eu", "eu-central-1"),
+			fixRuntime("runtime-us", "us-east-1"))
+		limiter := &blockingFirstWaitLimiter{RateLimiter: flowcontrol.NewFakeAlwaysRateLimiter(), blocked: make(chan struct{})}
+		reloader := NewReloader(path, NewStore(), kcpClient, metricsMock, limiter, logr.Discard())
+
+		_, _, err := reloader.Load()
+		require.NoError(t, err)
+
+		writeConfiguration(t, filepath.Dir(path), changedConfiguration)
+		require.NoError(t, reloader.Reload(context.Background()))
+		<-limiter.blocked
+
+		// when
+		writeConfiguration(t, filepath.Dir(path), usChangedConfiguration)
+		err = reloader.Reload(context.Background())
+		waitForPatch(reloader)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, limiter.cancelled)
+		assert.True(t, forcePatchRequested(t, kcpClient, "runtime-eu"))
+		assert.True(t, forcePatchRequested(t, kcpClient, "runtime-us"))
+	})
+}
+
+// blockingFirstWaitLimiter blocks the first wait until the context is cancelled
+type blockingFirstWaitLimiter struct {
+	flowcontrol.RateLimiter
+	blocked   chan struct{}
+	waits     int
+	cancelled bool
+}
+
+func (l *blockingFirstWaitLimiter) Wait(ctx context.Context) error {
+	l.waits++
+	if l.waits > 1 {
+		return nil
+	}
+
+	close(l.blocked)
+	<-ctx.Done()
+	l.cancelled = true
+	return ctx.Err()
+}
+
+func waitForPatch(reloader *Reloader) {
+	reloader.mu.Lock()
+	request := reloader.patchRequest
+	reloader.mu.Unlock()
+
+	if request != nil {
+		<-request.done
+	}
+}
+
+// writeConfiguration replaces the configuration file atomically, the way mounted ConfigMaps are updated
+func writeConfiguration(t *testing.T, dir, content string) string {
+	path := filepath.Join(dir, "config.json")
+	tmpPath := filepath.Join(dir, ".config.json.tmp")
+	require.NoError(t, os.WriteFile(tmpPath, []byte(content), 0o600))
+	require.NoError(t, os.Rename(tmpPath, path))
+	return path
+}
+
+func fixKcpClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, imv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func fixRuntime(name, region string) *imv1.Runtime {
+	runtime := &imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kcp-system",
+		},
+	}
+	runtime.Spec.Shoot.Provider.Type = "aws"
+	runtime.Spec.Shoot.Region = region
+	return runtime
+}
+
+func fixRuntimeWithAuditLogStatus(name, region, tenantID string) *imv1.Runtime {
+	runtime := fixRuntime(name, region)
+	runtime.Status.AuditLog = &imv1.AuditLogStatus{
+		TenantID:   tenantID,
+		SecretName: "auditlog-secret",
+	}
+	return runtime
+}
+
+func forcePatchRequested(t *testing.T, kcpClient client.Client, name string) bool {
+	var runtime imv1.Runtime
+	require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "kcp-system"}, &runtime))
+	return reconciler.ShouldForceReconciliation(runtime.Annotations)
+}
@@ -41,7 +41,7 @@ import (
 
 const (
 	lastKubeconfigSyncAnnotation      = "operator.kyma-project.io/last-sync"
-	forceKubeconfigRotationAnnotation = "operator.kyma-project.io/force-kubeconfig-rotation"
+	forceKubeconfigRotationAnnotation = imv1.AnnotationForceKubeconfigRotation
 	clusterCRNameLabel                = "operator.kyma-project.io/cluster-name"
 
 	rotationPeriodRatio = 0.95
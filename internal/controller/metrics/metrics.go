@@ -29,6 +29,13 @@ const (
 	KubeconfigExpirationMetricName = "im_kubeconfig_expiration"
 	expires                        = "expires"
 	lastSyncAnnotation             = "operator.kyma-project.io/last-sync"
+	SSHAccessMetricName            = "im_ssh_access_total"
+	action                         = "action"
+	SSHAccessGranted               = "granted"
+	SSHAccessRevoked               = "revoked"
+	AuditLogConfigMetricName       = "im_auditlog_config_info"
+	AuditLogConfigReloadErrName    = "im_auditlog_config_reload_errors_total"
+	hash                           = "hash"
 )
 
 //go:generate mockery --name=Metrics
@@ -41,6 +48,9 @@ type Metrics interface {
 	CleanUpGardenerClusterGauge(runtimeID string)
 	CleanUpKubeconfigExpiration(runtimeID string)
 	SetKubeconfigExpiration(secret corev1.Secret, rotationPeriod time.Duration, minimalRotationTimeRatio float64)
+	IncSSHAccessCounter(runtimeID, action string)
+	SetAuditLogConfigHash(hash string)
+	IncAuditLogConfigReloadErrorCounter()
 }
 
 type metricsImpl struct {
@@ -48,6 +58,9 @@ type metricsImpl struct {
 	kubeconfigExpirationGauge     *prometheus.GaugeVec
 	runtimeStateGauge             *prometheus.GaugeVec
 	runtimeFSMUnexpectedStopsCnt  prometheus.Counter
+	sshAccessCounterVec           *prometheus.CounterVec
+	auditLogConfigGauge           *prometheus.GaugeVec
+	auditLogConfigReloadErrCnt    prometheus.Counter
 }
 
 func NewMetrics() Metrics {
@@ -75,8 +88,26 @@ func NewMetrics() Metrics {
 				Name: RuntimeFSMStopMetricName,
 				Help: "Exposes the number of unexpected state machine stop events",
 			}),
+		sshAccessCounterVec: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: componentName,
+				Name:      SSHAccessMetricName,
+				Help:      "Exposes the number of granted and revoked SSH accesses to the worker nodes",
+			}, []string{runtimeIDKeyName, action}),
+		auditLogConfigGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem: componentName,
+				Name:      AuditLogConfigMetricName,
+				Help:      "Exposes the hash of the audit log tenant configuration currently in use",
+			}, []string{hash}),
+		auditLogConfigReloadErrCnt: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Subsystem: componentName,
+				Name:      AuditLogConfigReloadErrName,
+				Help:      "Exposes the number of audit log tenant configuration reloads rejected because of invalid configuration",
+			}),
 	}
-	ctrlMetrics.Registry.MustRegister(m.gardenerClustersStateGaugeVec, m.kubeconfigExpirationGauge, m.runtimeStateGauge, m.runtimeFSMUnexpectedStopsCnt)
+	ctrlMetrics.Registry.MustRegister(m.gardenerClustersStateGaugeVec, m.kubeconfigExpirationGauge, m.runtimeStateGauge, m.runtimeFSMUnexpectedStopsCnt, m.sshAccessCounterVec, m.auditLogConfigGauge, m.auditLogConfigReloadErrCnt)
 	return m
 }
 
@@ -111,6 +142,19 @@ func (m metricsImpl) IncRuntimeFSMStopCounter() {
 	m.runtimeFSMUnexpectedStopsCnt.Inc()
 }
 
+func (m metricsImpl) IncSSHAccessCounter(runtimeID, action string) {
+	m.sshAccessCounterVec.WithLabelValues(runtimeID, action).Inc()
+}
+
+func (m metricsImpl) SetAuditLogConfigHash(hash string) {
+	m.auditLogConfigGauge.Reset()
+	m.auditLogConfigGauge.WithLabelValues(hash).Set(1)
+}
+
+func (m metricsImpl) IncAuditLogConfigReloadErrorCounter() {
+	m.auditLogConfigReloadErrCnt.Inc()
+}
+
 func (m metricsImpl) SetGardenerClusterStates(cluster v1.GardenerCluster) {
 	var runtimeID = cluster.GetLabels()[RuntimeIDLabel]
 	var shootName = cluster.GetLabels()[ShootNameLabel]
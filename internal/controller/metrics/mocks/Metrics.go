@@ -30,16 +30,31 @@ func (_m *Metrics) CleanUpRuntimeGauge(runtimeID string, runtimeName string) {
 	_m.Called(runtimeID, runtimeName)
 }
 
+// IncAuditLogConfigReloadErrorCounter provides a mock function with given fields:
+func (_m *Metrics) IncAuditLogConfigReloadErrorCounter() {
+	_m.Called()
+}
+
 // IncRuntimeFSMStopCounter provides a mock function with given fields:
 func (_m *Metrics) IncRuntimeFSMStopCounter() {
 	_m.Called()
 }
 
+// IncSSHAccessCounter provides a mock function with given fields: runtimeID, action
+func (_m *Metrics) IncSSHAccessCounter(runtimeID string, action string) {
+	_m.Called(runtimeID, action)
+}
+
 // ResetRuntimeMetrics provides a mock function with given fields:
 func (_m *Metrics) ResetRuntimeMetrics() {
 	_m.Called()
 }
 
+// SetAuditLogConfigHash provides a mock function with given fields: hash
+func (_m *Metrics) SetAuditLogConfigHash(hash string) {
+	_m.Called(hash)
+}
+
 // SetGardenerClusterStates provides a mock function with given fields: cluster
 func (_m *Metrics) SetGardenerClusterStates(cluster v1.GardenerCluster) {
 	_m.Called(cluster)
@@ -0,0 +1,63 @@
+package fsm
+
+import (
+	"context"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// getAuditLogData returns the audit log data configured for the runtime provider type and region,
+// the audit log configuration can be reloaded while the controller is running.
+// The secret referenced by the audit log data must exist in the Gardener project, otherwise the shoot would reference missing credentials after a tenant migration.
+func getAuditLogData(ctx context.Context, s *systemState, m *fsm) (auditlogs.AuditLogData, error) {
+	if m.AuditLogging == nil {
+		return auditlogs.AuditLogData{}, auditlogs.ErrConfigurationNotFound
+	}
+
+	data, err := m.AuditLogging.GetAuditLogData(
+		s.instance.Spec.Shoot.Provider.Type,
+		s.instance.Spec.Shoot.Region)
+
+	if err != nil || data == (auditlogs.AuditLogData{}) {
+		return data, err
+	}
+
+	if err := verifyAuditLogSecret(ctx, m, data.SecretName); err != nil {
+		return auditlogs.AuditLogData{}, err
+	}
+
+	return data, nil
+}
+
+func verifyAuditLogSecret(ctx context.Context, m *fsm, secretName string) error {
+	var secret corev1.Secret
+	err := m.SeedClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: m.ShootNamesapace}, &secret)
+
+	if k8serrors.IsNotFound(err) {
+		return errors.Errorf("audit log secret %s does not exist in namespace %s", secretName, m.ShootNamesapace)
+	}
+
+	return errors.Wrapf(err, "failed to get audit log secret %s", secretName)
+}
+
+// updateAuditLogStatus reports the audit log tenant applied to the shoot, a changed tenant means the shoot was migrated to the new tenant
+func updateAuditLogStatus(m *fsm, runtime *imv1.Runtime, data auditlogs.AuditLogData) {
+	if data == (auditlogs.AuditLogData{}) {
+		return
+	}
+
+	current := runtime.Status.AuditLog
+	if current != nil && current.TenantID != data.TenantID {
+		m.log.Info("Audit log tenant migrated", "from", current.TenantID, "to", data.TenantID, "secretName", data.SecretName)
+	}
+
+	runtime.Status.AuditLog = &imv1.AuditLogStatus{
+		TenantID:   data.TenantID,
+		SecretName: data.SecretName,
+	}
+}
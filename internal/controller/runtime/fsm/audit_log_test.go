@@ -0,0 +1,103 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetAuditLogData(t *testing.T) {
+	data := auditlogs.AuditLogData{
+		TenantID:   "new-tenant",
+		ServiceURL: "http://auditlog-service",
+		SecretName: "new-tenant-secret",
+	}
+
+	for _, tc := range []struct {
+		name            string
+		objects         []client.Object
+		data            auditlogs.AuditLogData
+		expectedData    auditlogs.AuditLogData
+		expectedErrText string
+	}{
+		{
+			name:         "Should return audit log data when the secret exists in the project",
+			objects:      []client.Object{&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "new-tenant-secret", Namespace: "garden-kyma"}}},
+			data:         data,
+			expectedData: data,
+		},
+		{
+			name:            "Should fail when the secret does not exist in the project",
+			objects:         []client.Object{&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "new-tenant-secret", Namespace: "garden-other"}}},
+			data:            data,
+			expectedErrText: "audit log secret new-tenant-secret does not exist in namespace garden-kyma",
+		},
+		{
+			name: "Should not verify the secret when audit log is disabled for the region",
+			data: auditlogs.AuditLogData{Disabled: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			scheme, err := newCreateTestScheme()
+			require.NoError(t, err)
+
+			testFsm := &fsm{
+				log: logr.Discard(),
+				K8s: K8s{SeedClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()},
+				RCCfg: RCCfg{
+					ShootNamesapace: "garden-kyma",
+					AuditLogging: auditlogs.Configuration{
+						"aws": {"eu-central-1": tc.data},
+					},
+				},
+			}
+
+			runtime := imv1.Runtime{}
+			runtime.Spec.Shoot.Provider.Type = "aws"
+			runtime.Spec.Shoot.Region = "eu-central-1"
+
+			// when
+			actual, err := getAuditLogData(context.Background(), &systemState{instance: runtime}, testFsm)
+
+			// then
+			if tc.expectedErrText != "" {
+				require.EqualError(t, err, tc.expectedErrText)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedData, actual)
+		})
+	}
+}
+
+func TestUpdateAuditLogStatus(t *testing.T) {
+	testFsm := &fsm{log: logr.Discard()}
+
+	t.Run("Should report the tenant applied to the shoot", func(t *testing.T) {
+		runtime := imv1.Runtime{}
+		runtime.Status.AuditLog = &imv1.AuditLogStatus{TenantID: "old-tenant", SecretName: "old-tenant-secret"}
+
+		updateAuditLogStatus(testFsm, &runtime, auditlogs.AuditLogData{TenantID: "new-tenant", SecretName: "new-tenant-secret"})
+
+		assert.Equal(t, &imv1.AuditLogStatus{TenantID: "new-tenant", SecretName: "new-tenant-secret"}, runtime.Status.AuditLog)
+	})
+
+	t.Run("Should keep the status when no audit log data is configured", func(t *testing.T) {
+		runtime := imv1.Runtime{}
+		runtime.Status.AuditLog = &imv1.AuditLogStatus{TenantID: "old-tenant", SecretName: "old-tenant-secret"}
+
+		updateAuditLogStatus(testFsm, &runtime, auditlogs.AuditLogData{})
+
+		assert.Equal(t, &imv1.AuditLogStatus{TenantID: "old-tenant", SecretName: "old-tenant-secret"}, runtime.Status.AuditLog)
+	})
+}
@@ -0,0 +1,32 @@
+package fsm
+
+import (
+	"context"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/auditpolicy"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const msgFailedToConfigureAuditPolicy = "Failed to configure audit policy"
+
+// ensureAuditPolicy returns the ConfigMap name of the audit policy profile selected for the runtime, after making sure the ConfigMap exists in the garden project namespace.
+// An empty name is returned when no profile is selected, so the default audit policy ConfigMap is used.
+func ensureAuditPolicy(ctx context.Context, m *fsm, runtime imv1.Runtime) (string, error) {
+	profile, found, err := m.ConverterConfig.AuditLog.GetPolicyProfile(
+		ptr.Deref(runtime.Spec.Shoot.AuditPolicyProfile, ""),
+		runtime.Labels[imv1.LabelKymaBrokerPlanName])
+
+	if err != nil || !found {
+		return "", err
+	}
+
+	cmKey := types.NamespacedName{Name: profile.ConfigMapName, Namespace: m.ShootNamesapace}
+	if err := auditpolicy.EnsureAuditPolicyConfigMap(ctx, m.SeedClient, cmKey, profile.Policy); err != nil {
+		return "", errors.Wrap(err, "failed to ensure audit policy config map")
+	}
+
+	return profile.ConfigMapName, nil
+}
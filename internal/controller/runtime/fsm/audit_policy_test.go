@@ -0,0 +1,105 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/auditpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureAuditPolicy(t *testing.T) {
+	auditLogConfig := config.AuditLogConfig{
+		PolicyConfigMapName: "audit-policy-default",
+		PolicyProfiles: map[string]config.AuditPolicyProfile{
+			"strict": {ConfigMapName: "audit-policy-strict", Policy: "strict-policy"},
+			"light":  {ConfigMapName: "audit-policy-light", Policy: "light-policy"},
+		},
+		PlanPolicyProfiles: map[string]string{
+			"trial": "light",
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		plan            string
+		profile         *string
+		expectedCMName  string
+		expectedPolicy  string
+		expectedErrText string
+	}{
+		{
+			name:           "Should use the default policy when no profile is selected",
+			plan:           "aws",
+			expectedCMName: "",
+		},
+		{
+			name:           "Should use the profile configured for the plan",
+			plan:           "trial",
+			expectedCMName: "audit-policy-light",
+			expectedPolicy: "light-policy",
+		},
+		{
+			name:           "Should prefer the profile selected on the Runtime",
+			plan:           "trial",
+			profile:        ptr.To("strict"),
+			expectedCMName: "audit-policy-strict",
+			expectedPolicy: "strict-policy",
+		},
+		{
+			name:            "Should fail for a profile which is not configured",
+			plan:            "aws",
+			profile:         ptr.To("unknown"),
+			expectedErrText: "audit policy profile unknown is not configured",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			scheme, err := newCreateTestScheme()
+			require.NoError(t, err)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			testFsm := &fsm{
+				log: logr.Discard(),
+				K8s: K8s{SeedClient: fakeClient},
+				RCCfg: RCCfg{
+					ShootNamesapace: "garden-kyma",
+					Config:          config.Config{ConverterConfig: config.ConverterConfig{AuditLog: auditLogConfig}},
+				},
+			}
+
+			runtime := imv1.Runtime{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{imv1.LabelKymaBrokerPlanName: tc.plan},
+				},
+			}
+			runtime.Spec.Shoot.AuditPolicyProfile = tc.profile
+
+			// when
+			cmName, err := ensureAuditPolicy(context.Background(), testFsm, runtime)
+
+			// then
+			if tc.expectedErrText != "" {
+				require.EqualError(t, err, tc.expectedErrText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCMName, cmName)
+
+			if tc.expectedCMName != "" {
+				var cm v1.ConfigMap
+				require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: tc.expectedCMName, Namespace: "garden-kyma"}, &cm))
+				assert.Equal(t, tc.expectedPolicy, cm.Data[auditpolicy.PolicyKey])
+			}
+		})
+	}
+}
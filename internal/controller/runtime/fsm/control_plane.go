@@ -0,0 +1,69 @@
+package fsm
+
+import (
+	"context"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validateControlPlaneTransition verifies that Gardener accepts the change of the control plane high availability.
+// High availability can be enabled with node or zone failure tolerance, but the failure tolerance type cannot be changed or removed afterwards.
+func validateControlPlaneTransition(shoot *gardener.Shoot, desired *gardener.ControlPlane) error {
+	current := failureToleranceType(shoot.Spec.ControlPlane)
+	requested := failureToleranceType(desired)
+
+	if current == requested {
+		return nil
+	}
+
+	if current != "" && requested == "" {
+		return errors.Errorf("control plane high availability with %s failure tolerance cannot be disabled", current)
+	}
+
+	if current != "" {
+		return errors.Errorf("control plane failure tolerance cannot be changed from %s to %s", current, requested)
+	}
+
+	if v1beta1helper.HibernationIsEnabled(shoot) || shoot.Status.IsHibernated {
+		return errors.New("control plane high availability cannot be enabled while the shoot is hibernated")
+	}
+
+	return nil
+}
+
+// verifySeedForControlPlaneMigration verifies that the seed hosting the shoot control plane spans enough zones for zone failure tolerance
+func verifySeedForControlPlaneMigration(ctx context.Context, gardenClient client.Client, shoot *gardener.Shoot, desired *gardener.ControlPlane) error {
+	if !zoneFailureToleranceRequested(desired) || shoot.Spec.SeedName == nil {
+		return nil
+	}
+
+	var seed gardener.Seed
+	if err := gardenClient.Get(ctx, client.ObjectKey{Name: *shoot.Spec.SeedName}, &seed); err != nil {
+		return errors.Wrapf(err, "failed to get seed %s", *shoot.Spec.SeedName)
+	}
+
+	if !seedSupportsZoneFailureTolerance(&seed) {
+		return errors.Errorf("seed %s spans %d zones, but zone failure tolerance requires at least %d zones", seed.Name, len(seed.Spec.Provider.Zones), haZonesCount)
+	}
+
+	return nil
+}
+
+func controlPlaneHAMigrationRequested(shoot *gardener.Shoot, desired *gardener.ControlPlane) bool {
+	return failureToleranceType(shoot.Spec.ControlPlane) == "" && failureToleranceType(desired) != ""
+}
+
+func zoneFailureToleranceRequested(controlPlane *gardener.ControlPlane) bool {
+	return failureToleranceType(controlPlane) == gardener.FailureToleranceTypeZone
+}
+
+func failureToleranceType(controlPlane *gardener.ControlPlane) gardener.FailureToleranceType {
+	if controlPlane == nil || controlPlane.HighAvailability == nil {
+		return ""
+	}
+
+	return controlPlane.HighAvailability.FailureTolerance.Type
+}
@@ -0,0 +1,191 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateControlPlaneTransition(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		current       *gardener.ControlPlane
+		desired       *gardener.ControlPlane
+		hibernated    bool
+		expectedError string
+	}{
+		{
+			name: "Allow unchanged control plane without high availability",
+		},
+		{
+			name:    "Allow enabling node failure tolerance",
+			desired: fixControlPlane(gardener.FailureToleranceTypeNode),
+		},
+		{
+			name:    "Allow enabling zone failure tolerance",
+			desired: fixControlPlane(gardener.FailureToleranceTypeZone),
+		},
+		{
+			name:    "Allow unchanged zone failure tolerance",
+			current: fixControlPlane(gardener.FailureToleranceTypeZone),
+			desired: fixControlPlane(gardener.FailureToleranceTypeZone),
+		},
+		{
+			name:          "Reject changing zone to node failure tolerance",
+			current:       fixControlPlane(gardener.FailureToleranceTypeZone),
+			desired:       fixControlPlane(gardener.FailureToleranceTypeNode),
+			expectedError: "control plane failure tolerance cannot be changed from zone to node",
+		},
+		{
+			name:          "Reject changing node to zone failure tolerance",
+			current:       fixControlPlane(gardener.FailureToleranceTypeNode),
+			desired:       fixControlPlane(gardener.FailureToleranceTypeZone),
+			expectedError: "control plane failure tolerance cannot be changed from node to zone",
+		},
+		{
+			name:          "Reject disabling high availability",
+			current:       fixControlPlane(gardener.FailureToleranceTypeNode),
+			expectedError: "control plane high availability with node failure tolerance cannot be disabled",
+		},
+		{
+			name:          "Reject enabling high availability for hibernated shoot",
+			desired:       fixControlPlane(gardener.FailureToleranceTypeNode),
+			hibernated:    true,
+			expectedError: "control plane high availability cannot be enabled while the shoot is hibernated",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			shoot := &gardener.Shoot{
+				Spec:   gardener.ShootSpec{ControlPlane: tc.current},
+				Status: gardener.ShootStatus{IsHibernated: tc.hibernated},
+			}
+
+			// when
+			err := validateControlPlaneTransition(shoot, tc.desired)
+
+			// then
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestControlPlaneSeedVerification(t *testing.T) {
+	multiZonalSeed := fixReadySeed("seed-multi-zonal", "eu-central-1", "eu-central-1a", "eu-central-1b", "eu-central-1c")
+	singleZonalSeed := fixReadySeed("seed-single-zonal", "eu-west-1", "eu-west-1a")
+
+	scheme, err := newCreateTestScheme()
+	require.NoError(t, err)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(multiZonalSeed, singleZonalSeed).Build()
+
+	t.Run("Find seeds for zone failure tolerance only in regions with multi zonal seeds", func(t *testing.T) {
+		// when
+		available, regions, err := seedForRegionAvailable(context.Background(), fakeClient, "aws", "eu-west-1", true)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, available)
+		assert.Equal(t, []string{"eu-central-1"}, regions)
+
+		// when
+		available, _, err = seedForRegionAvailable(context.Background(), fakeClient, "aws", "eu-west-1", false)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, available)
+	})
+
+	t.Run("Verify seeds for zone failure tolerance also when seed location is not enforced", func(t *testing.T) {
+		// given
+		var shoot imv1.RuntimeShoot
+		shoot.Provider.Type = "aws"
+		shoot.Region = "eu-west-1"
+		shoot.ControlPlane = fixControlPlane(gardener.FailureToleranceTypeZone)
+
+		// when
+		available, regions, err := seedAvailableForShoot(context.Background(), fakeClient, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, available)
+		assert.Equal(t, []string{"eu-central-1"}, regions)
+
+		// when
+		shoot.EnforceSeedLocation = ptr.To(true)
+		available, _, err = seedAvailableForShoot(context.Background(), fakeClient, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, available)
+
+		// when
+		shoot.Provider.Type = "gcp"
+		shoot.EnforceSeedLocation = nil
+		available, _, err = seedAvailableForShoot(context.Background(), fakeClient, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, available)
+	})
+
+	t.Run("Verify seed hosting the control plane for zone failure tolerance", func(t *testing.T) {
+		// given
+		shoot := &gardener.Shoot{Spec: gardener.ShootSpec{SeedName: ptr.To("seed-single-zonal")}}
+
+		// when
+		err := verifySeedForControlPlaneMigration(context.Background(), fakeClient, shoot, fixControlPlane(gardener.FailureToleranceTypeZone))
+
+		// then
+		assert.EqualError(t, err, "seed seed-single-zonal spans 1 zones, but zone failure tolerance requires at least 3 zones")
+
+		// when
+		err = verifySeedForControlPlaneMigration(context.Background(), fakeClient, shoot, fixControlPlane(gardener.FailureToleranceTypeNode))
+
+		// then
+		assert.NoError(t, err)
+
+		// when
+		shoot.Spec.SeedName = ptr.To("seed-multi-zonal")
+		err = verifySeedForControlPlaneMigration(context.Background(), fakeClient, shoot, fixControlPlane(gardener.FailureToleranceTypeZone))
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func fixControlPlane(failureToleranceType gardener.FailureToleranceType) *gardener.ControlPlane {
+	return &gardener.ControlPlane{
+		HighAvailability: &gardener.HighAvailability{
+			FailureTolerance: gardener.FailureTolerance{Type: failureToleranceType},
+		},
+	}
+}
+
+func fixReadySeed(name, region string, zones ...string) *gardener.Seed {
+	return &gardener.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: gardener.SeedSpec{
+			Provider: gardener.SeedProvider{Type: "aws", Region: region, Zones: zones},
+			Settings: &gardener.SeedSettings{
+				Scheduling: &gardener.SeedSettingScheduling{Visible: true},
+			},
+		},
+		Status: gardener.SeedStatus{
+			LastOperation: &gardener.LastOperation{State: gardener.LastOperationStateSucceeded},
+			Conditions: []gardener.Condition{
+				{Type: gardener.SeedGardenletReady, Status: gardener.ConditionTrue},
+			},
+		},
+	}
+}
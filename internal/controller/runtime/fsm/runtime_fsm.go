@@ -34,7 +34,8 @@ type RCCfg struct {
 	ShootNamesapace               string
 	AuditLogMandatory             bool
 	Metrics                       metrics.Metrics
-	AuditLogging                  auditlogs.Configuration
+	AuditLogging                  auditlogs.DataProvider
+	JWKSRefreshInterval           time.Duration
 	config.Config
 }
 
@@ -92,13 +93,19 @@ loop:
 		WithValues("result", result).
 		Info("Reconciliation done")
 
+	finalResult := ctrl.Result{
+		Requeue: false,
+	}
+
 	if result != nil {
-		return *result, err
+		finalResult = *result
 	}
 
-	return ctrl.Result{
-		Requeue: false,
-	}, err
+	now := time.Now()
+	finalResult = withSSHAccessExpiryRequeue(finalResult, &state.instance, now)
+	finalResult = withHibernationScheduleRequeue(finalResult, &state.instance, state.shoot)
+
+	return withJWKSRefreshRequeue(finalResult, &state.instance, m.JWKSRefreshInterval, now), err
 }
 
 func NewFsm(log logr.Logger, cfg RCCfg, k8s K8s) Fsm {
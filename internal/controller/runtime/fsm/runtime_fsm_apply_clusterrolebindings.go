@@ -2,13 +2,19 @@ package fsm
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"slices"
+	"strings"
 
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
+	"github.com/pkg/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -20,6 +26,9 @@ var (
 	}
 )
 
+// annotation of KIM managed cluster role bindings which identifies the bound administrator
+const annotationAdministrator = "infrastructuremanager.kyma-project.io/administrator"
+
 func sFnApplyClusterRoleBindings(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
 	runtimeClient, err := m.RuntimeClientGetter.Get(ctx, s.instance)
 	if err != nil {
@@ -34,26 +43,52 @@ func sFnApplyClusterRoleBindings(ctx context.Context, m *fsm, s *systemState) (s
 		return requeue()
 	}
 
-	removed := getRemoved(crbList.Items, s.instance.Spec.Security.Administrators)
-	missing := getMissing(crbList.Items, s.instance.Spec.Security.Administrators)
+	admins, err := desiredAdministrators(s.instance.Spec.Security)
+	if err != nil {
+		m.log.Error(err, "Invalid administrators configuration")
+		s.instance.UpdateStatePending(
+			imv1.ConditionTypeRuntimeConfigured,
+			imv1.ConditionReasonConfigurationErr,
+			string(metav1.ConditionFalse),
+			err.Error(),
+		)
+		return updateStatusAndStop()
+	}
 
+	drifted := getDrifted(crbList.Items, admins)
+	removed := getRemoved(crbList.Items, admins)
+	missing := getMissing(crbList.Items, admins)
+
+	// bindings are applied before the removed ones are deleted, so administrators keep their access while bindings with generated names are migrated
 	for _, fn := range []func() error{
+		newApplyCRBs(ctx, runtimeClient, missing),
 		newDelCRBs(ctx, runtimeClient, removed),
-		newAddCRBs(ctx, runtimeClient, missing),
 	} {
 		if err := fn(); err != nil {
 			updateCRBApplyFailed(&s.instance)
 			m.log.Info("Cannot setup Cluster Role Bindings on shoot, scheduling for retry")
 			return requeue()
 		}
-		logDeletedClusterRoleBindings(removed, m, s)
 	}
+	logDeletedClusterRoleBindings(removed, m, s)
 
-	s.instance.UpdateStateReady(
-		imv1.ConditionTypeRuntimeConfigured,
-		imv1.ConditionReasonAdministratorsConfigured,
-		"Cluster admin configuration complete",
-	)
+	s.instance.Status.Administrators = toAdministratorStatuses(admins)
+
+	if len(drifted) > 0 {
+		driftedNames := clusterRoleBindingNames(drifted)
+		m.log.Info("Cluster role bindings of administrators were changed in the SKR and have been restored", "driftedCRBs", driftedNames)
+		s.instance.UpdateStateReady(
+			imv1.ConditionTypeRuntimeConfigured,
+			imv1.ConditionReasonAdministratorsDrifted,
+			fmt.Sprintf("Cluster admin configuration complete, restored manually changed cluster role bindings: %s", strings.Join(driftedNames, ", ")),
+		)
+	} else {
+		s.instance.UpdateStateReady(
+			imv1.ConditionTypeRuntimeConfigured,
+			imv1.ConditionReasonAdministratorsConfigured,
+			"Cluster admin configuration complete",
+		)
+	}
 
 	if !s.instance.IsProvisioningCompletedStatusSet() {
 		s.instance.UpdateStateProvisioningCompleted()
@@ -66,50 +101,133 @@ func sFnApplyClusterRoleBindings(ctx context.Context, m *fsm, s *systemState) (s
 
 func logDeletedClusterRoleBindings(removed []rbacv1.ClusterRoleBinding, m *fsm, s *systemState) {
 	if len(removed) > 0 {
-		var crbsNames []string
-		for _, binding := range removed {
-			crbsNames = append(crbsNames, binding.Name)
-		}
-		m.log.V(log_level.DEBUG).Info("Following CRBs were deleted", "deletedCRBs", crbsNames)
+		m.log.V(log_level.DEBUG).Info("Following CRBs were deleted", "deletedCRBs", clusterRoleBindingNames(removed))
 	}
 }
 
-func isRBACUserKind() func(rbacv1.Subject) bool {
-	return func(s rbacv1.Subject) bool {
-		return s.Kind == rbacv1.UserKind
+func clusterRoleBindingNames(crbs []rbacv1.ClusterRoleBinding) []string {
+	var crbsNames []string
+	for _, binding := range crbs {
+		crbsNames = append(crbsNames, binding.Name)
 	}
+	return crbsNames
 }
 
-func isRBACUserKindOneOf(names []string) func(rbacv1.Subject) bool {
-	return func(s rbacv1.Subject) bool {
-		return slices.Contains(names, s.Name)
+// desiredAdministrators merges the cluster-admin users with the administrator bindings, the cluster-admin role is used when no role is set
+func desiredAdministrators(security imv1.Security) ([]imv1.Administrator, error) {
+	var admins []imv1.Administrator
+	keys := map[string]bool{}
+
+	add := func(admin imv1.Administrator) error {
+		if admin.ClusterRole == "" {
+			admin.ClusterRole = imv1.DefaultAdministratorClusterRole
+		}
+
+		if admin.Kind != imv1.AdministratorKindServiceAccount {
+			admin.Namespace = ""
+		} else if admin.Namespace == "" {
+			return errors.Errorf("namespace is required for service account administrator %s", admin.Name)
+		}
+
+		if key := administratorKey(admin); !keys[key] {
+			keys[key] = true
+			admins = append(admins, admin)
+		}
+		return nil
+	}
+
+	for _, name := range security.Administrators {
+		if err := add(imv1.Administrator{Kind: imv1.AdministratorKindUser, Name: name}); err != nil {
+			return nil, err
+		}
 	}
+
+	for _, admin := range security.AdministratorBindings {
+		if err := add(admin); err != nil {
+			return nil, err
+		}
+	}
+
+	return admins, nil
 }
 
-func getRemoved(crbs []rbacv1.ClusterRoleBinding, admins []string) (removed []rbacv1.ClusterRoleBinding) {
-	// iterate over cluster role bindings to find out removed administrators
-	for _, crb := range crbs {
-		if !managedByKIM(crb) {
-			// cluster role binding is not controlled by KIM
-			continue
+func administratorKey(admin imv1.Administrator) string {
+	return fmt.Sprintf("%s/%s/%s/%s", admin.Kind, admin.Namespace, admin.Name, admin.ClusterRole)
+}
+
+// clusterRoleBindingName derives the name of the binding from a hash of the subject and the role, so every administrator has exactly one binding per role
+func clusterRoleBindingName(admin imv1.Administrator) string {
+	hash := sha256.Sum256([]byte(administratorKey(admin)))
+	return "admin-" + hex.EncodeToString(hash[:])[:16]
+}
+
+// isAdminBinding checks if the cluster role binding was created by KIM for an administrator.
+// Bindings created before the administrator annotation was introduced bind a single user to the cluster-admin role.
+func isAdminBinding(crb rbacv1.ClusterRoleBinding) bool {
+	if !managedByKIM(crb) {
+		return false
+	}
+
+	if _, found := crb.Annotations[annotationAdministrator]; found {
+		return true
+	}
+
+	if crb.RoleRef.Kind != "ClusterRole" || crb.RoleRef.Name != imv1.DefaultAdministratorClusterRole {
+		// cluster role binding is not admin
+		return false
+	}
+
+	return len(crb.Subjects) == 1 && crb.Subjects[0].Kind == rbacv1.UserKind
+}
+
+// isDrifted checks if the cluster role binding of an administrator was changed in the SKR after it was applied
+func isDrifted(existing, desired rbacv1.ClusterRoleBinding) bool {
+	return !managedByKIM(existing) ||
+		existing.Annotations[annotationAdministrator] != desired.Annotations[annotationAdministrator] ||
+		!slices.Equal(existing.Subjects, desired.Subjects) ||
+		existing.RoleRef != desired.RoleRef
+}
+
+func findClusterRoleBinding(crbs []rbacv1.ClusterRoleBinding, name string) *rbacv1.ClusterRoleBinding {
+	for i := range crbs {
+		if crbs[i].Name == name {
+			return &crbs[i]
 		}
+	}
+	return nil
+}
 
-		if crb.RoleRef.Kind != "ClusterRole" && crb.RoleRef.Name != "cluster-admin" {
-			// cluster role binding is not admin
-			continue
+func getDrifted(crbs []rbacv1.ClusterRoleBinding, admins []imv1.Administrator) (drifted []rbacv1.ClusterRoleBinding) {
+	for _, admin := range admins {
+		desired := toClusterRoleBinding(admin)
+		existing := findClusterRoleBinding(crbs, desired.Name)
+		if existing != nil && isDrifted(*existing, desired) {
+			drifted = append(drifted, *existing)
 		}
+	}
 
-		if !slices.ContainsFunc(crb.Subjects, isRBACUserKind()) {
-			// cluster role binding is not user kind
+	return drifted
+}
+
+func getRemoved(crbs []rbacv1.ClusterRoleBinding, admins []imv1.Administrator) (removed []rbacv1.ClusterRoleBinding) {
+	desiredNames := map[string]bool{}
+	for _, admin := range admins {
+		desiredNames[clusterRoleBindingName(admin)] = true
+	}
+
+	// iterate over cluster role bindings to find out removed administrators
+	for _, crb := range crbs {
+		if !isAdminBinding(crb) {
+			// cluster role binding is not an administrator binding controlled by KIM
 			continue
 		}
 
-		if slices.ContainsFunc(crb.Subjects, isRBACUserKindOneOf(admins)) {
-			// the administrator was not removed
+		if desiredNames[crb.Name] {
+			// the administrator was not removed, manual changes are reverted by the apply
 			continue
 		}
 
-		// administrator was removed
+		// administrator was removed, or the binding was created with a generated name
 		removed = append(removed, crb)
 	}
 
@@ -122,61 +240,74 @@ func managedByKIM(crb rbacv1.ClusterRoleBinding) bool {
 	return isManagedByKIM
 }
 
-//nolint:gochecknoglobals
-var newContainsAdmin = func(admin string) func(rbacv1.ClusterRoleBinding) bool {
-	return func(crb rbacv1.ClusterRoleBinding) bool {
-		if !managedByKIM(crb) {
-			return false
-		}
-		isAdmin := isRBACUserKindOneOf([]string{admin})
-		return slices.ContainsFunc(crb.Subjects, isAdmin)
-	}
-}
-
-func getMissing(crbs []rbacv1.ClusterRoleBinding, admins []string) (missing []rbacv1.ClusterRoleBinding) {
+func getMissing(crbs []rbacv1.ClusterRoleBinding, admins []imv1.Administrator) (missing []rbacv1.ClusterRoleBinding) {
 	for _, admin := range admins {
-		containsAdmin := newContainsAdmin(admin)
-		if slices.ContainsFunc(crbs, containsAdmin) {
+		crb := toClusterRoleBinding(admin)
+		existing := findClusterRoleBinding(crbs, crb.Name)
+		if existing != nil && !isDrifted(*existing, crb) {
 			continue
 		}
-		crb := toAdminClusterRoleBinding(admin)
 		missing = append(missing, crb)
 	}
 
 	return missing
 }
 
-func toAdminClusterRoleBindingWithLabel(name string, key, value string) rbacv1.ClusterRoleBinding {
-	// initialize labels
+func toAdminClusterRoleBinding(name string) rbacv1.ClusterRoleBinding {
+	return toClusterRoleBinding(imv1.Administrator{
+		Kind:        imv1.AdministratorKindUser,
+		Name:        name,
+		ClusterRole: imv1.DefaultAdministratorClusterRole,
+	})
+}
+
+func toClusterRoleBinding(admin imv1.Administrator) rbacv1.ClusterRoleBinding {
+	subject := rbacv1.Subject{
+		Kind: string(admin.Kind),
+		Name: admin.Name,
+	}
+
+	if admin.Kind == imv1.AdministratorKindServiceAccount {
+		subject.Namespace = admin.Namespace
+	} else {
+		subject.APIGroup = rbacv1.GroupName
+	}
+
 	labels := map[string]string{}
-	if key != "" {
+	for key, value := range labelsManagedByKIM {
 		labels[key] = value
 	}
-	// build CRB
+
 	return rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRoleBinding",
+		},
 		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: "admin-",
-			Labels:       labels,
+			Name:   clusterRoleBindingName(admin),
+			Labels: labels,
+			Annotations: map[string]string{
+				annotationAdministrator: administratorKey(admin),
+			},
 		},
-		Subjects: []rbacv1.Subject{{
-			Kind:     rbacv1.UserKind,
-			Name:     name,
-			APIGroup: rbacv1.GroupName,
-		}},
+		Subjects: []rbacv1.Subject{subject},
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: rbacv1.GroupName,
 			Kind:     "ClusterRole",
-			Name:     "cluster-admin",
+			Name:     admin.ClusterRole,
 		},
 	}
 }
 
-func toAdminClusterRoleBindingNoLabels(name string) rbacv1.ClusterRoleBinding {
-	return toAdminClusterRoleBindingWithLabel(name, "", "")
-}
-
-func toAdminClusterRoleBinding(name string) rbacv1.ClusterRoleBinding {
-	return toAdminClusterRoleBindingWithLabel(name, "reconciler.kyma-project.io/managed-by", "infrastructure-manager")
+func toAdministratorStatuses(admins []imv1.Administrator) []imv1.AdministratorStatus {
+	var statuses []imv1.AdministratorStatus
+	for _, admin := range admins {
+		statuses = append(statuses, imv1.AdministratorStatus{
+			Administrator:      admin,
+			ClusterRoleBinding: clusterRoleBindingName(admin),
+		})
+	}
+	return statuses
 }
 
 //nolint:gochecknoglobals
@@ -193,10 +324,13 @@ var newDelCRBs = func(ctx context.Context, runtimeClient client.Client, crbs []r
 }
 
 //nolint:gochecknoglobals
-var newAddCRBs = func(ctx context.Context, runtimeClient client.Client, crbs []rbacv1.ClusterRoleBinding) func() error {
+var newApplyCRBs = func(ctx context.Context, runtimeClient client.Client, crbs []rbacv1.ClusterRoleBinding) func() error {
 	return func() error {
 		for _, crb := range crbs {
-			if err := runtimeClient.Create(ctx, &crb); err != nil {
+			if err := runtimeClient.Patch(ctx, &crb, client.Apply, &client.PatchOptions{
+				FieldManager: fieldManagerName,
+				Force:        ptr.To(true),
+			}); err != nil {
 				return err
 			}
 		}
@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,7 +31,7 @@ var _ = Describe(`runtime_fsm_apply_crb`, Label("applyCRB"), func() {
 
 	DescribeTable("getMissing",
 		func(tc tcCRBData) {
-			actual := getMissing(tc.crbs, tc.admins)
+			actual := getMissing(tc.crbs, tc.administrators())
 			Expect(actual).To(BeComparableTo(tc.expected))
 		},
 		Entry("should return a list with CRBs to be created", tcCRBData{
@@ -45,11 +46,21 @@ var _ = Describe(`runtime_fsm_apply_crb`, Label("applyCRB"), func() {
 			admins: []string{"test1", "test2", "test3"},
 			crbs: []rbacv1.ClusterRoleBinding{
 				toAdminClusterRoleBinding("test1"),
-				toManagedClusterRoleBinding("test2", "infrastructure-manager"),
-				toManagedClusterRoleBinding("test3", "infrastructure-manager"),
+				toAdminClusterRoleBinding("test2"),
+				toAdminClusterRoleBinding("test3"),
 			},
 			expected: nil,
 		}),
+		Entry("should return CRBs with deterministic names for CRBs with generated names", tcCRBData{
+			admins: []string{"test1", "test2"},
+			crbs: []rbacv1.ClusterRoleBinding{
+				toAdminClusterRoleBinding("test1"),
+				toManagedClusterRoleBinding("test2", "infrastructure-manager"),
+			},
+			expected: []rbacv1.ClusterRoleBinding{
+				toAdminClusterRoleBinding("test2"),
+			},
+		}),
 		Entry("should return nil list if no admins missing", tcCRBData{
 			admins: []string{"test1"},
 			crbs: []rbacv1.ClusterRoleBinding{
@@ -57,11 +68,33 @@ var _ = Describe(`runtime_fsm_apply_crb`, Label("applyCRB"), func() {
 			},
 			expected: nil,
 		}),
+		Entry("should return CRBs of groups and service accounts with their roles", tcCRBData{
+			bindings: []imv1.Administrator{
+				{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "view"},
+				{Kind: imv1.AdministratorKindServiceAccount, Name: "ci", Namespace: "cicd"},
+			},
+			crbs: []rbacv1.ClusterRoleBinding{
+				toClusterRoleBinding(imv1.Administrator{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "admin"}),
+			},
+			expected: []rbacv1.ClusterRoleBinding{
+				toClusterRoleBinding(imv1.Administrator{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "view"}),
+				toClusterRoleBinding(imv1.Administrator{Kind: imv1.AdministratorKindServiceAccount, Name: "ci", Namespace: "cicd", ClusterRole: "cluster-admin"}),
+			},
+		}),
+		Entry("should recreate CRB changed in the SKR", tcCRBData{
+			admins: []string{"test1"},
+			crbs: []rbacv1.ClusterRoleBinding{
+				withSubject(toAdminClusterRoleBinding("test1"), "test2"),
+			},
+			expected: []rbacv1.ClusterRoleBinding{
+				toAdminClusterRoleBinding("test1"),
+			},
+		}),
 	)
 
 	DescribeTable("getRemoved",
 		func(tc tcCRBData) {
-			actual := getRemoved(tc.crbs, tc.admins)
+			actual := getRemoved(tc.crbs, tc.administrators())
 			Expect(actual).To(BeComparableTo(tc.expected))
 		},
 		Entry("should return nil list if CRB list is nil", tcCRBData{
@@ -123,7 +156,7 @@ var _ = Describe(`runtime_fsm_apply_crb`, Label("applyCRB"), func() {
 				toManagedClusterRoleBinding("test1", "infrastructure-manager"),
 				toManagedClusterRoleBinding("test2", "reconciler"),
 				toManagedClusterRoleBinding("test3", "should-stay"),
-				toManagedClusterRoleBinding("test4", "infrastructure-manager"),
+				toAdminClusterRoleBinding("test4"),
 				toManagedClusterRoleBinding("test5", "reconciler"),
 			},
 			expected: []rbacv1.ClusterRoleBinding{
@@ -151,8 +184,115 @@ var _ = Describe(`runtime_fsm_apply_crb`, Label("applyCRB"), func() {
 			},
 			expected: nil,
 		}),
+		Entry("should remove CRB when the role of the administrator changed", tcCRBData{
+			bindings: []imv1.Administrator{
+				{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "view"},
+			},
+			crbs: []rbacv1.ClusterRoleBinding{
+				toClusterRoleBinding(imv1.Administrator{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "view"}),
+				toClusterRoleBinding(imv1.Administrator{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "admin"}),
+			},
+			expected: []rbacv1.ClusterRoleBinding{
+				toClusterRoleBinding(imv1.Administrator{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "admin"}),
+			},
+		}),
+		Entry("should remove CRB of a user which is configured as a group", tcCRBData{
+			bindings: []imv1.Administrator{
+				{Kind: imv1.AdministratorKindGroup, Name: "test1"},
+			},
+			crbs: []rbacv1.ClusterRoleBinding{
+				toAdminClusterRoleBinding("test1"),
+			},
+			expected: []rbacv1.ClusterRoleBinding{
+				toAdminClusterRoleBinding("test1"),
+			},
+		}),
+		Entry("should not remove CRB changed in the SKR, it is restored by the apply", tcCRBData{
+			admins: []string{"test1"},
+			crbs: []rbacv1.ClusterRoleBinding{
+				withSubject(toAdminClusterRoleBinding("test1"), "test2"),
+			},
+			expected: nil,
+		}),
+		Entry("should remove CRB with generated name of a configured admin", tcCRBData{
+			admins: []string{"test1"},
+			crbs: []rbacv1.ClusterRoleBinding{
+				toAdminClusterRoleBinding("test1"),
+				toManagedClusterRoleBinding("test1", "infrastructure-manager"),
+			},
+			expected: []rbacv1.ClusterRoleBinding{
+				toManagedClusterRoleBinding("test1", "infrastructure-manager"),
+			},
+		}),
+		Entry("should not remove KIM managed CRB which is not an admin binding", tcCRBData{
+			admins: []string{"test1"},
+			crbs: []rbacv1.ClusterRoleBinding{
+				withRole(toManagedClusterRoleBinding("test2", "infrastructure-manager"), "view"),
+				withSubject(withRole(toManagedClusterRoleBinding("test3", "infrastructure-manager"), "edit"), "test3"),
+			},
+			expected: nil,
+		}),
 	)
 
+	It("should derive CRB names from the subject and the role", func() {
+		admin := imv1.Administrator{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "view"}
+		asUser := admin
+		asUser.Kind = imv1.AdministratorKindUser
+		withOtherRole := admin
+		withOtherRole.ClusterRole = "admin"
+
+		Expect(clusterRoleBindingName(admin)).To(Equal(clusterRoleBindingName(admin)))
+		Expect(clusterRoleBindingName(admin)).To(HavePrefix("admin-"))
+		Expect(clusterRoleBindingName(admin)).NotTo(Equal(clusterRoleBindingName(asUser)))
+		Expect(clusterRoleBindingName(admin)).NotTo(Equal(clusterRoleBindingName(withOtherRole)))
+	})
+
+	DescribeTable("desiredAdministrators",
+		func(security imv1.Security, expected []imv1.Administrator, expectedErr string) {
+			actual, err := desiredAdministrators(security)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(expectedErr))
+				return
+			}
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(actual).To(BeComparableTo(expected))
+		},
+		Entry("should merge users and administrator bindings without duplicates",
+			imv1.Security{
+				Administrators: []string{"test1"},
+				AdministratorBindings: []imv1.Administrator{
+					{Kind: imv1.AdministratorKindUser, Name: "test1", ClusterRole: "cluster-admin"},
+					{Kind: imv1.AdministratorKindGroup, Name: "operators", Namespace: "ignored", ClusterRole: "view"},
+				},
+			},
+			[]imv1.Administrator{
+				{Kind: imv1.AdministratorKindUser, Name: "test1", ClusterRole: "cluster-admin"},
+				{Kind: imv1.AdministratorKindGroup, Name: "operators", ClusterRole: "view"},
+			},
+			"",
+		),
+		Entry("should fail for service account without namespace",
+			imv1.Security{
+				AdministratorBindings: []imv1.Administrator{{Kind: imv1.AdministratorKindServiceAccount, Name: "ci"}},
+			},
+			nil,
+			"namespace is required for service account administrator ci",
+		),
+	)
+
+	It("should report CRBs changed in the SKR", func() {
+		changedRole := toAdminClusterRoleBinding("test2")
+		changedRole.RoleRef.Name = "view"
+
+		drifted := getDrifted([]rbacv1.ClusterRoleBinding{
+			toAdminClusterRoleBinding("test1"),
+			changedRole,
+			toManagedClusterRoleBinding("test3", "infrastructure-manager"),
+		}, userAdministrators("test1", "test2", "test3"))
+
+		Expect(drifted).To(BeComparableTo([]rbacv1.ClusterRoleBinding{changedRole}))
+	})
+
 	testRuntime := imv1.Runtime{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "testme1",
@@ -177,6 +317,48 @@ var _ = Describe(`runtime_fsm_apply_crb`, Label("applyCRB"), func() {
 	testScheme, err := newTestScheme()
 	Expect(err).ShouldNot(HaveOccurred())
 
+	It("should apply CRBs with deterministic names and list the administrators in the status", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		generated := toManagedClusterRoleBinding("test-admin1", "infrastructure-manager")
+		generated.Name = "admin-generated"
+		changed := withSubject(toAdminClusterRoleBinding("test-admin2"), "intruder")
+
+		runtime := testRuntimeWithAdmin.DeepCopy()
+		runtime.Spec.Security.AdministratorBindings = []imv1.Administrator{{Kind: imv1.AdministratorKindUser, Name: "test-admin2"}}
+
+		testFsm := must(
+			newFakeFSM,
+			withFakedK8sClient(testScheme, runtime, &generated, &changed),
+			withMockedMetrics(),
+			withDefaultReconcileDuration(),
+		)
+		state := &systemState{instance: *runtime}
+
+		_, _, err := sFnApplyClusterRoleBindings(ctx, testFsm, state)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		runtimeClient, err := testFsm.RuntimeClientGetter.Get(ctx, state.instance)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var crbList rbacv1.ClusterRoleBindingList
+		Expect(runtimeClient.List(ctx, &crbList)).To(Succeed())
+
+		admins := userAdministrators("test-admin1", "test-admin2")
+		Expect(clusterRoleBindingNames(crbList.Items)).To(ConsistOf(clusterRoleBindingName(admins[0]), clusterRoleBindingName(admins[1])))
+		Expect(getMissing(crbList.Items, admins)).To(BeEmpty())
+		Expect(state.instance.Status.Administrators).To(Equal([]imv1.AdministratorStatus{
+			{Administrator: admins[0], ClusterRoleBinding: clusterRoleBindingName(admins[0])},
+			{Administrator: admins[1], ClusterRoleBinding: clusterRoleBindingName(admins[1])},
+		}))
+
+		condition := meta.FindStatusCondition(state.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeConfigured))
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonAdministratorsDrifted)))
+		Expect(condition.Message).To(ContainSubstring(clusterRoleBindingName(admins[1])))
+	})
+
 	DescribeTable("sFnApplyClusterRoleBindings",
 		func(tc tcApplySfn) {
 			// initialize test data if required
@@ -247,9 +429,37 @@ var _ = Describe(`runtime_fsm_apply_crb`, Label("applyCRB"), func() {
 type tcCRBData struct {
 	crbs     []rbacv1.ClusterRoleBinding
 	admins   []string
+	bindings []imv1.Administrator
 	expected []rbacv1.ClusterRoleBinding
 }
 
+func (c tcCRBData) administrators() []imv1.Administrator {
+	admins, err := desiredAdministrators(imv1.Security{
+		Administrators:        c.admins,
+		AdministratorBindings: c.bindings,
+	})
+	Expect(err).ShouldNot(HaveOccurred())
+	return admins
+}
+
+func userAdministrators(names ...string) []imv1.Administrator {
+	return tcCRBData{admins: names}.administrators()
+}
+
+func withRole(crb rbacv1.ClusterRoleBinding, role string) rbacv1.ClusterRoleBinding {
+	crb.RoleRef.Name = role
+	return crb
+}
+
+func withSubject(crb rbacv1.ClusterRoleBinding, name string) rbacv1.ClusterRoleBinding {
+	crb.Subjects = []rbacv1.Subject{{
+		Kind:     rbacv1.UserKind,
+		Name:     name,
+		APIGroup: rbacv1.GroupName,
+	}}
+	return crb
+}
+
 type tcSfnExpected struct {
 	result ctrl.Result
 	err    error
@@ -283,6 +493,36 @@ func newTestScheme() (*runtime.Scheme, error) {
 	return schema, nil
 }
 
+// toAdminClusterRoleBindingWithLabel builds a cluster-admin binding as created before the bindings got deterministic names
+func toAdminClusterRoleBindingWithLabel(name string, key, value string) rbacv1.ClusterRoleBinding {
+	// initialize labels
+	labels := map[string]string{}
+	if key != "" {
+		labels[key] = value
+	}
+	// build CRB
+	return rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "admin-",
+			Labels:       labels,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:     rbacv1.UserKind,
+			Name:     name,
+			APIGroup: rbacv1.GroupName,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+		},
+	}
+}
+
+func toAdminClusterRoleBindingNoLabels(name string) rbacv1.ClusterRoleBinding {
+	return toAdminClusterRoleBindingWithLabel(name, "", "")
+}
+
 func toManagedClusterRoleBinding(name, managedBy string) rbacv1.ClusterRoleBinding {
 	return toAdminClusterRoleBindingWithLabel(name,
 		"reconciler.kyma-project.io/managed-by", managedBy)
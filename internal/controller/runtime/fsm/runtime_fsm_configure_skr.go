@@ -13,19 +13,16 @@ import (
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/skrdetails"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauth"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	k8s_client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-type additionalOIDCState struct {
-	hasEmptyArray bool
-}
-
 const (
 	msgFailedProvisioningInfoConfigMap = "Failed to apply kyma-provisioning-info config map, scheduling for retry - %s"
-	oidcErrorMessage                   = "Failed to create OpenIDConnect resource. Scheduling for retry"
+	oidcErrorMessage                   = "Failed to apply OpenIDConnect resources. Scheduling for retry"
 	kymaNamespaceCreationErrorMessage  = "Failed to create kyma-system namespace. Scheduling for retry"
 )
 
@@ -53,8 +50,8 @@ func sFnConfigureSKR(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctr
 		return switchState(sFnApplyClusterRoleBindings)
 	}
 
-	additionalOIDCStatus := additionalOidcEmptyOrUndefined(&s.instance, m.RCCfg)
-	err := recreateOpenIDConnectResources(ctx, m, s, additionalOIDCStatus)
+	// the extension is enabled only for additional OIDC configs which cannot be rendered as JWT authenticators in the structured authentication config map
+	err := applyOpenIDConnectResources(ctx, m, s)
 
 	if err != nil {
 		updateConditionFailed(&s.instance, imv1.ConditionReasonOidcError, oidcErrorMessage)
@@ -104,70 +101,63 @@ func createKymaSystemNamespace(ctx context.Context, m *fsm, s *systemState) erro
 	return kymaNsCreationErr
 }
 
-func additionalOidcEmptyOrUndefined(runtime *imv1.Runtime, cfg RCCfg) additionalOIDCState {
-	additionalOidcConfig := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig
-	additionalOIDCConfigUndefined := func() bool {
-		if additionalOidcConfig == nil {
-			return true
-		}
-		for _, oidcConfig := range *additionalOidcConfig {
-			if oidcConfig.ClientID == nil || oidcConfig.IssuerURL == nil {
-				return true
-			}
-		}
-		return false
-	}
-
-	additionalOIDCConfigEmpty := func() bool {
-		return len(*additionalOidcConfig) == 0
-	}
+func applyOpenIDConnectResources(ctx context.Context, m *fsm, s *systemState) error {
+	oidcConfig := structuredauth.GetOIDCConfigOrDefault(s.instance, m.ConverterConfig.Kubernetes.DefaultOperatorOidc.ToOIDCConfig())
+	additionalOidcConfigs := structuredauth.GetAdditionalOIDCConfigsOrDefault(s.instance, m.ClusterConfig.DefaultSharedIASTenant.ToOIDCConfig())
 
-	if additionalOIDCConfigUndefined() {
-		additionalOidcConfig = &[]imv1.OIDCConfig{}
-		defaultOIDCConfig := cfg.ClusterConfig.DefaultSharedIASTenant.ToOIDCConfig()
-		*additionalOidcConfig = append(*additionalOidcConfig, imv1.OIDCConfig{OIDCConfig: defaultOIDCConfig})
-		runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = additionalOidcConfig
-		return additionalOIDCState{}
-	}
-
-	if additionalOIDCConfigEmpty() {
-		return additionalOIDCState{hasEmptyArray: true}
+	openIDConnectConfigs, err := structuredauth.OpenIDConnectConfigs(oidcConfig, additionalOidcConfigs)
+	if err != nil {
+		return err
 	}
 
-	return additionalOIDCState{}
-
-}
-
-func recreateOpenIDConnectResources(ctx context.Context, m *fsm, s *systemState, additionalOIDC additionalOIDCState) error {
 	runtimeClient, runtimeClientError := m.RuntimeClientGetter.Get(ctx, s.instance)
 	if runtimeClientError != nil {
 		return runtimeClientError
 	}
 
-	err := deleteExistingKymaOpenIDConnectResources(ctx, runtimeClient)
-	if err != nil {
-		return err
-	}
+	desired := map[string]bool{}
+	for id, openIDConnectConfig := range openIDConnectConfigs {
+		// the kube-apiserver verifies the tokens of issuers with pinned keys using the keys pinned in the runtime status
+		if openIDConnectConfig.PinJWKS && len(openIDConnectConfig.JWKS) == 0 {
+			if pinned := findOIDCIssuerStatus(s.instance.Status.OIDCIssuers, ptr.Deref(openIDConnectConfig.IssuerURL, "")); pinned != nil {
+				openIDConnectConfig.JWKS = pinned.JWKS
+			}
+		}
 
-	if additionalOIDC.hasEmptyArray {
-		return nil
-	}
+		openIDConnectResource := createOpenIDConnectResource(openIDConnectConfig, id)
+		desired[openIDConnectResource.Name] = true
 
-	additionalOidcConfigs := *s.instance.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig
-	var errResourceCreation error
-	for id, additionalOidcConfig := range additionalOidcConfigs {
-		openIDConnectResource := createOpenIDConnectResource(additionalOidcConfig, id)
-		errResourceCreation = runtimeClient.Create(ctx, openIDConnectResource)
+		if err := runtimeClient.Patch(ctx, openIDConnectResource, k8s_client.Apply, &k8s_client.PatchOptions{
+			FieldManager: fieldManagerName,
+			Force:        ptr.To(true),
+		}); err != nil {
+			return err
+		}
 	}
-	return errResourceCreation
+
+	return deleteRemovedKymaOpenIDConnectResources(ctx, runtimeClient, desired)
 }
 
-func deleteExistingKymaOpenIDConnectResources(ctx context.Context, client k8s_client.Client) (err error) {
-	err = client.DeleteAllOf(ctx, &authenticationv1alpha1.OpenIDConnect{}, k8s_client.MatchingLabels(map[string]string{
+// deleteRemovedKymaOpenIDConnectResources removes the OpenIDConnect resources of OIDC configs which were removed or are rendered as JWT authenticators now
+func deleteRemovedKymaOpenIDConnectResources(ctx context.Context, client k8s_client.Client, desired map[string]bool) error {
+	var openIDConnects authenticationv1alpha1.OpenIDConnectList
+	if err := client.List(ctx, &openIDConnects, k8s_client.MatchingLabels(map[string]string{
 		imv1.LabelKymaManagedBy: "infrastructure-manager",
-	}))
+	})); err != nil {
+		return err
+	}
+
+	for _, openIDConnect := range openIDConnects.Items {
+		if desired[openIDConnect.Name] {
+			continue
+		}
+
+		if err := client.Delete(ctx, &openIDConnect); k8s_client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
 
-	return err
+	return nil
 }
 
 func isOidcExtensionEnabled(shoot gardener.Shoot) bool {
@@ -203,8 +193,8 @@ func createOpenIDConnectResource(additionalOidcConfig imv1.OIDCConfig, oidcID in
 			},
 		},
 		Spec: authenticationv1alpha1.OIDCAuthenticationSpec{
-			IssuerURL:            *additionalOidcConfig.IssuerURL,
-			ClientID:             *additionalOidcConfig.ClientID,
+			IssuerURL:            ptr.Deref(additionalOidcConfig.IssuerURL, ""),
+			ClientID:             ptr.Deref(additionalOidcConfig.ClientID, ""),
 			UsernameClaim:        additionalOidcConfig.UsernameClaim,
 			UsernamePrefix:       additionalOidcConfig.UsernamePrefix,
 			GroupsClaim:          additionalOidcConfig.GroupsClaim,
@@ -212,12 +202,15 @@ func createOpenIDConnectResource(additionalOidcConfig imv1.OIDCConfig, oidcID in
 			RequiredClaims:       additionalOidcConfig.RequiredClaims,
 			SupportedSigningAlgs: toSupportedSigningAlgs(additionalOidcConfig.SigningAlgs),
 			JWKS: authenticationv1alpha1.JWKSSpec{
-				Keys: []byte(additionalOidcConfig.JWKS),
-				// FIXME: Distributed claims?
+				Keys: additionalOidcConfig.JWKS,
 			},
 		},
 	}
 
+	if additionalOidcConfig.CABundle != nil {
+		cr.Spec.CABundle = []byte(*additionalOidcConfig.CABundle)
+	}
+
 	return cr
 }
 
@@ -60,68 +60,14 @@ func TestSkrConfigState(t *testing.T) {
 		assertEqualConditions(t, expectedRuntimeConditions, systemState.instance.Status.Conditions)
 	})
 
-	t.Run("Should configure OIDC using defaults", func(t *testing.T) {
+	t.Run("Should not crash and remove OpenIDConnect CRs when Disabled field is missing in extension data", func(t *testing.T) {
 		// given
 		ctx := context.Background()
 
 		fakeClient, testFsm := setupFakeClient()
 
-		for _, tc := range []struct {
-			name                 string
-			additionalOIDCConfig *[]imv1.OIDCConfig
-		}{
-			{"Should configure OIDC using defaults when additional OIDC config is nil", nil},
-			{"Should configure OIDC using defaults when additional OIDC config contains one empty element", &[]imv1.OIDCConfig{{}}},
-		} {
-			t.Run(tc.name, func(t *testing.T) {
-				runtimeStub := runtimeForTest()
-
-				runtimeStub.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = tc.additionalOIDCConfig
-
-				shootStub := fsm_testing.TestShootForPatch()
-				oidcService := gardener.Extension{
-					Type:     "shoot-oidc-service",
-					Disabled: ptr.To(false),
-				}
-				shootStub.Spec.Extensions = append(shootStub.Spec.Extensions, oidcService)
-
-				systemState := &systemState{
-					instance: runtimeStub,
-					shoot:    shootStub,
-				}
-
-				expectedRuntimeConditions := []metav1.Condition{
-					{
-						Type:    string(imv1.ConditionTypeOidcAndCMsConfigured),
-						Reason:  string(imv1.ConditionReasonOidcAndCMsConfigured),
-						Status:  "True",
-						Message: "OIDC and kyma-provisioning-info configuration completed",
-					},
-				}
-
-				// when
-				stateFn, _, _ := sFnConfigureSKR(ctx, testFsm, systemState)
-
-				// then
-				require.Contains(t, stateFn.name(), "sFnApplyClusterRoleBindings")
-
-				var openIdConnects authenticationv1alpha1.OpenIDConnectList
-
-				err := fakeClient.List(ctx, &openIdConnects)
-				require.NoError(t, err)
-				assert.Len(t, openIdConnects.Items, 1)
-
-				assertOIDCCRD(t, "kyma-oidc-0", "defaut-client-id", openIdConnects.Items[0])
-				assertEqualConditions(t, expectedRuntimeConditions, systemState.instance.Status.Conditions)
-			})
-		}
-	})
-
-	t.Run("Should not crash and configure OIDC using defaults when Disabled field is missing in extension data", func(t *testing.T) {
-		// given
-		ctx := context.Background()
-
-		fakeClient, testFsm := setupFakeClient()
+		err := fakeClient.Create(ctx, createOpenIDConnectCR("kyma-oidc-0", "operator.kyma-project.io/managed-by", "infrastructure-manager"))
+		require.NoError(t, err)
 
 		runtimeStub := runtimeForTest()
 		shootStub := fsm_testing.TestShootForPatch()
@@ -153,26 +99,27 @@ func TestSkrConfigState(t *testing.T) {
 
 		var openIdConnects authenticationv1alpha1.OpenIDConnectList
 
-		err := fakeClient.List(ctx, &openIdConnects)
+		err = fakeClient.List(ctx, &openIdConnects)
 		require.NoError(t, err)
-		assert.Len(t, openIdConnects.Items, 1)
-
-		assertOIDCCRD(t, "kyma-oidc-0", "defaut-client-id", openIdConnects.Items[0])
+		assert.Len(t, openIdConnects.Items, 0)
 		assertEqualConditions(t, expectedRuntimeConditions, systemState.instance.Status.Conditions)
 	})
 
-	t.Run("Should configure OIDC based on Runtime CR configuration", func(t *testing.T) {
+	t.Run("Should delete existing OpenIDConnect CRs managed by KIM and keep the others", func(t *testing.T) {
 		// given
 		ctx := context.Background()
 
 		fakeClient, testFsm := setupFakeClient()
 
-		runtimeStub := runtimeForTest()
-		additionalOidcConfig := &[]imv1.OIDCConfig{}
-		*additionalOidcConfig = append(*additionalOidcConfig, createGardenerOidcConfig("runtime-cr-config0"))
-		*additionalOidcConfig = append(*additionalOidcConfig, createGardenerOidcConfig("runtime-cr-config1"))
-		runtimeStub.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = additionalOidcConfig
+		kymaOpenIDConnectCR := createOpenIDConnectCR("old-kyma-oidc", "operator.kyma-project.io/managed-by", "infrastructure-manager")
+		err := fakeClient.Create(ctx, kymaOpenIDConnectCR)
+		require.NoError(t, err)
+
+		existingOpenIDConnectCR := createOpenIDConnectCR("old-non-kyma-oidc", "customer-label", "should-not-be-deleted")
+		err = fakeClient.Create(ctx, existingOpenIDConnectCR)
+		require.NoError(t, err)
 
+		runtimeStub := runtimeForTest()
 		shootStub := fsm_testing.TestShootForPatch()
 		oidcService := gardener.Extension{
 			Type:     "shoot-oidc-service",
@@ -200,81 +147,86 @@ func TestSkrConfigState(t *testing.T) {
 		// then
 		require.Contains(t, stateFn.name(), "sFnApplyClusterRoleBindings")
 
-		var openIdConnects authenticationv1alpha1.OpenIDConnectList
+		var openIdConnect authenticationv1alpha1.OpenIDConnect
+		key := client.ObjectKey{
+			Name: "old-kyma-oidc",
+		}
+		err = fakeClient.Get(ctx, key, &openIdConnect)
+		require.Error(t, err)
 
-		err := fakeClient.List(ctx, &openIdConnects)
+		key = client.ObjectKey{
+			Name: "old-non-kyma-oidc",
+		}
+		err = fakeClient.Get(ctx, key, &openIdConnect)
 		require.NoError(t, err)
-		assert.Len(t, openIdConnects.Items, 2)
-		assert.Equal(t, "kyma-oidc-0", openIdConnects.Items[0].Name)
-		assertOIDCCRD(t, "kyma-oidc-0", "runtime-cr-config0", openIdConnects.Items[0])
-		assertOIDCCRD(t, "kyma-oidc-1", "runtime-cr-config1", openIdConnects.Items[1])
-		assert.Equal(t, imv1.State("Pending"), systemState.instance.Status.State)
+		assert.Equal(t, openIdConnect.Name, "old-non-kyma-oidc")
+
+		var openIdConnects authenticationv1alpha1.OpenIDConnectList
+		err = fakeClient.List(ctx, &openIdConnects)
+		require.NoError(t, err)
+		assert.Len(t, openIdConnects.Items, 1)
+		assert.Equal(t, "old-non-kyma-oidc", openIdConnects.Items[0].Name)
 		assertEqualConditions(t, expectedRuntimeConditions, systemState.instance.Status.Conditions)
+		assert.Equal(t, imv1.State("Pending"), systemState.instance.Status.State)
 	})
 
-	t.Run("Should first delete existing OpenIDConnect CRs then recreate them", func(t *testing.T) {
+	t.Run("Should apply OpenIDConnect CRs for OIDC configs which cannot be rendered as JWT authenticators", func(t *testing.T) {
 		// given
 		ctx := context.Background()
 
 		fakeClient, testFsm := setupFakeClient()
 
-		kymaOpenIDConnectCR := createOpenIDConnectCR("old-kyma-oidc", "operator.kyma-project.io/managed-by", "infrastructure-manager")
-		err := fakeClient.Create(ctx, kymaOpenIDConnectCR)
-		require.NoError(t, err)
-
-		existingOpenIDConnectCR := createOpenIDConnectCR("old-non-kyma-oidc", "customer-label", "should-not-be-deleted")
-		err = fakeClient.Create(ctx, existingOpenIDConnectCR)
+		err := fakeClient.Create(ctx, createOpenIDConnectCR("kyma-oidc-1", "operator.kyma-project.io/managed-by", "infrastructure-manager"))
 		require.NoError(t, err)
 
 		runtimeStub := runtimeForTest()
+		runtimeStub.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = &[]imv1.OIDCConfig{
+			{
+				OIDCConfig: gardener.OIDCConfig{
+					ClientID:      ptr.To("public-client"),
+					IssuerURL:     ptr.To("https://public.issuer.com"),
+					UsernameClaim: ptr.To("sub"),
+				},
+			},
+			{
+				OIDCConfig: gardener.OIDCConfig{
+					ClientID:      ptr.To("private-client"),
+					IssuerURL:     ptr.To("https://private.issuer.com"),
+					UsernameClaim: ptr.To("email"),
+					SigningAlgs:   []string{"ES256"},
+				},
+				JWKS: []byte(`{"keys":[{"kid":"key-1","kty":"EC"}]}`),
+			},
+		}
 		shootStub := fsm_testing.TestShootForPatch()
-		oidcService := gardener.Extension{
+		shootStub.Spec.Extensions = append(shootStub.Spec.Extensions, gardener.Extension{
 			Type:     "shoot-oidc-service",
 			Disabled: ptr.To(false),
-		}
-		shootStub.Spec.Extensions = append(shootStub.Spec.Extensions, oidcService)
+		})
 
 		systemState := &systemState{
 			instance: runtimeStub,
 			shoot:    shootStub,
 		}
 
-		expectedRuntimeConditions := []metav1.Condition{
-			{
-				Type:    string(imv1.ConditionTypeOidcAndCMsConfigured),
-				Reason:  string(imv1.ConditionReasonOidcAndCMsConfigured),
-				Status:  "True",
-				Message: "OIDC and kyma-provisioning-info configuration completed",
-			},
-		}
-
 		// when
 		stateFn, _, _ := sFnConfigureSKR(ctx, testFsm, systemState)
 
 		// then
 		require.Contains(t, stateFn.name(), "sFnApplyClusterRoleBindings")
-
-		var openIdConnect authenticationv1alpha1.OpenIDConnect
-		key := client.ObjectKey{
-			Name: "old-kyma-oidc",
-		}
-		err = fakeClient.Get(ctx, key, &openIdConnect)
-		require.Error(t, err)
-
-		key = client.ObjectKey{
-			Name: "old-non-kyma-oidc",
-		}
-		err = fakeClient.Get(ctx, key, &openIdConnect)
-		require.NoError(t, err)
-		assert.Equal(t, openIdConnect.Name, "old-non-kyma-oidc")
+		assertSuccesfullStatusConditions(t, systemState)
 
 		var openIdConnects authenticationv1alpha1.OpenIDConnectList
 		err = fakeClient.List(ctx, &openIdConnects)
 		require.NoError(t, err)
-		assert.Len(t, openIdConnects.Items, 2)
-		assert.Equal(t, "kyma-oidc-0", openIdConnects.Items[0].Name)
-		assertEqualConditions(t, expectedRuntimeConditions, systemState.instance.Status.Conditions)
-		assert.Equal(t, imv1.State("Pending"), systemState.instance.Status.State)
+		require.Len(t, openIdConnects.Items, 1)
+
+		openIdConnect := openIdConnects.Items[0]
+		assert.Equal(t, "kyma-oidc-0", openIdConnect.Name)
+		assert.Equal(t, "https://private.issuer.com", openIdConnect.Spec.IssuerURL)
+		assert.Equal(t, "private-client", openIdConnect.Spec.ClientID)
+		assert.Equal(t, []authenticationv1alpha1.SigningAlgorithm{"ES256"}, openIdConnect.Spec.SupportedSigningAlgs)
+		assert.Equal(t, []byte(`{"keys":[{"kid":"key-1","kty":"EC"}]}`), openIdConnect.Spec.JWKS.Keys)
 	})
 
 	t.Run("Should apply kyma-provisioning-info config map - create scenario", func(t *testing.T) {
@@ -565,19 +517,6 @@ func assertEqualConditions(t *testing.T, expectedConditions []metav1.Condition,
 	return assert.Equal(t, expectedConditions, actualConditions)
 }
 
-func createGardenerOidcConfig(clientId string) imv1.OIDCConfig {
-	return imv1.OIDCConfig{
-		OIDCConfig: gardener.OIDCConfig{
-			ClientID:       ptr.To(clientId),
-			GroupsClaim:    ptr.To("groups"),
-			IssuerURL:      ptr.To("https://my.cool.tokens.com"),
-			SigningAlgs:    []string{"RS256"},
-			UsernameClaim:  ptr.To("sub"),
-			UsernamePrefix: ptr.To("-"),
-		},
-	}
-}
-
 func createConverterOidcConfig(clientId string) config.OidcProvider {
 	return config.OidcProvider{
 		ClientID:       clientId,
@@ -600,22 +539,6 @@ func createOpenIDConnectCR(name string, labelKey, labelValue string) *authentica
 	}
 }
 
-func assertOIDCCRD(t *testing.T, expectedName, expectedClientID string, actual authenticationv1alpha1.OpenIDConnect) {
-	assert.Equal(t, expectedName, actual.Name)
-	assert.Equal(t, expectedClientID, actual.Spec.ClientID)
-	assert.Equal(t, ptr.To("groups"), actual.Spec.GroupsClaim)
-	assert.Nil(t, actual.Spec.GroupsPrefix)
-	assert.Equal(t, "https://my.cool.tokens.com", actual.Spec.IssuerURL)
-	assert.Equal(t, []authenticationv1alpha1.SigningAlgorithm{"RS256"}, actual.Spec.SupportedSigningAlgs)
-	assert.Equal(t, ptr.To("sub"), actual.Spec.UsernameClaim)
-	assert.Equal(t, ptr.To("-"), actual.Spec.UsernamePrefix)
-	assert.Equal(t, map[string]string(nil), actual.Spec.RequiredClaims)
-	assert.Equal(t, 0, len(actual.Spec.ExtraClaims))
-	assert.Equal(t, 0, len(actual.Spec.CABundle))
-	assert.Equal(t, authenticationv1alpha1.JWKSSpec{}, actual.Spec.JWKS)
-	assert.Nil(t, actual.Spec.MaxTokenExpirationSeconds)
-}
-
 func runtimeForTest() imv1.Runtime {
 	return imv1.Runtime{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3,53 +3,61 @@ package fsm
 import (
 	"context"
 	"fmt"
+	"time"
+
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
 	gardener_shoot "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauth"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauthz"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 const (
-	msgFailedToConfigureAuditlogs     = "Failed to configure audit logs"
-	msgFailedStructuredConfigMap      = "Failed to create structured authentication config map"
-	msgFailedToConfigureRegistryCache = "Failed to configure registry cache"
+	msgFailedToConfigureAuditlogs      = "Failed to configure audit logs"
+	msgFailedStructuredConfigMap       = "Failed to create structured authentication config map"
+	msgFailedStructuredAuthorization   = "Failed to configure structured authorization"
+	msgFailedToConfigureRegistryCache  = "Failed to configure registry cache"
+	msgFailedToSelectZones             = "Failed to select worker zones"
+	msgFailedToConfigureControlPlaneHA = "Failed to configure control plane high availability"
 )
 
 func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
-	if s.instance.Spec.Shoot.EnforceSeedLocation != nil && *s.instance.Spec.Shoot.EnforceSeedLocation {
-		seedAvailable, regionsWithSeeds, err := seedForRegionAvailable(ctx, m.SeedClient, s.instance.Spec.Shoot.Provider.Type, s.instance.Spec.Shoot.Region)
-		if err != nil {
-			msg := fmt.Sprintf("Failed to verify whether seed is available for the region %s.", s.instance.Spec.Shoot.Region)
-			m.log.Error(err, msg)
-			s.instance.UpdateStatePending(
-				imv1.ConditionTypeRuntimeProvisioned,
-				imv1.ConditionReasonGardenerError,
-				"False",
-				msg,
-			)
-			return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
-		}
+	seedAvailable, regionsWithSeeds, err := seedAvailableForShoot(ctx, m.SeedClient, s.instance.Spec.Shoot)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to verify whether seed is available for the region %s.", s.instance.Spec.Shoot.Region)
+		m.log.Error(err, msg)
+		s.instance.UpdateStatePending(
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonGardenerError,
+			"False",
+			msg,
+		)
+		return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
+	}
 
-		if !seedAvailable {
-			msg := fmt.Sprintf("Cannot find available seed for the region %s. The followig regions have seeds ready: %v.", s.instance.Spec.Shoot.Region, regionsWithSeeds)
-			m.log.Error(nil, msg)
-			m.Metrics.IncRuntimeFSMStopCounter()
-			return updateStatePendingWithErrorAndStop(
-				&s.instance,
-				imv1.ConditionTypeRuntimeProvisioned,
-				imv1.ConditionReasonSeedNotFound,
-				msg)
+	if !seedAvailable {
+		msg := fmt.Sprintf("Cannot find available seed for the region %s. The followig regions have seeds ready: %v.", s.instance.Spec.Shoot.Region, regionsWithSeeds)
+		if zoneFailureToleranceRequested(s.instance.Spec.Shoot.ControlPlane) {
+			msg = fmt.Sprintf("Cannot find available seed with at least %d zones for the region %s. The followig regions have such seeds ready: %v.", haZonesCount, s.instance.Spec.Shoot.Region, regionsWithSeeds)
 		}
+		m.log.Error(nil, msg)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(
+			&s.instance,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonSeedNotFound,
+			msg)
 	}
 
 	cmName := fmt.Sprintf(extender.StructuredAuthConfigFmt, s.instance.Spec.Shoot.Name)
 	oidcConfig := structuredauth.GetOIDCConfigOrDefault(s.instance, m.ConverterConfig.Kubernetes.DefaultOperatorOidc.ToOIDCConfig())
+	additionalOidcConfigs := structuredauth.GetAdditionalOIDCConfigsOrDefault(s.instance, m.ClusterConfig.DefaultSharedIASTenant.ToOIDCConfig())
 
-	err := structuredauth.CreateOrUpdateStructuredAuthConfigMap(ctx, m.SeedClient, types.NamespacedName{Name: cmName, Namespace: m.ShootNamesapace}, oidcConfig)
+	openIDConnectConfigs, err := structuredauth.CreateOrUpdateStructuredAuthConfigMap(ctx, m.SeedClient, types.NamespacedName{Name: cmName, Namespace: m.ShootNamesapace}, oidcConfig, additionalOidcConfigs)
 	if err != nil {
 		m.log.Error(err, "Failed to create structured authentication config map")
 
@@ -58,12 +66,21 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 			&s.instance,
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonOidcError,
-			msgFailedStructuredConfigMap)
+			fmt.Sprintf("%s: %v", msgFailedStructuredConfigMap, err))
 	}
 
-	data, err := m.AuditLogging.GetAuditLogData(
-		s.instance.Spec.Shoot.Provider.Type,
-		s.instance.Spec.Shoot.Region)
+	err = structuredauthz.CreateOrUpdate(ctx, m.KcpClient, m.SeedClient, m.ShootNamesapace, s.instance)
+	if err != nil {
+		m.log.Error(err, msgFailedStructuredAuthorization)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(
+			&s.instance,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonAuthorizationError,
+			fmt.Sprintf("%s: %v", msgFailedStructuredAuthorization, err))
+	}
+
+	data, err := getAuditLogData(ctx, s, m)
 
 	if err != nil {
 		m.log.Error(err, msgFailedToConfigureAuditlogs)
@@ -78,10 +95,36 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 			msgFailedToConfigureAuditlogs)
 	}
 
-	shoot, err := convertCreate(&s.instance, gardener_shoot.CreateOpts{
-		ConverterConfig:       m.ConverterConfig,
-		AuditLogData:          data,
-		MaintenanceTimeWindow: getMaintenanceTimeWindow(s, m),
+	auditPolicyConfigMapName, err := ensureAuditPolicy(ctx, m, s.instance)
+	if err != nil {
+		m.log.Error(err, msgFailedToConfigureAuditPolicy)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(
+			&s.instance,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonAuditLogError,
+			fmt.Sprintf("%s: %v", msgFailedToConfigureAuditPolicy, err))
+	}
+
+	runtimeWithZones, err := withSelectedWorkerZones(ctx, m.SeedClient, &s.instance, nil, m.ConverterConfig.Provider.SingleZonePlans)
+	if err != nil {
+		m.log.Error(err, msgFailedToSelectZones)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(
+			&s.instance,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonZonesSelectionError,
+			fmt.Sprintf("%s: %v", msgFailedToSelectZones, err))
+	}
+
+	now := time.Now()
+	shoot, err := convertCreate(runtimeWithZones, gardener_shoot.CreateOpts{
+		ConverterConfig:          m.ConverterConfig,
+		AuditLogData:             data,
+		MaintenanceTimeWindow:    getMaintenanceTimeWindow(s, m),
+		AuditPolicyConfigMapName: auditPolicyConfigMapName,
+		OIDCExtensionEnabled:     len(openIDConnectConfigs) > 0,
+		SSHAccessEnabled:         s.instance.Spec.Shoot.SSHAccess.IsActive(now),
 	})
 	if err != nil {
 		m.log.Error(err, "Failed to convert Runtime instance to shoot object")
@@ -105,6 +148,9 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 		return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
 	}
 
+	updateSSHAccessStatus(m, &s.instance, now)
+	updateAuditLogStatus(m, &s.instance, data)
+
 	m.log.V(log_level.DEBUG).Info(
 		"Gardener shoot for runtime initialised successfully",
 		"name", shoot.Name,
@@ -6,6 +6,7 @@ import (
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauth"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauthz"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,6 +52,21 @@ func sFnDeleteShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 		return updateStatusAndRequeueAfter(m.RequeueDurationShootDelete)
 	}
 
+	m.log.Info("deleting structured authorization config", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
+	err = structuredauthz.Delete(ctx, m.SeedClient, s.shoot.Namespace, s.shoot.Name)
+	if err != nil {
+		// action error handler section
+		m.log.Error(err, "Failed to delete structured authorization configmap and webhook kubeconfig secrets")
+		s.instance.UpdateStateDeletion(
+			imv1.ConditionTypeRuntimeDeprovisioned,
+			imv1.ConditionReasonStructuredConfigDeleted,
+			"False",
+			"Gardener API structured authorization configmap delete error",
+		)
+
+		return updateStatusAndRequeueAfter(m.RequeueDurationShootDelete)
+	}
+
 	m.log.Info("deleting shoot", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
 	err = m.SeedClient.Delete(ctx, s.shoot)
 	if err != nil {
@@ -0,0 +1,118 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	imgardenerhandler "github.com/kyma-project/infrastructure-manager/pkg/gardener"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sFnForwardGardenerOperation forwards the Gardener operation requested on the Runtime to the shoot.
+// The Runtime annotation is removed once the operation is forwarded, the outcome is tracked in the GardenerOperation condition.
+func sFnForwardGardenerOperation(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	operation, _, allowed := reconciler.GetGardenerOperation(s.instance.Annotations)
+	shootGeneration := s.shoot.Generation
+
+	if allowed {
+		if _, pending := s.shoot.Annotations[imv1.AnnotationGardenerOperation]; pending {
+			m.log.Info("Shoot has a pending Gardener operation, scheduling for retry", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+			return requeueAfter(m.GardenerRequeueDuration)
+		}
+
+		if err := annotateShootWithOperation(ctx, m.SeedClient, s.shoot, operation); err != nil {
+			m.log.Error(err, "Failed to forward Gardener operation, scheduling for retry", "operation", operation)
+			return requeueAfter(m.GardenerRequeueDuration)
+		}
+	}
+
+	annotations := s.instance.GetAnnotations()
+	delete(annotations, reconciler.GardenerOperationAnnotation)
+	s.instance.SetAnnotations(annotations)
+
+	if err := m.KcpClient.Update(ctx, &s.instance); err != nil {
+		m.log.Error(err, "could not remove Gardener operation annotation, scheduling for retry")
+		return requeue()
+	}
+
+	s.instance.Status.GardenerOperation = operation
+
+	if !allowed {
+		msg := fmt.Sprintf("Gardener operation %s is not allowed, allowed operations: %v", operation, reconciler.AllowedGardenerOperations)
+		m.log.Info(msg, "RuntimeCR", s.instance.Name)
+		s.instance.UpdateCondition(imv1.ConditionTypeGardenerOperation, imv1.ConditionReasonGardenerOperationRejected, "False", msg)
+		return updateStatusAndRequeue()
+	}
+
+	m.log.Info("Gardener operation forwarded to shoot", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name, "operation", operation)
+	s.instance.Status.GardenerOperationShootGeneration = shootGeneration
+	s.instance.UpdateCondition(
+		imv1.ConditionTypeGardenerOperation,
+		imv1.ConditionReasonGardenerOperationForwarded,
+		"Unknown",
+		fmt.Sprintf("Gardener operation %s forwarded to shoot", operation))
+	return updateStatusAndRequeueAfter(m.RequeueDurationShootReconcile)
+}
+
+// trackGardenerOperation reports the outcome of the forwarded Gardener operation in the GardenerOperation condition and returns true while the operation is in progress.
+// The operation is finished when the shoot generation increased by the operation is reconciled, operations which do not increase the generation finish once the annotation is removed.
+func trackGardenerOperation(runtime *imv1.Runtime, shoot *gardener.Shoot) bool {
+	if !gardenerOperationForwarded(runtime) {
+		return false
+	}
+
+	operation := runtime.Status.GardenerOperation
+	lastOperation := shoot.Status.LastOperation
+
+	// Gardener removes the operation annotation once the operation is picked up
+	if _, pending := shoot.Annotations[imv1.AnnotationGardenerOperation]; pending {
+		return true
+	}
+
+	if shoot.Generation <= runtime.Status.GardenerOperationShootGeneration {
+		runtime.UpdateCondition(
+			imv1.ConditionTypeGardenerOperation,
+			imv1.ConditionReasonGardenerOperationCompleted,
+			"True",
+			fmt.Sprintf("Gardener operation %s completed without shoot reconciliation", operation))
+		return false
+	}
+
+	if shoot.Status.ObservedGeneration < shoot.Generation || lastOperation == nil {
+		return true
+	}
+
+	switch lastOperation.State {
+	case gardener.LastOperationStateSucceeded:
+		runtime.UpdateCondition(
+			imv1.ConditionTypeGardenerOperation,
+			imv1.ConditionReasonGardenerOperationCompleted,
+			"True",
+			fmt.Sprintf("Gardener operation %s completed", operation))
+		return false
+
+	case gardener.LastOperationStateFailed:
+		reason := imgardenerhandler.ToErrReason(shoot.Status.LastErrors...)
+		runtime.UpdateCondition(
+			imv1.ConditionTypeGardenerOperation,
+			imv1.ConditionReasonGardenerOperationFailed,
+			"False",
+			fmt.Sprintf("Gardener operation %s failed, reason: %s", operation, reason))
+		return false
+	}
+
+	return true
+}
+
+// gardenerOperationRequested returns true when a Gardener operation is requested with the annotation
+func gardenerOperationRequested(runtime *imv1.Runtime) bool {
+	_, found, _ := reconciler.GetGardenerOperation(runtime.Annotations)
+	return found
+}
+
+func gardenerOperationForwarded(runtime *imv1.Runtime) bool {
+	return runtime.IsConditionSet(imv1.ConditionTypeGardenerOperation, imv1.ConditionReasonGardenerOperationForwarded)
+}
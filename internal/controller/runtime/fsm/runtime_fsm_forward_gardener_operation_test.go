@@ -0,0 +1,200 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const forwardedShootGeneration = int64(5)
+
+func fixRuntimeWithGardenerOperation(annotations map[string]string, forwardedOperation string) *imv1.Runtime {
+	runtime := &imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "runtime",
+			Namespace:   "kcp-system",
+			Annotations: annotations,
+		},
+		Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady},
+	}
+	if forwardedOperation != "" {
+		runtime.Status.GardenerOperation = forwardedOperation
+		runtime.Status.GardenerOperationShootGeneration = forwardedShootGeneration
+		runtime.Status.Conditions = []metav1.Condition{
+			{
+				Type:   string(imv1.ConditionTypeGardenerOperation),
+				Status: metav1.ConditionUnknown,
+				Reason: string(imv1.ConditionReasonGardenerOperationForwarded),
+			},
+		}
+	}
+	return runtime
+}
+
+func fixShootWithGardenerOperation(annotations map[string]string, state gardener.LastOperationState, generation, observedGeneration int64) *gardener.Shoot {
+	return &gardener.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-test", Annotations: annotations, Generation: generation},
+		Status: gardener.ShootStatus{
+			ObservedGeneration: observedGeneration,
+			LastOperation: &gardener.LastOperation{
+				State: state,
+			},
+		},
+	}
+}
+
+func TestForwardGardenerOperation(t *testing.T) {
+	for _, tc := range []struct {
+		name                       string
+		runtime                    *imv1.Runtime
+		shoot                      *gardener.Shoot
+		expectedReason             imv1.RuntimeConditionReason
+		expectedConditionStatus    metav1.ConditionStatus
+		expectedShootOperation     string
+		expectedRecordedGeneration int64
+	}{
+		{
+			name:                       "Forward allowed operation to shoot",
+			runtime:                    fixRuntimeWithGardenerOperation(map[string]string{reconciler.GardenerOperationAnnotation: "maintain"}, ""),
+			shoot:                      fixShootWithGardenerOperation(nil, gardener.LastOperationStateSucceeded, forwardedShootGeneration, forwardedShootGeneration),
+			expectedReason:             imv1.ConditionReasonGardenerOperationForwarded,
+			expectedConditionStatus:    metav1.ConditionUnknown,
+			expectedShootOperation:     "maintain",
+			expectedRecordedGeneration: forwardedShootGeneration,
+		},
+		{
+			name:                    "Reject operation which is not allowed",
+			runtime:                 fixRuntimeWithGardenerOperation(map[string]string{reconciler.GardenerOperationAnnotation: "rotate-etcd-encryption-key-start"}, ""),
+			shoot:                   fixShootWithGardenerOperation(nil, gardener.LastOperationStateSucceeded, forwardedShootGeneration, forwardedShootGeneration),
+			expectedReason:          imv1.ConditionReasonGardenerOperationRejected,
+			expectedConditionStatus: metav1.ConditionFalse,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			scheme, err := newCreateTestScheme()
+			require.NoError(t, err)
+			require.NoError(t, imv1.AddToScheme(scheme))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.runtime, tc.shoot).Build()
+			testFsm := &fsm{
+				log: logr.Discard(),
+				K8s: K8s{KcpClient: fakeClient, SeedClient: fakeClient},
+			}
+
+			var runtime imv1.Runtime
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.runtime), &runtime))
+			var shoot gardener.Shoot
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.shoot), &shoot))
+			state := &systemState{instance: runtime, shoot: shoot.DeepCopy()}
+
+			// when
+			_, _, err = sFnForwardGardenerOperation(context.Background(), testFsm, state)
+
+			// then
+			require.NoError(t, err)
+
+			condition := meta.FindStatusCondition(state.instance.Status.Conditions, string(imv1.ConditionTypeGardenerOperation))
+			require.NotNil(t, condition)
+			assert.Equal(t, string(tc.expectedReason), condition.Reason)
+			assert.Equal(t, tc.expectedConditionStatus, condition.Status)
+			assert.Equal(t, tc.expectedRecordedGeneration, state.instance.Status.GardenerOperationShootGeneration)
+			assert.NotContains(t, state.instance.Annotations, reconciler.GardenerOperationAnnotation)
+
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.shoot), &shoot))
+			assert.Equal(t, tc.expectedShootOperation, shoot.Annotations[imv1.AnnotationGardenerOperation])
+		})
+	}
+}
+
+func TestTrackGardenerOperation(t *testing.T) {
+	for _, tc := range []struct {
+		name                    string
+		runtime                 *imv1.Runtime
+		shoot                   *gardener.Shoot
+		expectedInProgress      bool
+		expectedReason          imv1.RuntimeConditionReason
+		expectedConditionStatus metav1.ConditionStatus
+	}{
+		{
+			name:                    "Wait until operation is picked up by Gardener",
+			runtime:                 fixRuntimeWithGardenerOperation(nil, "retry"),
+			shoot:                   fixShootWithGardenerOperation(map[string]string{imv1.AnnotationGardenerOperation: "retry"}, gardener.LastOperationStateFailed, forwardedShootGeneration, forwardedShootGeneration),
+			expectedInProgress:      true,
+			expectedReason:          imv1.ConditionReasonGardenerOperationForwarded,
+			expectedConditionStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:                    "Ignore last operation of the shoot generation reconciled before the operation was forwarded",
+			runtime:                 fixRuntimeWithGardenerOperation(nil, "reconcile"),
+			shoot:                   fixShootWithGardenerOperation(nil, gardener.LastOperationStateSucceeded, forwardedShootGeneration+1, forwardedShootGeneration),
+			expectedInProgress:      true,
+			expectedReason:          imv1.ConditionReasonGardenerOperationForwarded,
+			expectedConditionStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:                    "Wait until reconciliation of the operation is finished",
+			runtime:                 fixRuntimeWithGardenerOperation(nil, "reconcile"),
+			shoot:                   fixShootWithGardenerOperation(nil, gardener.LastOperationStateProcessing, forwardedShootGeneration+1, forwardedShootGeneration+1),
+			expectedInProgress:      true,
+			expectedReason:          imv1.ConditionReasonGardenerOperationForwarded,
+			expectedConditionStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:                    "Report completed operation",
+			runtime:                 fixRuntimeWithGardenerOperation(nil, "reconcile"),
+			shoot:                   fixShootWithGardenerOperation(nil, gardener.LastOperationStateSucceeded, forwardedShootGeneration+1, forwardedShootGeneration+1),
+			expectedReason:          imv1.ConditionReasonGardenerOperationCompleted,
+			expectedConditionStatus: metav1.ConditionTrue,
+		},
+		{
+			name:                    "Report completed operation which did not trigger shoot reconciliation",
+			runtime:                 fixRuntimeWithGardenerOperation(nil, "rotate-ssh-keypair"),
+			shoot:                   fixShootWithGardenerOperation(nil, gardener.LastOperationStateSucceeded, forwardedShootGeneration, forwardedShootGeneration),
+			expectedReason:          imv1.ConditionReasonGardenerOperationCompleted,
+			expectedConditionStatus: metav1.ConditionTrue,
+		},
+		{
+			name:                    "Report failed operation",
+			runtime:                 fixRuntimeWithGardenerOperation(nil, "reconcile"),
+			shoot:                   fixShootWithGardenerOperation(nil, gardener.LastOperationStateFailed, forwardedShootGeneration+1, forwardedShootGeneration+1),
+			expectedReason:          imv1.ConditionReasonGardenerOperationFailed,
+			expectedConditionStatus: metav1.ConditionFalse,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			inProgress := trackGardenerOperation(tc.runtime, tc.shoot)
+
+			// then
+			assert.Equal(t, tc.expectedInProgress, inProgress)
+
+			condition := meta.FindStatusCondition(tc.runtime.Status.Conditions, string(imv1.ConditionTypeGardenerOperation))
+			require.NotNil(t, condition)
+			assert.Equal(t, string(tc.expectedReason), condition.Reason)
+			assert.Equal(t, tc.expectedConditionStatus, condition.Status)
+		})
+	}
+
+	t.Run("Ignore runtime without forwarded operation", func(t *testing.T) {
+		// given
+		runtime := fixRuntimeWithGardenerOperation(nil, "")
+
+		// when
+		inProgress := trackGardenerOperation(runtime, fixShootWithGardenerOperation(nil, gardener.LastOperationStateSucceeded, 1, 1))
+
+		// then
+		assert.False(t, inProgress)
+		assert.Empty(t, runtime.Status.Conditions)
+	})
+}
@@ -0,0 +1,77 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Shoots are not watched, runtimes with hibernation schedules are requeued so the hibernation state follows the scheduled hibernation and wake-up
+const hibernationScheduleRequeueDuration = 5 * time.Minute
+
+// sFnHandleHibernatedShoot stops processing of a hibernated shoot, the runtime cluster cannot be configured until it is woken up.
+// Runtimes which have never been provisioned stay pending until the shoot is woken up and the SKR configuration is completed.
+func sFnHandleHibernatedShoot(_ context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info(fmt.Sprintf("Shoot %s is hibernated, configuration will be completed after it is woken up", s.shoot.Name))
+
+	s.instance.Status.HibernationState = imv1.HibernationStateHibernated
+
+	if !s.instance.IsProvisioningCompletedStatusSet() {
+		s.instance.UpdateStatePending(
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonHibernated,
+			"Unknown",
+			"Runtime is hibernated, provisioning will be completed after it is woken up")
+
+		return updateStatusAndStop()
+	}
+
+	s.instance.UpdateStateReady(
+		imv1.ConditionTypeRuntimeProvisioned,
+		imv1.ConditionReasonHibernated,
+		"Runtime is hibernated")
+
+	return updateStatusAndStop()
+}
+
+// hibernationState derives the hibernation state from the desired and the observed state of the shoot.
+// The state is empty for shoots which have never been configured for hibernation.
+func hibernationState(shoot *gardener.Shoot) imv1.HibernationState {
+	hibernationEnabled := v1beta1helper.HibernationIsEnabled(shoot)
+
+	switch {
+	case hibernationEnabled && shoot.Status.IsHibernated:
+		return imv1.HibernationStateHibernated
+	case hibernationEnabled:
+		return imv1.HibernationStateHibernating
+	case shoot.Status.IsHibernated:
+		return imv1.HibernationStateWakingUp
+	case shoot.Spec.Hibernation == nil:
+		return ""
+	default:
+		return imv1.HibernationStateAwake
+	}
+}
+
+// withHibernationScheduleRequeue makes sure runtimes with a scheduled hibernation are reconciled while the schedules are active
+func withHibernationScheduleRequeue(result ctrl.Result, instance *imv1.Runtime, shoot *gardener.Shoot) ctrl.Result {
+	if shoot == nil || shoot.Spec.Hibernation == nil || len(shoot.Spec.Hibernation.Schedules) == 0 || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+
+	// runtimes hibernated before provisioning stay pending until the shoot is woken up
+	if instance.Status.State != imv1.RuntimeStateReady && !instance.IsConditionSet(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonHibernated) {
+		return result
+	}
+
+	if result.RequeueAfter == 0 || result.RequeueAfter > hibernationScheduleRequeueDuration {
+		result.RequeueAfter = hibernationScheduleRequeueDuration
+	}
+
+	return result
+}
@@ -0,0 +1,177 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestHibernationState(t *testing.T) {
+	for _, testCase := range []struct {
+		name          string
+		hibernation   *gardener.Hibernation
+		isHibernated  bool
+		expectedState imv1.HibernationState
+	}{
+		{
+			name:          "No state for shoot without hibernation settings",
+			expectedState: "",
+		},
+		{
+			name:          "Awake when hibernation is disabled",
+			hibernation:   &gardener.Hibernation{Enabled: ptr.To(false)},
+			expectedState: imv1.HibernationStateAwake,
+		},
+		{
+			name:          "Awake when only schedules are set",
+			hibernation:   &gardener.Hibernation{Schedules: []gardener.HibernationSchedule{{Start: ptr.To("0 20 * * *")}}},
+			expectedState: imv1.HibernationStateAwake,
+		},
+		{
+			name:          "Hibernating when hibernation is enabled and shoot is not hibernated yet",
+			hibernation:   &gardener.Hibernation{Enabled: ptr.To(true)},
+			expectedState: imv1.HibernationStateHibernating,
+		},
+		{
+			name:          "Hibernated when hibernation is enabled and shoot is hibernated",
+			hibernation:   &gardener.Hibernation{Enabled: ptr.To(true)},
+			isHibernated:  true,
+			expectedState: imv1.HibernationStateHibernated,
+		},
+		{
+			name:          "Waking up when hibernation is disabled and shoot is still hibernated",
+			hibernation:   &gardener.Hibernation{Enabled: ptr.To(false)},
+			isHibernated:  true,
+			expectedState: imv1.HibernationStateWakingUp,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			shoot := &gardener.Shoot{
+				Spec:   gardener.ShootSpec{Hibernation: testCase.hibernation},
+				Status: gardener.ShootStatus{IsHibernated: testCase.isHibernated},
+			}
+
+			// when
+			state := hibernationState(shoot)
+
+			// then
+			assert.Equal(t, testCase.expectedState, state)
+		})
+	}
+}
+
+func TestHandleHibernatedShoot(t *testing.T) {
+	for _, testCase := range []struct {
+		name                    string
+		provisioningCompleted   bool
+		expectedState           imv1.State
+		expectedConditionStatus metav1.ConditionStatus
+	}{
+		{
+			name:                    "Keep runtime pending when the shoot is hibernated before the runtime was provisioned",
+			expectedState:           imv1.RuntimeStatePending,
+			expectedConditionStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:                    "Set runtime ready when a provisioned runtime is hibernated",
+			provisioningCompleted:   true,
+			expectedState:           imv1.RuntimeStateReady,
+			expectedConditionStatus: metav1.ConditionTrue,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			testFsm := &fsm{log: logr.Discard()}
+			systemState := &systemState{
+				instance: imv1.Runtime{
+					Status: imv1.RuntimeStatus{ProvisioningCompleted: testCase.provisioningCompleted},
+				},
+				shoot: &gardener.Shoot{
+					ObjectMeta: metav1.ObjectMeta{Name: "shoot"},
+					Spec:       gardener.ShootSpec{Hibernation: &gardener.Hibernation{Enabled: ptr.To(true)}},
+					Status:     gardener.ShootStatus{IsHibernated: true},
+				},
+			}
+
+			// when
+			stateFn, _, _ := sFnHandleHibernatedShoot(context.Background(), testFsm, systemState)
+
+			// then
+			require.NotNil(t, stateFn)
+			assert.Contains(t, stateFn.name(), "sFnUpdateStatus")
+			assert.Equal(t, testCase.expectedState, systemState.instance.Status.State)
+			assert.Equal(t, imv1.HibernationStateHibernated, systemState.instance.Status.HibernationState)
+
+			condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeProvisioned))
+			require.NotNil(t, condition)
+			assert.Equal(t, string(imv1.ConditionReasonHibernated), condition.Reason)
+			assert.Equal(t, testCase.expectedConditionStatus, condition.Status)
+		})
+	}
+}
+
+func TestWithHibernationScheduleRequeue(t *testing.T) {
+	scheduled := &gardener.Shoot{}
+	scheduled.Spec.Hibernation = &gardener.Hibernation{
+		Schedules: []gardener.HibernationSchedule{{Start: ptr.To("00 20 * * *"), End: ptr.To("00 06 * * *")}},
+	}
+
+	notScheduled := &gardener.Shoot{}
+	notScheduled.Spec.Hibernation = &gardener.Hibernation{Enabled: ptr.To(true)}
+
+	hibernatedBeforeProvisioning := imv1.Runtime{}
+	hibernatedBeforeProvisioning.UpdateStatePending(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonHibernated, "Unknown", "hibernated")
+
+	for tname, tc := range map[string]struct {
+		runtime  imv1.Runtime
+		shoot    *gardener.Shoot
+		result   ctrl.Result
+		expected ctrl.Result
+	}{
+		"Should requeue ready runtime with hibernation schedules": {
+			runtime:  imv1.Runtime{Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady}},
+			shoot:    scheduled,
+			expected: ctrl.Result{RequeueAfter: hibernationScheduleRequeueDuration},
+		},
+		"Should requeue runtime hibernated before provisioning": {
+			runtime:  hibernatedBeforeProvisioning,
+			shoot:    scheduled,
+			expected: ctrl.Result{RequeueAfter: hibernationScheduleRequeueDuration},
+		},
+		"Should keep an earlier requeue": {
+			runtime:  imv1.Runtime{Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady}},
+			shoot:    scheduled,
+			result:   ctrl.Result{RequeueAfter: time.Minute},
+			expected: ctrl.Result{RequeueAfter: time.Minute},
+		},
+		"Should not requeue runtime without hibernation schedules": {
+			runtime:  imv1.Runtime{Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady}},
+			shoot:    notScheduled,
+			expected: ctrl.Result{},
+		},
+		"Should not requeue failed runtime": {
+			runtime:  imv1.Runtime{Status: imv1.RuntimeStatus{State: imv1.RuntimeStateFailed}},
+			shoot:    scheduled,
+			expected: ctrl.Result{},
+		},
+		"Should not requeue without shoot": {
+			runtime:  imv1.Runtime{Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady}},
+			expected: ctrl.Result{},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			assert.Equal(t, tc.expected, withHibernationScheduleRequeue(tc.result, &tc.runtime, tc.shoot))
+		})
+	}
+}
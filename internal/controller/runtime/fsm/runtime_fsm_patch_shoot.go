@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauth"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauthz"
 	"reflect"
+	"time"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
@@ -15,6 +17,7 @@ import (
 	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
 	"github.com/kyma-project/kim-snatch/api/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -24,9 +27,7 @@ import (
 const fieldManagerName = "kim"
 
 func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
-	data, err := m.AuditLogging.GetAuditLogData(
-		s.instance.Spec.Shoot.Provider.Type,
-		s.instance.Spec.Shoot.Region)
+	data, err := getAuditLogData(ctx, s, m)
 
 	if err != nil {
 		m.log.Error(err, msgFailedToConfigureAuditlogs)
@@ -41,23 +42,48 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 			msgFailedToConfigureAuditlogs)
 	}
 
+	auditPolicyConfigMapName, err := ensureAuditPolicy(ctx, m, s.instance)
+	if err != nil {
+		m.log.Error(err, msgFailedToConfigureAuditPolicy)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(
+			&s.instance,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonAuditLogError,
+			fmt.Sprintf("%s: %v", msgFailedToConfigureAuditPolicy, err))
+	}
+
 	oidcConfig := structuredauth.GetOIDCConfigOrDefault(s.instance, m.ConverterConfig.Kubernetes.DefaultOperatorOidc.ToOIDCConfig())
+	additionalOidcConfigs := structuredauth.GetAdditionalOIDCConfigsOrDefault(s.instance, m.ClusterConfig.DefaultSharedIASTenant.ToOIDCConfig())
 
 	cmName := fmt.Sprintf(extender.StructuredAuthConfigFmt, s.instance.Spec.Shoot.Name)
-	err = structuredauth.CreateOrUpdateStructuredAuthConfigMap(
+	openIDConnectConfigs, err := structuredauth.CreateOrUpdateStructuredAuthConfigMap(
 		ctx,
 		m.SeedClient,
 		types.NamespacedName{Name: cmName, Namespace: m.ShootNamesapace},
 		oidcConfig,
+		additionalOidcConfigs,
 	)
 
 	if err != nil {
+		m.log.Error(err, "Failed to create structured authentication config map")
 		m.Metrics.IncRuntimeFSMStopCounter()
 		return updateStatePendingWithErrorAndStop(
 			&s.instance,
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonOidcError,
-			msgFailedStructuredConfigMap)
+			fmt.Sprintf("%s: %v", msgFailedStructuredConfigMap, err))
+	}
+
+	err = structuredauthz.CreateOrUpdate(ctx, m.KcpClient, m.SeedClient, m.ShootNamesapace, s.instance)
+	if err != nil {
+		m.log.Error(err, msgFailedStructuredAuthorization)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(
+			&s.instance,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonAuthorizationError,
+			fmt.Sprintf("%s: %v", msgFailedStructuredAuthorization, err))
 	}
 
 	var registrycache []v1beta1.RegistryCache
@@ -76,19 +102,60 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 		}
 	}
 
+	controlPlane := s.instance.Spec.Shoot.ControlPlane
+	err = validateControlPlaneTransition(s.shoot, controlPlane)
+	if err == nil && controlPlaneHAMigrationRequested(s.shoot, controlPlane) {
+		err = verifySeedForControlPlaneMigration(ctx, m.SeedClient, s.shoot, controlPlane)
+	}
+
+	if err != nil {
+		m.log.Error(err, msgFailedToConfigureControlPlaneHA)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		msg := fmt.Sprintf("%s: %v", msgFailedToConfigureControlPlaneHA, err)
+		s.instance.UpdateCondition(imv1.ConditionTypeControlPlaneHA, imv1.ConditionReasonControlPlaneHAError, "False", msg)
+		return updateStatePendingWithErrorAndStop(
+			&s.instance,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonControlPlaneHAError,
+			msg)
+	}
+
+	// the error of a rejected transition is cleared once the Runtime CR requests a valid one
+	if s.instance.IsConditionSet(imv1.ConditionTypeControlPlaneHA, imv1.ConditionReasonControlPlaneHAError) {
+		meta.RemoveStatusCondition(&s.instance.Status.Conditions, string(imv1.ConditionTypeControlPlaneHA))
+	}
+
+	runtimeWithZones, err := withSelectedWorkerZones(ctx, m.SeedClient, &s.instance, s.shoot.Spec.Provider.Workers, m.ConverterConfig.Provider.SingleZonePlans)
+	if err != nil {
+		m.log.Error(err, msgFailedToSelectZones)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(
+			&s.instance,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonZonesSelectionError,
+			fmt.Sprintf("%s: %v", msgFailedToSelectZones, err))
+	}
+
+	now := time.Now()
+
 	// NOTE: In the future we want to pass the whole shoot object here
-	updatedShoot, err := convertPatch(&s.instance, gardener_shoot.PatchOpts{
-		ConverterConfig:       m.ConverterConfig,
-		AuditLogData:          data,
-		MaintenanceTimeWindow: getMaintenanceTimeWindow(s, m),
-		Workers:               s.shoot.Spec.Provider.Workers,
-		ShootK8SVersion:       s.shoot.Spec.Kubernetes.Version,
-		Extensions:            s.shoot.Spec.Extensions,
-		Resources:             s.shoot.Spec.Resources,
-		InfrastructureConfig:  s.shoot.Spec.Provider.InfrastructureConfig,
-		ControlPlaneConfig:    s.shoot.Spec.Provider.ControlPlaneConfig,
-		Log:                   ptr.To(m.log),
-		RegistryCache:         registrycache,
+	updatedShoot, err := convertPatch(runtimeWithZones, gardener_shoot.PatchOpts{
+		ConverterConfig:          m.ConverterConfig,
+		AuditLogData:             data,
+		MaintenanceTimeWindow:    getMaintenanceTimeWindow(s, m),
+		Workers:                  s.shoot.Spec.Provider.Workers,
+		ShootK8SVersion:          s.shoot.Spec.Kubernetes.Version,
+		Extensions:               s.shoot.Spec.Extensions,
+		Resources:                s.shoot.Spec.Resources,
+		InfrastructureConfig:     s.shoot.Spec.Provider.InfrastructureConfig,
+		ControlPlaneConfig:       s.shoot.Spec.Provider.ControlPlaneConfig,
+		Log:                      ptr.To(m.log),
+		RegistryCache:            registrycache,
+		ClusterAutoscaler:        s.shoot.Spec.Kubernetes.ClusterAutoscaler,
+		ShootHibernation:         s.shoot.Spec.Hibernation,
+		AuditPolicyConfigMapName: auditPolicyConfigMapName,
+		OIDCExtensionEnabled:     len(openIDConnectConfigs) > 0,
+		SSHAccessEnabled:         s.instance.Spec.Shoot.SSHAccess.IsActive(now),
 	})
 
 	if err != nil {
@@ -131,6 +198,14 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 		return nextState, res, err
 	}
 
+	updateSSHAccessStatus(m, &s.instance, now)
+	updateAuditLogStatus(m, &s.instance, data)
+
+	// the shoot does not reference removed webhook authorizers anymore, a failed cleanup is retried with the next patch
+	if err := structuredauthz.DeleteUnused(ctx, m.SeedClient, m.ShootNamesapace, s.instance); err != nil {
+		m.log.Error(err, "Failed to delete unused structured authorization objects")
+	}
+
 	err = handleForceReconciliationAnnotation(&s.instance, m, ctx)
 	if err != nil {
 		m.log.Error(err, "could not handle force reconciliation annotation. Scheduling for retry.")
@@ -152,6 +227,14 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 
 	m.log.V(log_level.DEBUG).Info("Gardener shoot for runtime patched successfully", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
 
+	if controlPlaneHAMigrationRequested(s.shoot, controlPlane) {
+		s.instance.UpdateCondition(
+			imv1.ConditionTypeControlPlaneHA,
+			imv1.ConditionReasonControlPlaneHAMigrationPending,
+			"Unknown",
+			fmt.Sprintf("Control plane migration to %s failure tolerance is in progress", failureToleranceType(controlPlane)))
+	}
+
 	s.instance.UpdateStatePending(
 		imv1.ConditionTypeRuntimeProvisioned,
 		imv1.ConditionReasonProcessing,
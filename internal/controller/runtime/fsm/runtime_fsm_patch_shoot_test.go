@@ -35,6 +35,10 @@ var _ = Describe("KIM sFnPatchExistingShoot", func() {
 	expectedAnnotations := map[string]string{"operator.kyma-project.io/existing-annotation": "true"}
 	inputRuntimeWithForceAnnotation := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/force-patch-reconciliation": "true", "operator.kyma-project.io/existing-annotation": "true"})
 	inputRuntime := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/existing-annotation": "true"})
+	inputRuntimeWithHAError := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/existing-annotation": "true"})
+	inputRuntimeWithHAError.UpdateCondition(imv1.ConditionTypeControlPlaneHA, imv1.ConditionReasonControlPlaneHAError, "False", "control plane failure tolerance cannot be changed from zone to node")
+
+	auditLogSecret := &core_v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "garden-"}}
 
 	testFunction := buildPatchTestFunction(sFnPatchExistingShoot)
 
@@ -54,6 +58,18 @@ var _ = Describe("KIM sFnPatchExistingShoot", func() {
 				status:      fsm_testing.PendingStatusShootPatched(),
 			},
 		),
+		Entry(
+			"should clear control plane high availability error after the transition is reverted",
+			testCtx,
+			setupFakeFSMForTest(testScheme, inputRuntimeWithHAError),
+			&systemState{instance: *inputRuntimeWithHAError, shoot: fsm_testing.TestShootForPatch()},
+			outputFnState{
+				nextStep:    haveName("sFnUpdateStatus"),
+				annotations: expectedAnnotations,
+				result:      nil,
+				status:      fsm_testing.PendingStatusShootPatched(),
+			},
+		),
 		Entry(
 			"should transition to Pending Unknown state after successful patching and remove force patch annotation",
 			testCtx,
@@ -81,13 +97,25 @@ var _ = Describe("KIM sFnPatchExistingShoot", func() {
 		Entry(
 			"should transition to Pending Unknown state after successful patching when Audit Logs are mandatory and Audit Log Config can be read",
 			testCtx,
+			setupFakeFSMForTestWithAuditLogMandatoryAndConfig(testScheme, inputRuntime, auditLogSecret),
+			&systemState{instance: *inputRuntime, shoot: fsm_testing.TestShootForPatch()},
+			outputFnState{
+				nextStep:    haveName("sFnUpdateStatus"),
+				annotations: expectedAnnotations,
+				result:      nil,
+				status:      withAuditLogStatus(fsm_testing.PendingStatusShootPatched(), "test-tenant", "test-secret"),
+			},
+		),
+		Entry(
+			"should transition to Failed state when Audit Logs are mandatory and Audit Log secret does not exist in the project",
+			testCtx,
 			setupFakeFSMForTestWithAuditLogMandatoryAndConfig(testScheme, inputRuntime),
 			&systemState{instance: *inputRuntime, shoot: fsm_testing.TestShootForPatch()},
 			outputFnState{
 				nextStep:    haveName("sFnUpdateStatus"),
 				annotations: expectedAnnotations,
 				result:      nil,
-				status:      fsm_testing.PendingStatusShootPatched(),
+				status:      fsm_testing.FailedStatusAuditLogError(),
 			},
 		),
 		Entry(
@@ -293,12 +321,12 @@ func setupFakeFSMForTestWithAuditLogMandatory(scheme *api.Scheme, runtime *imv1.
 	)
 }
 
-func setupFakeFSMForTestWithAuditLogMandatoryAndConfig(scheme *api.Scheme, runtime *imv1.Runtime) *fsm {
+func setupFakeFSMForTestWithAuditLogMandatoryAndConfig(scheme *api.Scheme, runtime *imv1.Runtime, objs ...client.Object) *fsm {
 	return must(newFakeFSM,
 		withMockedMetrics(),
 		withShootNamespace("garden-"),
 		withTestFinalizer,
-		withFakedK8sClient(scheme, runtime),
+		withFakedK8sClient(scheme, append([]client.Object{runtime}, objs...)...),
 		withFakeEventRecorder(1),
 		withDefaultReconcileDuration(),
 		withAuditLogMandatory(true),
@@ -310,6 +338,11 @@ func setupFakeFSMForTestWithAuditLogMandatoryAndConfig(scheme *api.Scheme, runti
 	)
 }
 
+func withAuditLogStatus(status imv1.RuntimeStatus, tenantID, secretName string) imv1.RuntimeStatus {
+	status.AuditLog = &imv1.AuditLogStatus{TenantID: tenantID, SecretName: secretName}
+	return status
+}
+
 func buildPatchTestFunction(fn stateFn) func(context.Context, *fsm, *systemState, outputFnState) {
 	return func(ctx context.Context, r *fsm, s *systemState, expected outputFnState) {
 
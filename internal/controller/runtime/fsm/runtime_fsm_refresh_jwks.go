@@ -0,0 +1,230 @@
+package fsm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauth"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// retry delay for runtimes with pinned keys which were never refreshed
+	jwksInitialRefreshDelay = time.Second
+	// the issuers are queried concurrently, the refresh blocks the worker for at most this duration
+	jwksRefreshTimeout = 10 * time.Second
+)
+
+func sFnRefreshPinnedJWKS(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	previousIssuers := s.instance.Status.OIDCIssuers
+
+	keysChanged := refreshPinnedJWKS(ctx, m, &s.instance, time.Now())
+	if !keysChanged || !isOidcExtensionEnabled(*s.shoot) {
+		return updateStatusAndStop()
+	}
+
+	// the pinned keys are rendered into the OpenIDConnect resources used by the kube-apiserver to verify the tokens of the issuers
+	if err := applyOpenIDConnectResources(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to apply pinned OIDC issuer keys to OpenIDConnect resources")
+
+		// the previous keys are kept so the new keys are applied by the next refresh
+		s.instance.Status.OIDCIssuers = previousIssuers
+		s.instance.UpdateCondition(imv1.ConditionTypeOIDCIssuerKeys, imv1.ConditionReasonOIDCIssuerKeysNotApplied, "False",
+			"Failed to apply pinned signing keys to OpenIDConnect resources, scheduling for retry")
+		return updateStatusAndRequeueAfter(m.ControlPlaneRequeueDuration)
+	}
+
+	return updateStatusAndStop()
+}
+
+type discoveredKeys struct {
+	keys structuredauth.PinnedKeys
+	err  error
+}
+
+// refreshPinnedJWKS fetches the signing keys of the additional OIDC issuers with JWKS pinning enabled, and reports rotated keys and unreachable issuers in the OIDCIssuerKeys condition.
+// The previously pinned keys are kept when the issuer cannot be reached. True is returned when keys were pinned for the first time or rotated.
+func refreshPinnedJWKS(ctx context.Context, m *fsm, runtime *imv1.Runtime, now time.Time) bool {
+	issuers := pinnedJWKSIssuers(*runtime)
+	if len(issuers) == 0 {
+		runtime.Status.OIDCIssuers = nil
+		meta.RemoveStatusCondition(&runtime.Status.Conditions, string(imv1.ConditionTypeOIDCIssuerKeys))
+		return false
+	}
+
+	discovered := discoverPinnedJWKS(ctx, issuers)
+
+	var unreachable, invalid, rotated []string
+	var keysChanged bool
+	statuses := make([]imv1.OIDCIssuerStatus, 0, len(issuers))
+
+	for i, issuer := range issuers {
+		issuerURL := *issuer.IssuerURL
+		previous := findOIDCIssuerStatus(runtime.Status.OIDCIssuers, issuerURL)
+
+		keys, err := discovered[i].keys, discovered[i].err
+		if err != nil {
+			m.log.Error(err, "Failed to refresh pinned OIDC issuer keys", "issuer", issuerURL)
+
+			msg := fmt.Sprintf("%s: %v", issuerURL, err)
+			if errors.Is(err, structuredauth.ErrIssuerUnreachable) {
+				unreachable = append(unreachable, msg)
+			} else {
+				invalid = append(invalid, msg)
+			}
+
+			status := imv1.OIDCIssuerStatus{IssuerURL: issuerURL}
+			if previous != nil {
+				status = *previous
+			}
+			status.LastRefreshTime = metav1.NewTime(now)
+			statuses = append(statuses, status)
+			continue
+		}
+
+		if previous != nil && previous.KeysHash != "" && previous.KeysHash != keys.Hash {
+			m.log.Info("OIDC issuer rotated its signing keys", "issuer", issuerURL, "keyIDs", keys.KeyIDs)
+			rotated = append(rotated, issuerURL)
+		}
+
+		if previous == nil || !bytes.Equal(previous.JWKS, keys.JWKS) {
+			keysChanged = true
+		}
+
+		statuses = append(statuses, imv1.OIDCIssuerStatus{
+			IssuerURL:       issuerURL,
+			KeyIDs:          keys.KeyIDs,
+			KeysHash:        keys.Hash,
+			JWKS:            keys.JWKS,
+			LastRefreshTime: metav1.NewTime(now),
+		})
+	}
+
+	runtime.Status.OIDCIssuers = statuses
+
+	switch {
+	case len(unreachable) > 0:
+		runtime.UpdateCondition(imv1.ConditionTypeOIDCIssuerKeys, imv1.ConditionReasonOIDCIssuerUnreachable, "False",
+			strings.Join(append(unreachable, invalid...), "; "))
+	case len(invalid) > 0:
+		runtime.UpdateCondition(imv1.ConditionTypeOIDCIssuerKeys, imv1.ConditionReasonOIDCIssuerKeysInvalid, "False",
+			strings.Join(invalid, "; "))
+	case len(rotated) > 0:
+		runtime.UpdateCondition(imv1.ConditionTypeOIDCIssuerKeys, imv1.ConditionReasonOIDCIssuerKeysRotated, "True",
+			fmt.Sprintf("Signing keys rotated for %s", strings.Join(rotated, ", ")))
+	default:
+		runtime.UpdateCondition(imv1.ConditionTypeOIDCIssuerKeys, imv1.ConditionReasonOIDCIssuerKeysPinned, "True",
+			fmt.Sprintf("Signing keys pinned for %d issuers", len(statuses)))
+	}
+
+	return keysChanged
+}
+
+// discoverPinnedJWKS fetches the keys of all issuers concurrently within jwksRefreshTimeout
+func discoverPinnedJWKS(ctx context.Context, issuers []imv1.OIDCConfig) []discoveredKeys {
+	ctx, cancel := context.WithTimeout(ctx, jwksRefreshTimeout)
+	defer cancel()
+
+	discovered := make([]discoveredKeys, len(issuers))
+
+	var wg sync.WaitGroup
+	for i, issuer := range issuers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			keys, err := structuredauth.DiscoverJWKS(ctx, issuer)
+			discovered[i] = discoveredKeys{keys: keys, err: err}
+		}()
+	}
+	wg.Wait()
+
+	return discovered
+}
+
+// pinnedJWKSIssuers returns the additional OIDC configs with JWKS pinning enabled, one per issuer
+func pinnedJWKSIssuers(runtime imv1.Runtime) []imv1.OIDCConfig {
+	additionalOidcConfig := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig
+	if additionalOidcConfig == nil {
+		return nil
+	}
+
+	var issuers []imv1.OIDCConfig
+	issuerURLs := map[string]bool{}
+	for _, oidcConfig := range *additionalOidcConfig {
+		if !oidcConfig.PinJWKS || oidcConfig.IssuerURL == nil || issuerURLs[*oidcConfig.IssuerURL] {
+			continue
+		}
+		issuerURLs[*oidcConfig.IssuerURL] = true
+		issuers = append(issuers, oidcConfig)
+	}
+
+	return issuers
+}
+
+func findOIDCIssuerStatus(statuses []imv1.OIDCIssuerStatus, issuerURL string) *imv1.OIDCIssuerStatus {
+	for i := range statuses {
+		if statuses[i].IssuerURL == issuerURL {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// nextJWKSRefresh returns how long to wait until the pinned keys of the runtime must be refreshed, false is returned when nothing is pinned
+func nextJWKSRefresh(runtime *imv1.Runtime, interval time.Duration, now time.Time) (time.Duration, bool) {
+	issuers := pinnedJWKSIssuers(*runtime)
+	if interval <= 0 || (len(issuers) == 0 && len(runtime.Status.OIDCIssuers) == 0) {
+		return 0, false
+	}
+
+	if len(issuers) != len(runtime.Status.OIDCIssuers) {
+		return 0, true
+	}
+
+	next := interval
+	for _, issuer := range issuers {
+		status := findOIDCIssuerStatus(runtime.Status.OIDCIssuers, *issuer.IssuerURL)
+		if status == nil {
+			return 0, true
+		}
+
+		next = min(next, status.LastRefreshTime.Add(interval).Sub(now))
+	}
+
+	return max(next, 0), true
+}
+
+func jwksRefreshDue(runtime *imv1.Runtime, interval time.Duration, now time.Time) bool {
+	next, pinned := nextJWKSRefresh(runtime, interval, now)
+	return pinned && next == 0
+}
+
+// withJWKSRefreshRequeue makes sure ready runtimes are reconciled when their pinned keys must be refreshed
+func withJWKSRefreshRequeue(result ctrl.Result, instance *imv1.Runtime, interval time.Duration, now time.Time) ctrl.Result {
+	if instance.Status.State != imv1.RuntimeStateReady || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+
+	next, pinned := nextJWKSRefresh(instance, interval, now)
+	if !pinned {
+		return result
+	}
+
+	if next == 0 {
+		next = jwksInitialRefreshDelay
+	}
+
+	if result.RequeueAfter == 0 || result.RequeueAfter > next {
+		result.RequeueAfter = next
+	}
+
+	return result
+}
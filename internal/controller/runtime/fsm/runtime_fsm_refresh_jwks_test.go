@@ -0,0 +1,282 @@
+package fsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	fsm_mocks "github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm/mocks"
+	fsm_testing "github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestOIDCIssuer(t *testing.T, keyID *string) *httptest.Server {
+	var server *httptest.Server
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"issuer": server.URL, "jwks_uri": server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{"kid": *keyID, "kty": "RSA", "alg": "RS256", "use": "sig", "n": "modulus-" + *keyID, "e": "AQAB"}},
+		})
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func runtimeWithPinnedIssuers(issuerURLs ...string) imv1.Runtime {
+	var oidcConfigs []imv1.OIDCConfig
+	for _, issuerURL := range issuerURLs {
+		var oidcConfig imv1.OIDCConfig
+		oidcConfig.IssuerURL = ptr.To(issuerURL)
+		oidcConfig.ClientID = ptr.To("client-id")
+		oidcConfig.PinJWKS = true
+		oidcConfigs = append(oidcConfigs, oidcConfig)
+	}
+
+	runtime := imv1.Runtime{}
+	runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = &oidcConfigs
+	runtime.Status.State = imv1.RuntimeStateReady
+
+	return runtime
+}
+
+func TestRefreshPinnedJWKS(t *testing.T) {
+	ctx := context.Background()
+	testFsm := &fsm{log: logr.Discard()}
+	now := time.Now()
+
+	t.Run("Should pin keys, detect rotation and keep keys of unreachable issuer", func(t *testing.T) {
+		// given
+		keyID := "key-1"
+		issuer := newTestOIDCIssuer(t, &keyID)
+		runtime := runtimeWithPinnedIssuers(issuer.URL)
+
+		// when
+		keysChanged := refreshPinnedJWKS(ctx, testFsm, &runtime, now)
+
+		// then
+		assert.True(t, keysChanged)
+		require.Len(t, runtime.Status.OIDCIssuers, 1)
+		pinned := runtime.Status.OIDCIssuers[0]
+		assert.Equal(t, []string{"key-1"}, pinned.KeyIDs)
+		assert.Contains(t, string(pinned.JWKS), `"kid":"key-1"`)
+		assertOIDCIssuerKeysCondition(t, runtime, imv1.ConditionReasonOIDCIssuerKeysPinned, metav1.ConditionTrue)
+
+		// when
+		assert.False(t, refreshPinnedJWKS(ctx, testFsm, &runtime, now))
+		keyID = "key-2"
+		keysChanged = refreshPinnedJWKS(ctx, testFsm, &runtime, now)
+
+		// then
+		assert.True(t, keysChanged)
+		assert.Equal(t, []string{"key-2"}, runtime.Status.OIDCIssuers[0].KeyIDs)
+		assert.NotEqual(t, pinned.KeysHash, runtime.Status.OIDCIssuers[0].KeysHash)
+		assertOIDCIssuerKeysCondition(t, runtime, imv1.ConditionReasonOIDCIssuerKeysRotated, metav1.ConditionTrue)
+
+		// when
+		rotated := runtime.Status.OIDCIssuers[0]
+		issuer.Close()
+		later := now.Add(time.Hour)
+		keysChanged = refreshPinnedJWKS(ctx, testFsm, &runtime, later)
+
+		// then
+		assert.False(t, keysChanged)
+		assert.Equal(t, rotated.KeysHash, runtime.Status.OIDCIssuers[0].KeysHash)
+		assert.Equal(t, rotated.JWKS, runtime.Status.OIDCIssuers[0].JWKS)
+		assert.True(t, runtime.Status.OIDCIssuers[0].LastRefreshTime.Equal(ptr.To(metav1.NewTime(later))))
+		assertOIDCIssuerKeysCondition(t, runtime, imv1.ConditionReasonOIDCIssuerUnreachable, metav1.ConditionFalse)
+	})
+
+	t.Run("Should clear status when pinning is disabled", func(t *testing.T) {
+		// given
+		runtime := runtimeWithPinnedIssuers()
+		runtime.Status.OIDCIssuers = []imv1.OIDCIssuerStatus{{IssuerURL: "https://issuer.example.com"}}
+		runtime.UpdateCondition(imv1.ConditionTypeOIDCIssuerKeys, imv1.ConditionReasonOIDCIssuerKeysPinned, "True", "pinned")
+
+		// when
+		refreshPinnedJWKS(ctx, testFsm, &runtime, now)
+
+		// then
+		assert.Nil(t, runtime.Status.OIDCIssuers)
+		assert.Nil(t, meta.FindStatusCondition(runtime.Status.Conditions, string(imv1.ConditionTypeOIDCIssuerKeys)))
+	})
+}
+
+func TestRefreshPinnedJWKSState(t *testing.T) {
+	ctx := context.Background()
+
+	shootWithOIDCExtension := func() *gardener.Shoot {
+		shoot := fsm_testing.TestShootForPatch()
+		shoot.Spec.Extensions = append(shoot.Spec.Extensions, gardener.Extension{
+			Type:     "shoot-oidc-service",
+			Disabled: ptr.To(false),
+		})
+		return shoot
+	}
+
+	t.Run("Should render pinned keys into the OpenIDConnect resource and update it when keys rotate", func(t *testing.T) {
+		// given
+		fakeClient, testFsm := setupFakeClient()
+		testFsm.log = logr.Discard()
+
+		keyID := "key-1"
+		issuer := newTestOIDCIssuer(t, &keyID)
+		runtime := runtimeWithPinnedIssuers(issuer.URL)
+		runtime.ObjectMeta = runtimeForTest().ObjectMeta
+
+		systemState := &systemState{instance: runtime, shoot: shootWithOIDCExtension()}
+
+		assertPinnedKeys := func(keyID string) {
+			var openIDConnect authenticationv1alpha1.OpenIDConnect
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "kyma-oidc-0"}, &openIDConnect))
+			assert.Equal(t, issuer.URL, openIDConnect.Spec.IssuerURL)
+			assert.Equal(t, systemState.instance.Status.OIDCIssuers[0].JWKS, openIDConnect.Spec.JWKS.Keys)
+			assert.Contains(t, string(openIDConnect.Spec.JWKS.Keys), fmt.Sprintf(`"kid":%q`, keyID))
+		}
+
+		// when
+		stateFn, _, _ := sFnRefreshPinnedJWKS(ctx, testFsm, systemState)
+
+		// then
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assertPinnedKeys("key-1")
+
+		// when
+		keyID = "key-2"
+		stateFn, _, _ = sFnRefreshPinnedJWKS(ctx, testFsm, systemState)
+
+		// then
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assertPinnedKeys("key-2")
+		assertOIDCIssuerKeysCondition(t, systemState.instance, imv1.ConditionReasonOIDCIssuerKeysRotated, metav1.ConditionTrue)
+	})
+
+	t.Run("Should keep previous keys when OpenIDConnect resource cannot be applied", func(t *testing.T) {
+		// given
+		_, testFsm := setupFakeClient()
+		testFsm.log = logr.Discard()
+
+		runtimeClientGetter := &fsm_mocks.RuntimeClientGetter{}
+		runtimeClientGetter.On("Get", mock.Anything, mock.Anything).Return(nil, errors.New("SKR unreachable"))
+		testFsm.RuntimeClientGetter = runtimeClientGetter
+
+		keyID := "key-1"
+		issuer := newTestOIDCIssuer(t, &keyID)
+		systemState := &systemState{instance: runtimeWithPinnedIssuers(issuer.URL), shoot: shootWithOIDCExtension()}
+
+		// when
+		stateFn, _, _ := sFnRefreshPinnedJWKS(ctx, testFsm, systemState)
+
+		// then
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.Nil(t, systemState.instance.Status.OIDCIssuers)
+		assertOIDCIssuerKeysCondition(t, systemState.instance, imv1.ConditionReasonOIDCIssuerKeysNotApplied, metav1.ConditionFalse)
+		assert.True(t, jwksRefreshDue(&systemState.instance, time.Hour, time.Now()))
+	})
+}
+
+func assertOIDCIssuerKeysCondition(t *testing.T, runtime imv1.Runtime, reason imv1.RuntimeConditionReason, status metav1.ConditionStatus) {
+	condition := meta.FindStatusCondition(runtime.Status.Conditions, string(imv1.ConditionTypeOIDCIssuerKeys))
+	require.NotNil(t, condition)
+	assert.Equal(t, string(reason), condition.Reason)
+	assert.Equal(t, status, condition.Status)
+}
+
+func TestWithJWKSRefreshRequeue(t *testing.T) {
+	now := time.Now()
+	interval := time.Hour
+
+	refreshed := func(issuerURL string, at time.Time) imv1.OIDCIssuerStatus {
+		return imv1.OIDCIssuerStatus{IssuerURL: issuerURL, LastRefreshTime: metav1.NewTime(at)}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		runtime     func() imv1.Runtime
+		result      ctrl.Result
+		expected    ctrl.Result
+		expectedDue bool
+	}{
+		{
+			name:     "Should not requeue runtime without pinned issuers",
+			runtime:  func() imv1.Runtime { return runtimeWithPinnedIssuers() },
+			expected: ctrl.Result{},
+		},
+		{
+			name:        "Should requeue soon when issuer was never refreshed",
+			runtime:     func() imv1.Runtime { return runtimeWithPinnedIssuers("https://issuer.example.com") },
+			expected:    ctrl.Result{RequeueAfter: jwksInitialRefreshDelay},
+			expectedDue: true,
+		},
+		{
+			name: "Should requeue when the earliest refresh is due",
+			runtime: func() imv1.Runtime {
+				runtime := runtimeWithPinnedIssuers("https://a.example.com", "https://b.example.com")
+				runtime.Status.OIDCIssuers = []imv1.OIDCIssuerStatus{
+					refreshed("https://a.example.com", now.Add(-10*time.Minute)),
+					refreshed("https://b.example.com", now.Add(-40*time.Minute)),
+				}
+				return runtime
+			},
+			expected: ctrl.Result{RequeueAfter: 20 * time.Minute},
+		},
+		{
+			name: "Should keep earlier requeue",
+			runtime: func() imv1.Runtime {
+				runtime := runtimeWithPinnedIssuers("https://a.example.com")
+				runtime.Status.OIDCIssuers = []imv1.OIDCIssuerStatus{refreshed("https://a.example.com", now)}
+				return runtime
+			},
+			result:   ctrl.Result{RequeueAfter: time.Minute},
+			expected: ctrl.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name: "Should refresh when issuer was removed from pinning",
+			runtime: func() imv1.Runtime {
+				runtime := runtimeWithPinnedIssuers()
+				runtime.Status.OIDCIssuers = []imv1.OIDCIssuerStatus{refreshed("https://a.example.com", now)}
+				return runtime
+			},
+			expected:    ctrl.Result{RequeueAfter: jwksInitialRefreshDelay},
+			expectedDue: true,
+		},
+		{
+			name: "Should not requeue runtime which is not ready",
+			runtime: func() imv1.Runtime {
+				runtime := runtimeWithPinnedIssuers("https://issuer.example.com")
+				runtime.Status.State = imv1.RuntimeStateFailed
+				return runtime
+			},
+			expected:    ctrl.Result{},
+			expectedDue: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runtime := tc.runtime()
+			assert.Equal(t, tc.expected, withJWKSRefreshRequeue(tc.result, &runtime, interval, now))
+			assert.Equal(t, tc.expectedDue, jwksRefreshDue(&runtime, interval, now))
+		})
+	}
+}
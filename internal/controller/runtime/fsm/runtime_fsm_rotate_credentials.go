@@ -0,0 +1,233 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const msgFailedToRotateCredentials = "Failed to rotate credentials"
+
+// sFnRotateCredentials drives the two-phase Gardener credentials rotation.
+// The shoot is annotated to prepare the rotation, the kubeconfig is fetched again once the new credentials are distributed
+// and the observability credentials are rotated, then the rotation is completed and the kubeconfig without the old credentials is fetched.
+func sFnRotateCredentials(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	if s.shoot.Status.LastOperation.State == gardener.LastOperationStateFailed {
+		msg := fmt.Sprintf("%s: last operation of shoot %s failed", msgFailedToRotateCredentials, s.shoot.Name)
+		m.log.Info(msg)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		s.instance.UpdateCondition(imv1.ConditionTypeCredentialsRotation, imv1.ConditionReasonCredentialsRotationError, "False", msg)
+		return updateStatusAndStop()
+	}
+
+	switch credentialsRotationReason(&s.instance) {
+	case imv1.ConditionReasonCredentialsRotationPreparing:
+		if !shootOperationSucceeded(s.shoot) || credentialsRotationPhase(s.shoot) != gardener.RotationPrepared || !observabilityCredentialsRotated(s.shoot) {
+			return requeueAfter(m.RequeueDurationShootReconcile)
+		}
+
+		if err := requestKubeconfigRotation(ctx, m.KcpClient, &s.instance); err != nil {
+			return handleCredentialsRotationError(m, s, err)
+		}
+
+		s.instance.UpdateCondition(
+			imv1.ConditionTypeCredentialsRotation,
+			imv1.ConditionReasonCredentialsRotationPrepared,
+			"Unknown",
+			"New credentials are prepared, waiting for the kubeconfig rotation")
+		return updateStatusAndRequeueAfter(m.ControlPlaneRequeueDuration)
+
+	case imv1.ConditionReasonCredentialsRotationPrepared:
+		rotated, err := kubeconfigRotated(ctx, m.KcpClient, &s.instance)
+		if err != nil {
+			return handleCredentialsRotationError(m, s, err)
+		}
+
+		if !rotated {
+			return requeueAfter(m.ControlPlaneRequeueDuration)
+		}
+
+		if err := annotateShootWithOperation(ctx, m.SeedClient, s.shoot, v1beta1constants.OperationRotateCredentialsComplete); err != nil {
+			return handleCredentialsRotationError(m, s, err)
+		}
+
+		s.instance.UpdateCondition(
+			imv1.ConditionTypeCredentialsRotation,
+			imv1.ConditionReasonCredentialsRotationCompleting,
+			"Unknown",
+			"Old credentials are being invalidated")
+		return updateStatusAndRequeueAfter(m.RequeueDurationShootReconcile)
+
+	case imv1.ConditionReasonCredentialsRotationCompleting:
+		if !shootOperationSucceeded(s.shoot) || credentialsRotationPhase(s.shoot) != gardener.RotationCompleted {
+			return requeueAfter(m.RequeueDurationShootReconcile)
+		}
+
+		if err := requestKubeconfigRotation(ctx, m.KcpClient, &s.instance); err != nil {
+			return handleCredentialsRotationError(m, s, err)
+		}
+
+		m.log.Info("Credentials rotation completed", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+		s.instance.UpdateCondition(
+			imv1.ConditionTypeCredentialsRotation,
+			imv1.ConditionReasonCredentialsRotationCompleted,
+			"True",
+			"Credentials rotation completed")
+
+		// the Gardener operation deferred during the rotation is forwarded with the next reconciliation
+		if gardenerOperationRequested(&s.instance) {
+			return updateStatusAndRequeue()
+		}
+		return updateStatusAndStop()
+	}
+
+	// the rotation is started once the Gardener operation forwarded before is picked up by Gardener
+	if _, pending := s.shoot.Annotations[imv1.AnnotationGardenerOperation]; pending {
+		m.log.Info("Shoot has a pending Gardener operation, credentials rotation is deferred", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+		return requeueAfter(m.GardenerRequeueDuration)
+	}
+
+	if err := annotateShootWithOperation(ctx, m.SeedClient, s.shoot, v1beta1constants.OperationRotateCredentialsStart); err != nil {
+		return handleCredentialsRotationError(m, s, err)
+	}
+
+	annotations := s.instance.GetAnnotations()
+	delete(annotations, reconciler.RotateCredentialsAnnotation)
+	s.instance.SetAnnotations(annotations)
+
+	if err := m.KcpClient.Update(ctx, &s.instance); err != nil {
+		m.log.Error(err, "could not remove credentials rotation annotation, scheduling for retry")
+		return requeue()
+	}
+
+	m.log.Info("Credentials rotation started", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+	s.instance.UpdateCondition(
+		imv1.ConditionTypeCredentialsRotation,
+		imv1.ConditionReasonCredentialsRotationPreparing,
+		"Unknown",
+		"New credentials are being prepared")
+	return updateStatusAndRequeueAfter(m.RequeueDurationShootReconcile)
+}
+
+func handleCredentialsRotationError(m *fsm, s *systemState, err error) (stateFn, *ctrl.Result, error) {
+	m.log.Error(err, msgFailedToRotateCredentials, "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+
+	// the failed step is retried, a rotation which failed to start is started again
+	reason := credentialsRotationReason(&s.instance)
+	if reason == "" {
+		reason = imv1.ConditionReasonCredentialsRotationError
+	}
+
+	s.instance.UpdateCondition(
+		imv1.ConditionTypeCredentialsRotation,
+		reason,
+		"Unknown",
+		fmt.Sprintf("%s: %v", msgFailedToRotateCredentials, err))
+	return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
+}
+
+// credentialsRotationInProgress returns true when the rotation is requested with the annotation or a started rotation has not been finished yet
+func credentialsRotationInProgress(runtime *imv1.Runtime) bool {
+	return reconciler.ShouldRotateCredentials(runtime.Annotations) || credentialsRotationReason(runtime) != ""
+}
+
+func credentialsRotationReason(runtime *imv1.Runtime) imv1.RuntimeConditionReason {
+	condition := meta.FindStatusCondition(runtime.Status.Conditions, string(imv1.ConditionTypeCredentialsRotation))
+	if condition == nil || condition.Status != metav1.ConditionUnknown {
+		return ""
+	}
+
+	return imv1.RuntimeConditionReason(condition.Reason)
+}
+
+// credentialsRotationPhase returns the phase reached by all credentials rotated in two phases, or an empty phase when they differ
+func credentialsRotationPhase(shoot *gardener.Shoot) gardener.CredentialsRotationPhase {
+	credentials := shoot.Status.Credentials
+	if credentials == nil || credentials.Rotation == nil {
+		return ""
+	}
+
+	rotation := credentials.Rotation
+
+	var phases []gardener.CredentialsRotationPhase
+	if rotation.CertificateAuthorities != nil {
+		phases = append(phases, rotation.CertificateAuthorities.Phase)
+	}
+	if rotation.ServiceAccountKey != nil {
+		phases = append(phases, rotation.ServiceAccountKey.Phase)
+	}
+	if rotation.ETCDEncryptionKey != nil {
+		phases = append(phases, rotation.ETCDEncryptionKey.Phase)
+	}
+
+	if len(phases) != 3 {
+		return ""
+	}
+
+	for _, phase := range phases[1:] {
+		if phase != phases[0] {
+			return ""
+		}
+	}
+
+	return phases[0]
+}
+
+// observabilityCredentialsRotated returns true when the observability credentials rotation, started together with the other credentials, is finished.
+// Observability credentials are rotated in a single phase.
+func observabilityCredentialsRotated(shoot *gardener.Shoot) bool {
+	credentials := shoot.Status.Credentials
+	if credentials == nil || credentials.Rotation == nil || credentials.Rotation.Observability == nil {
+		return false
+	}
+
+	return !v1beta1helper.IsShootObservabilityRotationInitiationTimeAfterLastCompletionTime(credentials)
+}
+
+func shootOperationSucceeded(shoot *gardener.Shoot) bool {
+	_, operationPending := shoot.Annotations[imv1.AnnotationGardenerOperation]
+	return !operationPending && shoot.Status.LastOperation.State == gardener.LastOperationStateSucceeded
+}
+
+func annotateShootWithOperation(ctx context.Context, gardenClient client.Client, shoot *gardener.Shoot, operation string) error {
+	original := shoot.DeepCopy()
+	metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, imv1.AnnotationGardenerOperation, operation)
+
+	return gardenClient.Patch(ctx, shoot, client.MergeFrom(original))
+}
+
+func requestKubeconfigRotation(ctx context.Context, kcpClient client.Client, runtime *imv1.Runtime) error {
+	var cluster imv1.GardenerCluster
+	if err := kcpClient.Get(ctx, gardenerClusterKey(runtime), &cluster); err != nil {
+		return err
+	}
+
+	original := cluster.DeepCopy()
+	metav1.SetMetaDataAnnotation(&cluster.ObjectMeta, imv1.AnnotationForceKubeconfigRotation, "true")
+
+	return kcpClient.Patch(ctx, &cluster, client.MergeFrom(original))
+}
+
+func kubeconfigRotated(ctx context.Context, kcpClient client.Client, runtime *imv1.Runtime) (bool, error) {
+	var cluster imv1.GardenerCluster
+	if err := kcpClient.Get(ctx, gardenerClusterKey(runtime), &cluster); err != nil {
+		return false, err
+	}
+
+	_, rotationPending := cluster.Annotations[imv1.AnnotationForceKubeconfigRotation]
+	return !rotationPending && cluster.Status.State == imv1.ReadyState, nil
+}
+
+func gardenerClusterKey(runtime *imv1.Runtime) types.NamespacedName {
+	return types.NamespacedName{Name: runtime.Labels[imv1.LabelKymaRuntimeID], Namespace: runtime.Namespace}
+}
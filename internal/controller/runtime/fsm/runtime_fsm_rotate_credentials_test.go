@@ -0,0 +1,280 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRotateCredentials(t *testing.T) {
+	rotationStarted := metav1.NewTime(time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC))
+	rotationFinished := metav1.NewTime(rotationStarted.Add(time.Minute))
+
+	fixRuntime := func(annotations map[string]string, reason imv1.RuntimeConditionReason) *imv1.Runtime {
+		runtime := &imv1.Runtime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "runtime",
+				Namespace:   "kcp-system",
+				Labels:      map[string]string{imv1.LabelKymaRuntimeID: "runtime-id"},
+				Annotations: annotations,
+			},
+			Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady},
+		}
+		if reason != "" {
+			runtime.UpdateCondition(imv1.ConditionTypeCredentialsRotation, reason, "Unknown", "")
+		}
+		return runtime
+	}
+
+	fixShoot := func(phase gardener.CredentialsRotationPhase) *gardener.Shoot {
+		return &gardener.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-test"},
+			Status: gardener.ShootStatus{
+				LastOperation: &gardener.LastOperation{State: gardener.LastOperationStateSucceeded},
+				Credentials: &gardener.ShootCredentials{
+					Rotation: &gardener.ShootCredentialsRotation{
+						CertificateAuthorities: &gardener.CARotation{Phase: phase},
+						ServiceAccountKey:      &gardener.ServiceAccountKeyRotation{Phase: phase},
+						ETCDEncryptionKey:      &gardener.ETCDEncryptionKeyRotation{Phase: phase},
+						Observability: &gardener.ObservabilityRotation{
+							LastInitiationTime: &rotationStarted,
+							LastCompletionTime: &rotationFinished,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	fixShootWithObservabilityRotationPending := func(phase gardener.CredentialsRotationPhase) *gardener.Shoot {
+		shoot := fixShoot(phase)
+		shoot.Status.Credentials.Rotation.Observability.LastCompletionTime = nil
+		return shoot
+	}
+
+	fixGardenerCluster := func(annotations map[string]string) *imv1.GardenerCluster {
+		return &imv1.GardenerCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "runtime-id", Namespace: "kcp-system", Annotations: annotations},
+			Status:     imv1.GardenerClusterStatus{State: imv1.ReadyState},
+		}
+	}
+
+	for _, tc := range []struct {
+		name                       string
+		runtime                    *imv1.Runtime
+		shoot                      *gardener.Shoot
+		gardenerCluster            *imv1.GardenerCluster
+		expectedReason             imv1.RuntimeConditionReason
+		expectedConditionStatus    metav1.ConditionStatus
+		expectedShootOperation     string
+		expectedKubeconfigRotation bool
+	}{
+		{
+			name:                    "Start rotation requested with the annotation",
+			runtime:                 fixRuntime(map[string]string{reconciler.RotateCredentialsAnnotation: "true"}, ""),
+			shoot:                   fixShoot(gardener.RotationCompleted),
+			gardenerCluster:         fixGardenerCluster(nil),
+			expectedReason:          imv1.ConditionReasonCredentialsRotationPreparing,
+			expectedConditionStatus: metav1.ConditionUnknown,
+			expectedShootOperation:  "rotate-credentials-start",
+		},
+		{
+			name:                    "Wait until new credentials are prepared",
+			runtime:                 fixRuntime(nil, imv1.ConditionReasonCredentialsRotationPreparing),
+			shoot:                   fixShoot(gardener.RotationPreparing),
+			gardenerCluster:         fixGardenerCluster(nil),
+			expectedReason:          imv1.ConditionReasonCredentialsRotationPreparing,
+			expectedConditionStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:                    "Wait until observability credentials are rotated",
+			runtime:                 fixRuntime(nil, imv1.ConditionReasonCredentialsRotationPreparing),
+			shoot:                   fixShootWithObservabilityRotationPending(gardener.RotationPrepared),
+			gardenerCluster:         fixGardenerCluster(nil),
+			expectedReason:          imv1.ConditionReasonCredentialsRotationPreparing,
+			expectedConditionStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:                       "Rotate kubeconfig when new credentials are prepared",
+			runtime:                    fixRuntime(nil, imv1.ConditionReasonCredentialsRotationPreparing),
+			shoot:                      fixShoot(gardener.RotationPrepared),
+			gardenerCluster:            fixGardenerCluster(nil),
+			expectedReason:             imv1.ConditionReasonCredentialsRotationPrepared,
+			expectedConditionStatus:    metav1.ConditionUnknown,
+			expectedKubeconfigRotation: true,
+		},
+		{
+			name:                       "Wait until kubeconfig is rotated",
+			runtime:                    fixRuntime(nil, imv1.ConditionReasonCredentialsRotationPrepared),
+			shoot:                      fixShoot(gardener.RotationPrepared),
+			gardenerCluster:            fixGardenerCluster(map[string]string{imv1.AnnotationForceKubeconfigRotation: "true"}),
+			expectedReason:             imv1.ConditionReasonCredentialsRotationPrepared,
+			expectedConditionStatus:    metav1.ConditionUnknown,
+			expectedKubeconfigRotation: true,
+		},
+		{
+			name:                    "Complete rotation when kubeconfig is rotated",
+			runtime:                 fixRuntime(nil, imv1.ConditionReasonCredentialsRotationPrepared),
+			shoot:                   fixShoot(gardener.RotationPrepared),
+			gardenerCluster:         fixGardenerCluster(nil),
+			expectedReason:          imv1.ConditionReasonCredentialsRotationCompleting,
+			expectedConditionStatus: metav1.ConditionUnknown,
+			expectedShootOperation:  "rotate-credentials-complete",
+		},
+		{
+			name:                       "Finish rotation when old credentials are invalidated",
+			runtime:                    fixRuntime(nil, imv1.ConditionReasonCredentialsRotationCompleting),
+			shoot:                      fixShoot(gardener.RotationCompleted),
+			gardenerCluster:            fixGardenerCluster(nil),
+			expectedReason:             imv1.ConditionReasonCredentialsRotationCompleted,
+			expectedConditionStatus:    metav1.ConditionTrue,
+			expectedKubeconfigRotation: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			scheme, err := newCreateTestScheme()
+			require.NoError(t, err)
+			require.NoError(t, imv1.AddToScheme(scheme))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.runtime, tc.shoot, tc.gardenerCluster).Build()
+			testFsm := &fsm{
+				log: logr.Discard(),
+				K8s: K8s{KcpClient: fakeClient, SeedClient: fakeClient},
+			}
+
+			var runtime imv1.Runtime
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.runtime), &runtime))
+			state := &systemState{instance: runtime, shoot: tc.shoot.DeepCopy()}
+
+			// when
+			_, _, err = sFnRotateCredentials(context.Background(), testFsm, state)
+
+			// then
+			require.NoError(t, err)
+
+			condition := meta.FindStatusCondition(state.instance.Status.Conditions, string(imv1.ConditionTypeCredentialsRotation))
+			require.NotNil(t, condition)
+			assert.Equal(t, string(tc.expectedReason), condition.Reason)
+			assert.Equal(t, tc.expectedConditionStatus, condition.Status)
+			assert.NotContains(t, state.instance.Annotations, reconciler.RotateCredentialsAnnotation)
+
+			var shoot gardener.Shoot
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.shoot), &shoot))
+			assert.Equal(t, tc.expectedShootOperation, shoot.Annotations[imv1.AnnotationGardenerOperation])
+
+			var gardenerCluster imv1.GardenerCluster
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.gardenerCluster), &gardenerCluster))
+			assert.Equal(t, tc.expectedKubeconfigRotation, gardenerCluster.Annotations[imv1.AnnotationForceKubeconfigRotation] == "true")
+		})
+	}
+}
+
+func TestRotateCredentialsWithGardenerOperation(t *testing.T) {
+	scheme, err := newCreateTestScheme()
+	require.NoError(t, err)
+	require.NoError(t, imv1.AddToScheme(scheme))
+
+	t.Run("Defer rotation start while forwarded Gardener operation is pending on the shoot", func(t *testing.T) {
+		// given
+		runtime := &imv1.Runtime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "runtime",
+				Namespace:   "kcp-system",
+				Annotations: map[string]string{reconciler.RotateCredentialsAnnotation: "true"},
+			},
+			Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady},
+		}
+		shoot := &gardener.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "shoot",
+				Namespace:   "garden-test",
+				Annotations: map[string]string{imv1.AnnotationGardenerOperation: "reconcile"},
+			},
+			Status: gardener.ShootStatus{
+				LastOperation: &gardener.LastOperation{State: gardener.LastOperationStateSucceeded},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(runtime, shoot).Build()
+		testFsm := &fsm{
+			log:   logr.Discard(),
+			K8s:   K8s{KcpClient: fakeClient, SeedClient: fakeClient},
+			RCCfg: RCCfg{GardenerRequeueDuration: time.Minute},
+		}
+		state := &systemState{instance: *runtime.DeepCopy(), shoot: shoot.DeepCopy()}
+
+		// when
+		_, result, err := sFnRotateCredentials(context.Background(), testFsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, time.Minute, result.RequeueAfter)
+		assert.Nil(t, meta.FindStatusCondition(state.instance.Status.Conditions, string(imv1.ConditionTypeCredentialsRotation)))
+		assert.Contains(t, state.instance.Annotations, reconciler.RotateCredentialsAnnotation)
+
+		var updatedShoot gardener.Shoot
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(shoot), &updatedShoot))
+		assert.Equal(t, "reconcile", updatedShoot.Annotations[imv1.AnnotationGardenerOperation])
+	})
+
+	t.Run("Requeue finished rotation to forward the deferred Gardener operation", func(t *testing.T) {
+		// given
+		runtime := &imv1.Runtime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "runtime",
+				Namespace:   "kcp-system",
+				Labels:      map[string]string{imv1.LabelKymaRuntimeID: "runtime-id"},
+				Annotations: map[string]string{reconciler.GardenerOperationAnnotation: "reconcile"},
+			},
+			Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady},
+		}
+		runtime.UpdateCondition(imv1.ConditionTypeCredentialsRotation, imv1.ConditionReasonCredentialsRotationCompleting, "Unknown", "")
+		phase := gardener.RotationCompleted
+		shoot := &gardener.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-test"},
+			Status: gardener.ShootStatus{
+				LastOperation: &gardener.LastOperation{State: gardener.LastOperationStateSucceeded},
+				Credentials: &gardener.ShootCredentials{
+					Rotation: &gardener.ShootCredentialsRotation{
+						CertificateAuthorities: &gardener.CARotation{Phase: phase},
+						ServiceAccountKey:      &gardener.ServiceAccountKeyRotation{Phase: phase},
+						ETCDEncryptionKey:      &gardener.ETCDEncryptionKeyRotation{Phase: phase},
+					},
+				},
+			},
+		}
+		gardenerCluster := &imv1.GardenerCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "runtime-id", Namespace: "kcp-system"},
+			Status:     imv1.GardenerClusterStatus{State: imv1.ReadyState},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(runtime, shoot, gardenerCluster).Build()
+		testFsm := &fsm{log: logr.Discard(), K8s: K8s{KcpClient: fakeClient, SeedClient: fakeClient}}
+		state := &systemState{instance: *runtime.DeepCopy(), shoot: shoot.DeepCopy()}
+
+		// when
+		next, _, err := sFnRotateCredentials(context.Background(), testFsm, state)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+
+		state.snapshot = state.instance.Status
+		_, result, err := next(context.Background(), testFsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, result.Requeue)
+		assert.True(t, state.instance.IsConditionSet(imv1.ConditionTypeCredentialsRotation, imv1.ConditionReasonCredentialsRotationCompleted))
+	})
+}
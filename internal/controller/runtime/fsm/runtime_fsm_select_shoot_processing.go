@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/go-logr/logr"
@@ -28,6 +29,19 @@ func sFnSelectShootProcessing(_ context.Context, m *fsm, s *systemState) (stateF
 		return requeueAfter(m.GardenerRequeueDuration)
 	}
 
+	s.instance.Status.HibernationState = hibernationState(s.shoot)
+
+	// Gardener operations and the revocation of expired SSH access are not applied to the shoot while reconciliation is suspended
+	suspended := reconciler.ShouldSuspendReconciliation(s.instance.Annotations)
+
+	// the requested Gardener operation is forwarded once the credentials rotation driven on the shoot is finished
+	if gardenerOperationRequested(&s.instance) && !suspended {
+		if !credentialsRotationInProgress(&s.instance) {
+			return switchState(sFnForwardGardenerOperation)
+		}
+		m.log.Info("Credentials rotation is in progress, Gardener operation is deferred", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+	}
+
 	patchShoot, err := shouldPatchShoot(&s.instance, s.shoot, &m.log)
 	if err != nil {
 		m.log.Error(err, "Failed to get applied generation for shoot", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
@@ -35,10 +49,22 @@ func sFnSelectShootProcessing(_ context.Context, m *fsm, s *systemState) (stateF
 		return requeueAfter(m.GardenerRequeueDuration)
 	}
 
-	if patchShoot {
+	if patchShoot || (!suspended && sshAccessRevocationDue(&s.instance, time.Now())) {
 		return switchState(sFnPatchExistingShoot)
 	}
 
+	// forwarded Gardener operations are tracked without blocking the processing of the runtime
+	gardenerOperationTracked := gardenerOperationForwarded(&s.instance)
+	gardenerOperationInProgress := trackGardenerOperation(&s.instance, s.shoot)
+
+	if s.instance.Status.State == imv1.RuntimeStateReady && credentialsRotationInProgress(&s.instance) {
+		return switchState(sFnRotateCredentials)
+	}
+
+	if s.instance.Status.State == imv1.RuntimeStateReady && jwksRefreshDue(&s.instance, m.JWKSRefreshInterval, time.Now()) {
+		return switchState(sFnRefreshPinnedJWKS)
+	}
+
 	if s.instance.Status.State == imv1.RuntimeStatePending || s.instance.Status.State == "" {
 		if lastOperation.Type == gardener.LastOperationTypeCreate {
 			return switchState(sFnWaitForShootCreation)
@@ -49,8 +75,23 @@ func sFnSelectShootProcessing(_ context.Context, m *fsm, s *systemState) (stateF
 		}
 	}
 
+	// Runtimes which were hibernated before the configuration was applied are configured once the shoot is woken up
+	if s.instance.IsConditionSet(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonHibernated) &&
+		s.instance.Status.HibernationState == imv1.HibernationStateAwake &&
+		lastOperation.State == gardener.LastOperationStateSucceeded {
+		return switchState(sFnWaitForShootReconcile)
+	}
+
+	if gardenerOperationInProgress {
+		m.log.V(log_level.DEBUG).Info("Gardener operation is in progress, scheduling for retry", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name, "operation", s.instance.Status.GardenerOperation)
+		return updateStatusAndRequeueAfter(m.RequeueDurationShootReconcile)
+	}
+
 	// All other runtimes in Ready and Failed state will be not processed to mitigate massive reconciliation during restart
 	m.log.Info("Stopping processing reconcile, exiting with no retry", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name, "function", "sFnSelectShootProcessing")
+	if s.instance.Status.HibernationState != s.snapshot.HibernationState || gardenerOperationTracked {
+		return updateStatusAndStop()
+	}
 	return stop()
 }
 
@@ -50,6 +50,68 @@ var _ = Describe("KIM sFnSelectShootProcessing", func() {
 		},
 	}
 
+	inputRtHibernated := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/suspend-patch-reconciliation": "true"})
+	inputRtHibernated.Status.State = imv1.RuntimeStateReady
+	inputRtHibernated.Status.HibernationState = imv1.HibernationStateHibernated
+	inputRtHibernated.Status.Conditions = []metav1.Condition{
+		{
+			Type:   string(imv1.ConditionTypeRuntimeProvisioned),
+			Status: metav1.ConditionTrue,
+			Reason: string(imv1.ConditionReasonHibernated),
+		},
+	}
+
+	testShootWokenUp := testShoot.DeepCopy()
+	testShootWokenUp.Spec.Hibernation = &gardener.Hibernation{Enabled: ptr.To(false)}
+
+	inputRtHibernatedBeforeProvisioning := inputRtHibernated.DeepCopy()
+	inputRtHibernatedBeforeProvisioning.Status.State = imv1.RuntimeStatePending
+	inputRtHibernatedBeforeProvisioning.Status.Conditions[0].Status = metav1.ConditionUnknown
+
+	testShootWokenUpAfterCreation := testShootWokenUp.DeepCopy()
+	testShootWokenUpAfterCreation.Status.LastOperation.Type = gardener.LastOperationTypeReconcile
+
+	inputRtWithTrackedOperation := inputRtWithForceAnnotation.DeepCopy()
+	inputRtWithTrackedOperation.Status.State = imv1.RuntimeStateReady
+	inputRtWithTrackedOperation.Status.GardenerOperation = "reconcile"
+	inputRtWithTrackedOperation.Status.Conditions = []metav1.Condition{
+		{
+			Type:   string(imv1.ConditionTypeGardenerOperation),
+			Status: metav1.ConditionUnknown,
+			Reason: string(imv1.ConditionReasonGardenerOperationForwarded),
+		},
+	}
+
+	sshAccessGrantedCondition := metav1.Condition{
+		Type:   string(imv1.ConditionTypeSSHAccess),
+		Status: metav1.ConditionTrue,
+		Reason: string(imv1.ConditionReasonSSHAccessGranted),
+	}
+
+	inputRtWithExpiredSSHAccess := makeInputRuntimeWithAnnotation(nil)
+	inputRtWithExpiredSSHAccess.Generation = 1
+	inputRtWithExpiredSSHAccess.Status.State = imv1.RuntimeStateReady
+	inputRtWithExpiredSSHAccess.Status.Conditions = []metav1.Condition{sshAccessGrantedCondition}
+
+	inputRtWithExpiredSSHAccessSuspended := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/suspend-patch-reconciliation": "true"})
+	inputRtWithExpiredSSHAccessSuspended.Status.State = imv1.RuntimeStateReady
+	inputRtWithExpiredSSHAccessSuspended.Status.Conditions = []metav1.Condition{sshAccessGrantedCondition}
+
+	testShootWithAppliedGeneration := testShoot.DeepCopy()
+	testShootWithAppliedGeneration.Annotations = map[string]string{"infrastructuremanager.kyma-project.io/runtime-generation": "1"}
+
+	inputRtWithGardenerOperationSuspended := makeInputRuntimeWithAnnotation(map[string]string{
+		"operator.kyma-project.io/suspend-patch-reconciliation": "true",
+		"operator.kyma-project.io/gardener-operation":           "reconcile",
+	})
+
+	inputRtWithGardenerOperationDuringRotation := makeInputRuntimeWithAnnotation(map[string]string{
+		"operator.kyma-project.io/rotate-credentials": "true",
+		"operator.kyma-project.io/gardener-operation": "reconcile",
+	})
+	inputRtWithGardenerOperationDuringRotation.Generation = 1
+	inputRtWithGardenerOperationDuringRotation.Status.State = imv1.RuntimeStateReady
+
 	testFunction := buildTestFunction(sFnSelectShootProcessing)
 
 	DescribeTable(
@@ -65,6 +127,16 @@ var _ = Describe("KIM sFnSelectShootProcessing", func() {
 				MatchNextFnState: haveName("sFnPatchExistingShoot"),
 			},
 		),
+		Entry(
+			"should switch to sFnPatchExistingShoot while forwarded Gardener operation is in progress",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtWithTrackedOperation, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnPatchExistingShoot"),
+			},
+		),
 		Entry(
 			"should stop due to suspend annotation",
 			testCtx,
@@ -75,6 +147,66 @@ var _ = Describe("KIM sFnSelectShootProcessing", func() {
 				MatchNextFnState: BeNil(),
 			},
 		),
+		Entry(
+			"should switch to sFnPatchExistingShoot to revoke expired SSH access",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtWithExpiredSSHAccess, shoot: testShootWithAppliedGeneration},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnPatchExistingShoot"),
+			},
+		),
+		Entry(
+			"should not revoke expired SSH access while reconciliation is suspended",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtWithExpiredSSHAccessSuspended, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: BeNil(),
+			},
+		),
+		Entry(
+			"should not forward Gardener operation while reconciliation is suspended",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtWithGardenerOperationSuspended, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: BeNil(),
+			},
+		),
+		Entry(
+			"should defer Gardener operation and switch to sFnRotateCredentials while credentials rotation is in progress",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtWithGardenerOperationDuringRotation, shoot: testShootWithAppliedGeneration},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnRotateCredentials"),
+			},
+		),
+		Entry(
+			"should switch to sFnWaitForShootReconcile when hibernated runtime is woken up",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtHibernated, shoot: testShootWokenUp},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnWaitForShootReconcile"),
+			},
+		),
+		Entry(
+			"should switch to sFnWaitForShootReconcile when runtime hibernated before provisioning is woken up",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtHibernatedBeforeProvisioning, shoot: testShootWokenUpAfterCreation},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnWaitForShootReconcile"),
+			},
+		),
 	)
 })
 
@@ -12,15 +12,32 @@ import (
 )
 
 func sFnWaitForShootReconcile(_ context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	s.instance.Status.HibernationState = hibernationState(s.shoot)
+
 	switch s.shoot.Status.LastOperation.State {
 	case gardener.LastOperationStateProcessing, gardener.LastOperationStatePending, gardener.LastOperationStateAborted, gardener.LastOperationStateError:
 		m.log.V(log_level.DEBUG).Info(fmt.Sprintf("Shoot %s is in %s state, scheduling for retry", s.shoot.Name, s.shoot.Status.LastOperation.State))
 
-		s.instance.UpdateStatePending(
-			imv1.ConditionTypeRuntimeProvisioned,
-			imv1.ConditionReasonProcessing,
-			"Unknown",
-			"Shoot update is in progress")
+		switch s.instance.Status.HibernationState {
+		case imv1.HibernationStateHibernating:
+			s.instance.UpdateStatePending(
+				imv1.ConditionTypeRuntimeProvisioned,
+				imv1.ConditionReasonHibernating,
+				"Unknown",
+				"Shoot hibernation is in progress")
+		case imv1.HibernationStateWakingUp:
+			s.instance.UpdateStatePending(
+				imv1.ConditionTypeRuntimeProvisioned,
+				imv1.ConditionReasonWakingUp,
+				"Unknown",
+				"Shoot wake up is in progress")
+		default:
+			s.instance.UpdateStatePending(
+				imv1.ConditionTypeRuntimeProvisioned,
+				imv1.ConditionReasonProcessing,
+				"Unknown",
+				"Shoot update is in progress")
+		}
 
 		return updateStatusAndRequeueAfter(m.RequeueDurationShootReconcile)
 
@@ -51,6 +68,18 @@ func sFnWaitForShootReconcile(_ context.Context, m *fsm, s *systemState) (stateF
 		return updateStatusAndStop()
 
 	case gardener.LastOperationStateSucceeded:
+		if s.instance.IsConditionSet(imv1.ConditionTypeControlPlaneHA, imv1.ConditionReasonControlPlaneHAMigrationPending) {
+			s.instance.UpdateCondition(
+				imv1.ConditionTypeControlPlaneHA,
+				imv1.ConditionReasonControlPlaneHAConfigured,
+				"True",
+				fmt.Sprintf("Control plane is highly available with %s failure tolerance", failureToleranceType(s.shoot.Spec.ControlPlane)))
+		}
+
+		if s.instance.Status.HibernationState == imv1.HibernationStateHibernated {
+			return switchState(sFnHandleHibernatedShoot)
+		}
+
 		m.log.Info(fmt.Sprintf("Shoot %s successfully updated, moving to processing", s.shoot.Name))
 		return ensureStatusConditionIsSetAndContinue(
 			&s.instance,
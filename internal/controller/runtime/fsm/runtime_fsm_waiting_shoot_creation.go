@@ -79,6 +79,10 @@ func sFnWaitForShootCreation(_ context.Context, m *fsm, s *systemState) (stateFn
 		return updateStatusAndStop()
 
 	case gardener.LastOperationStateSucceeded:
+		if hibernationState(s.shoot) == imv1.HibernationStateHibernated {
+			return switchState(sFnHandleHibernatedShoot)
+		}
+
 		m.log.Info(fmt.Sprintf("Shoot %s successfully created", s.shoot.Name))
 		return ensureStatusConditionIsSetAndContinue(
 			&s.instance,
@@ -4,11 +4,36 @@ import (
 	"context"
 	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"slices"
 )
 
-func seedForRegionAvailable(context context.Context, seedClient client.Client, providerType, region string) (bool, []string, error) {
+// seedAvailableForShoot checks whether the shoot can be scheduled to a seed, seeds are verified only when the seed location is enforced or zone failure tolerance is requested.
+// Without enforced seed location the shoot can be scheduled to a seed in another region, so any seed spanning enough zones is sufficient.
+func seedAvailableForShoot(context context.Context, seedClient client.Client, shoot imv1.RuntimeShoot) (bool, []string, error) {
+	enforceSeedLocation := ptr.Deref(shoot.EnforceSeedLocation, false)
+	zoneFailureTolerance := zoneFailureToleranceRequested(shoot.ControlPlane)
+
+	if !enforceSeedLocation && !zoneFailureTolerance {
+		return true, nil, nil
+	}
+
+	seedAvailable, regionsWithSeeds, err := seedForRegionAvailable(context, seedClient, shoot.Provider.Type, shoot.Region, zoneFailureTolerance)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if !enforceSeedLocation {
+		return len(regionsWithSeeds) > 0, regionsWithSeeds, nil
+	}
+
+	return seedAvailable, regionsWithSeeds, nil
+}
+
+// seedForRegionAvailable checks whether a usable seed exists in the region, when zone failure tolerance is required the seed has to span enough zones
+func seedForRegionAvailable(context context.Context, seedClient client.Client, providerType, region string, zoneFailureTolerance bool) (bool, []string, error) {
 	var seedList gardener_types.SeedList
 	var regionsWithSeeds []string
 
@@ -21,6 +46,7 @@ func seedForRegionAvailable(context context.Context, seedClient client.Client, p
 	for _, seed := range seedList.Items {
 		if seed.Spec.Provider.Type == providerType &&
 			seedCanBeUsed(&seed) &&
+			(!zoneFailureTolerance || seedSupportsZoneFailureTolerance(&seed)) &&
 			!slices.Contains(regionsWithSeeds, seed.Spec.Provider.Region) {
 			regionsWithSeeds = append(regionsWithSeeds, seed.Spec.Provider.Region)
 		}
@@ -29,6 +55,10 @@ func seedForRegionAvailable(context context.Context, seedClient client.Client, p
 	return slices.Contains(regionsWithSeeds, region), regionsWithSeeds, nil
 }
 
+func seedSupportsZoneFailureTolerance(seed *gardener_types.Seed) bool {
+	return len(seed.Spec.Provider.Zones) >= haZonesCount
+}
+
 func seedCanBeUsed(seed *gardener_types.Seed) bool {
 	return seed.DeletionTimestamp == nil && seed.Spec.Settings.Scheduling.Visible && verifySeedReadiness(seed)
 }
@@ -0,0 +1,62 @@
+package fsm
+
+import (
+	"fmt"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// updateSSHAccessStatus reports the SSH access applied to the shoot, each grant and revocation is counted in the metric
+func updateSSHAccessStatus(m *fsm, instance *imv1.Runtime, now time.Time) {
+	sshAccess := instance.Spec.Shoot.SSHAccess
+	granted := sshAccessGranted(instance)
+
+	if sshAccess.IsActive(now) {
+		if !granted {
+			m.Metrics.IncSSHAccessCounter(instance.Labels[imv1.LabelKymaRuntimeID], metrics.SSHAccessGranted)
+		}
+
+		instance.UpdateCondition(
+			imv1.ConditionTypeSSHAccess,
+			imv1.ConditionReasonSSHAccessGranted,
+			"True",
+			fmt.Sprintf("SSH access granted until %s, reason: %s", sshAccess.ExpiresAt.UTC().Format(time.RFC3339), sshAccess.Reason))
+		return
+	}
+
+	if granted {
+		m.Metrics.IncSSHAccessCounter(instance.Labels[imv1.LabelKymaRuntimeID], metrics.SSHAccessRevoked)
+		instance.UpdateCondition(
+			imv1.ConditionTypeSSHAccess,
+			imv1.ConditionReasonSSHAccessRevoked,
+			"False",
+			"SSH access revoked")
+	}
+}
+
+// sshAccessRevocationDue returns true when the SSH access granted on the shoot has expired or was withdrawn from the Runtime CR
+func sshAccessRevocationDue(instance *imv1.Runtime, now time.Time) bool {
+	return sshAccessGranted(instance) && !instance.Spec.Shoot.SSHAccess.IsActive(now)
+}
+
+func sshAccessGranted(instance *imv1.Runtime) bool {
+	return instance.IsConditionSetWithStatus(imv1.ConditionTypeSSHAccess, imv1.ConditionReasonSSHAccessGranted, "True")
+}
+
+// withSSHAccessExpiryRequeue makes sure the runtime is reconciled when the granted SSH access expires, even if nothing else changes
+func withSSHAccessExpiryRequeue(result ctrl.Result, instance *imv1.Runtime, now time.Time) ctrl.Result {
+	sshAccess := instance.Spec.Shoot.SSHAccess
+	if !sshAccess.IsActive(now) || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+
+	untilExpiry := sshAccess.ExpiresAt.Sub(now)
+	if result.RequeueAfter == 0 || result.RequeueAfter > untilExpiry {
+		result.RequeueAfter = untilExpiry
+	}
+
+	return result
+}
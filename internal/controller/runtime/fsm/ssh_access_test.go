@@ -0,0 +1,154 @@
+package fsm
+
+import (
+	"testing"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestUpdateSSHAccessStatus(t *testing.T) {
+	now := time.Now()
+
+	for tname, tc := range map[string]struct {
+		sshAccess         *imv1.SSHAccess
+		alreadyGranted    bool
+		expectedAction    string
+		expectedCondition metav1.ConditionStatus
+		expectedReason    imv1.RuntimeConditionReason
+	}{
+		"Should grant SSH access and count the grant": {
+			sshAccess:         fixSSHAccess(now.Add(time.Hour)),
+			expectedAction:    metrics.SSHAccessGranted,
+			expectedCondition: metav1.ConditionTrue,
+			expectedReason:    imv1.ConditionReasonSSHAccessGranted,
+		},
+		"Should not count the grant again when SSH access was already granted": {
+			sshAccess:         fixSSHAccess(now.Add(time.Hour)),
+			alreadyGranted:    true,
+			expectedCondition: metav1.ConditionTrue,
+			expectedReason:    imv1.ConditionReasonSSHAccessGranted,
+		},
+		"Should revoke expired SSH access": {
+			sshAccess:         fixSSHAccess(now.Add(-time.Minute)),
+			alreadyGranted:    true,
+			expectedAction:    metrics.SSHAccessRevoked,
+			expectedCondition: metav1.ConditionFalse,
+			expectedReason:    imv1.ConditionReasonSSHAccessRevoked,
+		},
+		"Should revoke SSH access removed from the Runtime": {
+			alreadyGranted:    true,
+			expectedAction:    metrics.SSHAccessRevoked,
+			expectedCondition: metav1.ConditionFalse,
+			expectedReason:    imv1.ConditionReasonSSHAccessRevoked,
+		},
+		"Should not set the condition when SSH access was never granted": {},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			metricsMock := &mocks.Metrics{}
+			if tc.expectedAction != "" {
+				metricsMock.On("IncSSHAccessCounter", "runtime-id", tc.expectedAction).Return().Once()
+			}
+			m := &fsm{RCCfg: RCCfg{Metrics: metricsMock}}
+
+			runtime := imv1.Runtime{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{imv1.LabelKymaRuntimeID: "runtime-id"},
+				},
+			}
+			runtime.Spec.Shoot.SSHAccess = tc.sshAccess
+			if tc.alreadyGranted {
+				runtime.UpdateCondition(imv1.ConditionTypeSSHAccess, imv1.ConditionReasonSSHAccessGranted, "True", "SSH access granted")
+			}
+
+			// when
+			updateSSHAccessStatus(m, &runtime, now)
+
+			// then
+			metricsMock.AssertExpectations(t)
+			condition := meta.FindStatusCondition(runtime.Status.Conditions, string(imv1.ConditionTypeSSHAccess))
+			if tc.expectedReason == "" {
+				assert.Nil(t, condition)
+				return
+			}
+			assert.NotNil(t, condition)
+			assert.Equal(t, tc.expectedCondition, condition.Status)
+			assert.Equal(t, string(tc.expectedReason), condition.Reason)
+		})
+	}
+}
+
+func TestSSHAccessRevocationDue(t *testing.T) {
+	now := time.Now()
+
+	runtime := imv1.Runtime{}
+	runtime.Spec.Shoot.SSHAccess = fixSSHAccess(now.Add(-time.Minute))
+	assert.False(t, sshAccessRevocationDue(&runtime, now), "access which was never granted must not be revoked")
+
+	runtime.UpdateCondition(imv1.ConditionTypeSSHAccess, imv1.ConditionReasonSSHAccessGranted, "True", "SSH access granted")
+	assert.True(t, sshAccessRevocationDue(&runtime, now))
+
+	runtime.Spec.Shoot.SSHAccess = fixSSHAccess(now.Add(time.Hour))
+	assert.False(t, sshAccessRevocationDue(&runtime, now))
+}
+
+func TestWithSSHAccessExpiryRequeue(t *testing.T) {
+	now := time.Now()
+
+	for tname, tc := range map[string]struct {
+		sshAccess *imv1.SSHAccess
+		result    ctrl.Result
+		expected  ctrl.Result
+	}{
+		"Should not change the result without SSH access": {
+			result:   ctrl.Result{},
+			expected: ctrl.Result{},
+		},
+		"Should not change the result for expired SSH access": {
+			sshAccess: fixSSHAccess(now.Add(-time.Minute)),
+			result:    ctrl.Result{},
+			expected:  ctrl.Result{},
+		},
+		"Should requeue when SSH access expires": {
+			sshAccess: fixSSHAccess(now.Add(time.Hour)),
+			result:    ctrl.Result{},
+			expected:  ctrl.Result{RequeueAfter: time.Hour},
+		},
+		"Should keep an earlier requeue": {
+			sshAccess: fixSSHAccess(now.Add(time.Hour)),
+			result:    ctrl.Result{RequeueAfter: time.Minute},
+			expected:  ctrl.Result{RequeueAfter: time.Minute},
+		},
+		"Should shorten a later requeue": {
+			sshAccess: fixSSHAccess(now.Add(time.Hour)),
+			result:    ctrl.Result{RequeueAfter: 2 * time.Hour},
+			expected:  ctrl.Result{RequeueAfter: time.Hour},
+		},
+		"Should keep an immediate requeue": {
+			sshAccess: fixSSHAccess(now.Add(time.Hour)),
+			result:    ctrl.Result{Requeue: true},
+			expected:  ctrl.Result{Requeue: true},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			runtime := imv1.Runtime{}
+			runtime.Spec.Shoot.SSHAccess = tc.sshAccess
+
+			assert.Equal(t, tc.expected, withSSHAccessExpiryRequeue(tc.result, &runtime, now))
+		})
+	}
+}
+
+func fixSSHAccess(expiresAt time.Time) *imv1.SSHAccess {
+	return &imv1.SSHAccess{
+		ExpiresAt: metav1.NewTime(expiresAt),
+		Reason:    "node debugging",
+	}
+}
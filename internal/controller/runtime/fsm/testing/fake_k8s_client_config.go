@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	gardener_api "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
 	core_v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -17,6 +19,9 @@ func GetFakePatchInterceptorFn(incShootGeneration bool) func(ctx context.Context
 		if patch.Type() != types.ApplyPatchType {
 			return client.Patch(ctx, obj, patch, opts...)
 		}
+		if crb, isCRB := obj.(*rbacv1.ClusterRoleBinding); isCRB {
+			return createOrUpdate(ctx, client, crb)
+		}
 		shoot, ok := obj.(*gardener_api.Shoot)
 		if !ok {
 			return errors.New("failed to cast object to shoot")
@@ -61,6 +66,10 @@ func GetFakePatchInterceptorForShootsAndConfigMaps(incShootGeneration bool) func
 			return nil
 		}
 
+		if openIDConnect, isOpenIDConnect := obj.(*authenticationv1alpha1.OpenIDConnect); isOpenIDConnect {
+			return createOrUpdate(ctx, client, openIDConnect)
+		}
+
 		return client.Patch(ctx, obj, patch, opts...) // If not shoot or configmap, use default patch
 	}
 }
@@ -126,3 +135,20 @@ func GetFakeUpdateInterceptorFn(incGeneration bool) func(ctx context.Context, cl
 		return nil
 	}
 }
+
+// createOrUpdate simulates the upsert of an apply patch, see https://github.com/kubernetes-sigs/controller-runtime/issues/2341
+func createOrUpdate(ctx context.Context, k8sClient client.WithWatch, obj client.Object) error {
+	err := k8sClient.Create(ctx, obj)
+	if err == nil || !k8s_errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	// the fake client rejects unconditional updates of custom resources
+	existing := obj.DeepCopyObject().(client.Object)
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+
+	return k8sClient.Update(ctx, obj)
+}
@@ -5,6 +5,7 @@ import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/aws"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
@@ -226,7 +227,7 @@ var (
 )
 
 func TestShootForPatch() *gardener.Shoot {
-	infrastructureConfig, _ := aws.NewInfrastructureConfig("10.250.0.0/22", []string{"europe-west1-d"})
+	infrastructureConfig, _ := aws.NewInfrastructureConfig("10.250.0.0/22", []string{"europe-west1-d"}, networking.DefaultSubnetLayout())
 	infraConfigBytes, _ := json.Marshal(infrastructureConfig)
 
 	return &gardener.Shoot{
@@ -255,7 +256,7 @@ func TestShootForPatch() *gardener.Shoot {
 }
 
 func TestShootForUpdate() *gardener.Shoot {
-	infrastructureConfig, _ := aws.NewInfrastructureConfig("10.250.0.0/22", []string{"europe-west1-d"})
+	infrastructureConfig, _ := aws.NewInfrastructureConfig("10.250.0.0/22", []string{"europe-west1-d"}, networking.DefaultSubnetLayout())
 	infraConfigBytes, _ := json.Marshal(infrastructureConfig)
 
 	return &gardener.Shoot{
@@ -0,0 +1,138 @@
+package fsm
+
+import (
+	"context"
+	"slices"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	haZonesCount    = 3
+	singleZoneCount = 1
+)
+
+// withSelectedWorkerZones returns a copy of the runtime in which workers without zones get zones assigned.
+// Zones selected once are stored in the Runtime status and reused, so later patches don't depend on changes in the cloud profile.
+// Zones of existing shoot workers take precedence over a new selection from the cloud profile.
+func withSelectedWorkerZones(ctx context.Context, gardenClient client.Client, instance *imv1.Runtime, shootWorkers []gardener.Worker, singleZonePlans []string) (*imv1.Runtime, error) {
+	runtime := instance.DeepCopy()
+
+	workers := slices.Clone(runtime.Spec.Shoot.Provider.Workers)
+	if runtime.Spec.Shoot.Provider.AdditionalWorkers != nil {
+		workers = append(workers, *runtime.Spec.Shoot.Provider.AdditionalWorkers...)
+	}
+
+	var cloudProfile *gardener.CloudProfile
+	var workerZones []imv1.WorkerZones
+
+	for i := range workers {
+		worker := &workers[i]
+		if len(worker.Zones) > 0 {
+			continue
+		}
+
+		zones := findWorkerZones(worker.Name, instance.Status.WorkerZones, shootWorkers)
+
+		if len(zones) == 0 {
+			if cloudProfile == nil {
+				var err error
+				cloudProfile, err = getCloudProfile(ctx, gardenClient, *runtime)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			var err error
+			zones, err = selectZones(*cloudProfile, runtime.Spec.Shoot.Region, worker.Machine.Type, getZonesCount(*runtime, singleZonePlans))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to select zones for worker %s", worker.Name)
+			}
+		}
+
+		worker.Zones = zones
+		workerZones = append(workerZones, imv1.WorkerZones{
+			WorkerName: worker.Name,
+			Zones:      zones,
+		})
+	}
+
+	mainWorkersCount := len(runtime.Spec.Shoot.Provider.Workers)
+	runtime.Spec.Shoot.Provider.Workers = workers[:mainWorkersCount]
+	if runtime.Spec.Shoot.Provider.AdditionalWorkers != nil {
+		additionalWorkers := workers[mainWorkersCount:]
+		runtime.Spec.Shoot.Provider.AdditionalWorkers = &additionalWorkers
+	}
+
+	instance.Status.WorkerZones = workerZones
+
+	return runtime, nil
+}
+
+func findWorkerZones(workerName string, workerZones []imv1.WorkerZones, shootWorkers []gardener.Worker) []string {
+	if index := slices.IndexFunc(workerZones, func(zones imv1.WorkerZones) bool {
+		return zones.WorkerName == workerName
+	}); index != -1 {
+		return workerZones[index].Zones
+	}
+
+	if index := slices.IndexFunc(shootWorkers, func(worker gardener.Worker) bool {
+		return worker.Name == workerName
+	}); index != -1 {
+		return shootWorkers[index].Zones
+	}
+
+	return nil
+}
+
+func getCloudProfile(ctx context.Context, gardenClient client.Client, runtime imv1.Runtime) (*gardener.CloudProfile, error) {
+	cloudProfileName, err := extender.GetCloudProfileName(runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	var cloudProfile gardener.CloudProfile
+	if err := gardenClient.Get(ctx, client.ObjectKey{Name: cloudProfileName}, &cloudProfile); err != nil {
+		return nil, errors.Wrapf(err, "failed to get cloud profile %s", cloudProfileName)
+	}
+
+	return &cloudProfile, nil
+}
+
+func getZonesCount(runtime imv1.Runtime, singleZonePlans []string) int {
+	if slices.Contains(singleZonePlans, runtime.Labels[imv1.LabelKymaBrokerPlanName]) {
+		return singleZoneCount
+	}
+
+	return haZonesCount
+}
+
+// Zones are sorted by name and the first ones offering the machine type are taken, so the selection is deterministic for the same cloud profile
+func selectZones(cloudProfile gardener.CloudProfile, regionName, machineType string, count int) ([]string, error) {
+	regionIndex := slices.IndexFunc(cloudProfile.Spec.Regions, func(region gardener.Region) bool {
+		return region.Name == regionName
+	})
+
+	if regionIndex == -1 {
+		return nil, errors.Errorf("region %s is not defined in cloud profile %s", regionName, cloudProfile.Name)
+	}
+
+	var zones []string
+	for _, zone := range cloudProfile.Spec.Regions[regionIndex].Zones {
+		if !slices.Contains(zone.UnavailableMachineTypes, machineType) {
+			zones = append(zones, zone.Name)
+		}
+	}
+
+	if len(zones) < count {
+		return nil, errors.Errorf("%d zones are required, but only %d zones in region %s offer machine type %s", count, len(zones), regionName, machineType)
+	}
+
+	slices.Sort(zones)
+
+	return zones[:count], nil
+}
@@ -0,0 +1,147 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWithSelectedWorkerZones(t *testing.T) {
+	cloudProfile := &gardener.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+		Spec: gardener.CloudProfileSpec{
+			Regions: []gardener.Region{
+				{
+					Name: "eu-central-1",
+					Zones: []gardener.AvailabilityZone{
+						{Name: "eu-central-1c", UnavailableMachineTypes: []string{"p4d.24xlarge"}},
+						{Name: "eu-central-1a", UnavailableMachineTypes: []string{"g5.xlarge", "p4d.24xlarge"}},
+						{Name: "eu-central-1b"},
+						{Name: "eu-central-1d"},
+					},
+				},
+			},
+		},
+	}
+
+	fixRuntime := func(plan string, workerZones []imv1.WorkerZones, workers ...gardener.Worker) *imv1.Runtime {
+		additionalWorkers := workers[1:]
+		return &imv1.Runtime{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{imv1.LabelKymaBrokerPlanName: plan},
+			},
+			Spec: imv1.RuntimeSpec{
+				Shoot: imv1.RuntimeShoot{
+					Region: "eu-central-1",
+					Provider: imv1.Provider{
+						Type:              "aws",
+						Workers:           workers[:1],
+						AdditionalWorkers: &additionalWorkers,
+					},
+				},
+			},
+			Status: imv1.RuntimeStatus{
+				WorkerZones: workerZones,
+			},
+		}
+	}
+
+	fixWorker := func(name, machineType string, zones ...string) gardener.Worker {
+		return gardener.Worker{
+			Name:    name,
+			Machine: gardener.Machine{Type: machineType},
+			Zones:   zones,
+		}
+	}
+
+	for tname, tc := range map[string]struct {
+		Runtime             *imv1.Runtime
+		ShootWorkers        []gardener.Worker
+		ExpectedZones       [][]string
+		ExpectedWorkerZones []imv1.WorkerZones
+		ExpectedError       string
+	}{
+		"Keep zones set on the Runtime CR": {
+			Runtime:       fixRuntime("aws", nil, fixWorker("cpu-worker-0", "m6i.large", "eu-central-1a", "eu-central-1b", "eu-central-1c")),
+			ExpectedZones: [][]string{{"eu-central-1a", "eu-central-1b", "eu-central-1c"}},
+		},
+		"Select three zones for HA plan": {
+			Runtime:             fixRuntime("aws", nil, fixWorker("cpu-worker-0", "m6i.large")),
+			ExpectedZones:       [][]string{{"eu-central-1a", "eu-central-1b", "eu-central-1c"}},
+			ExpectedWorkerZones: []imv1.WorkerZones{{WorkerName: "cpu-worker-0", Zones: []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"}}},
+		},
+		"Select single zone for non HA plan": {
+			Runtime:             fixRuntime("trial", nil, fixWorker("cpu-worker-0", "m6i.large")),
+			ExpectedZones:       [][]string{{"eu-central-1a"}},
+			ExpectedWorkerZones: []imv1.WorkerZones{{WorkerName: "cpu-worker-0", Zones: []string{"eu-central-1a"}}},
+		},
+		"Skip zones with unavailable machine type": {
+			Runtime: fixRuntime("aws", nil,
+				fixWorker("cpu-worker-0", "m6i.large", "eu-central-1a"),
+				fixWorker("gpu-worker", "g5.xlarge")),
+			ExpectedZones:       [][]string{{"eu-central-1a"}, {"eu-central-1b", "eu-central-1c", "eu-central-1d"}},
+			ExpectedWorkerZones: []imv1.WorkerZones{{WorkerName: "gpu-worker", Zones: []string{"eu-central-1b", "eu-central-1c", "eu-central-1d"}}},
+		},
+		"Reuse zones stored in status": {
+			Runtime: fixRuntime("aws", []imv1.WorkerZones{{WorkerName: "cpu-worker-0", Zones: []string{"eu-central-1b", "eu-central-1c", "eu-central-1d"}}},
+				fixWorker("cpu-worker-0", "m6i.large")),
+			ExpectedZones:       [][]string{{"eu-central-1b", "eu-central-1c", "eu-central-1d"}},
+			ExpectedWorkerZones: []imv1.WorkerZones{{WorkerName: "cpu-worker-0", Zones: []string{"eu-central-1b", "eu-central-1c", "eu-central-1d"}}},
+		},
+		"Reuse zones of existing shoot worker": {
+			Runtime:             fixRuntime("aws", nil, fixWorker("cpu-worker-0", "m6i.large")),
+			ShootWorkers:        []gardener.Worker{fixWorker("cpu-worker-0", "m6i.large", "eu-central-1d")},
+			ExpectedZones:       [][]string{{"eu-central-1d"}},
+			ExpectedWorkerZones: []imv1.WorkerZones{{WorkerName: "cpu-worker-0", Zones: []string{"eu-central-1d"}}},
+		},
+		"Fail when not enough zones offer the machine type": {
+			Runtime:       fixRuntime("aws", nil, fixWorker("cpu-worker-0", "m6i.large", "eu-central-1a"), fixWorker("gpu-worker", "p4d.24xlarge")),
+			ExpectedError: "failed to select zones for worker gpu-worker: 3 zones are required, but only 2 zones in region eu-central-1 offer machine type p4d.24xlarge",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			scheme, err := newCreateTestScheme()
+			require.NoError(t, err)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cloudProfile.DeepCopy()).Build()
+
+			// when
+			runtime, err := withSelectedWorkerZones(context.Background(), fakeClient, tc.Runtime, tc.ShootWorkers, []string{"trial"})
+
+			// then
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			workers := append(runtime.Spec.Shoot.Provider.Workers, *runtime.Spec.Shoot.Provider.AdditionalWorkers...)
+			require.Len(t, workers, len(tc.ExpectedZones))
+			for i, expectedZones := range tc.ExpectedZones {
+				assert.Equal(t, expectedZones, workers[i].Zones)
+			}
+			assert.Equal(t, tc.ExpectedWorkerZones, tc.Runtime.Status.WorkerZones)
+		})
+	}
+
+	t.Run("Fail when region is missing in cloud profile", func(t *testing.T) {
+		// given
+		scheme, err := newCreateTestScheme()
+		require.NoError(t, err)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cloudProfile.DeepCopy()).Build()
+		runtime := fixRuntime("aws", nil, fixWorker("cpu-worker-0", "m6i.large"))
+		runtime.Spec.Shoot.Region = "us-east-1"
+
+		// when
+		_, err = withSelectedWorkerZones(context.Background(), fakeClient, runtime, nil, nil)
+
+		// then
+		assert.EqualError(t, err, "failed to select zones for worker cpu-worker-0: region us-east-1 is not defined in cloud profile aws")
+	})
+}
@@ -141,7 +141,7 @@ var _ = BeforeSuite(func() {
 		Finalizer:                     imv1.Finalizer,
 		Config:                        convConfig,
 		Metrics:                       mm,
-		AuditLogging:                  map[string]map[string]auditlogs.AuditLogData{},
+		AuditLogging:                  auditlogs.Configuration{},
 		GardenerRequeueDuration:       3 * time.Second,
 		ControlPlaneRequeueDuration:   3 * time.Second,
 		RequeueDurationShootReconcile: 3 * time.Second,
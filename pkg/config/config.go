@@ -2,9 +2,11 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type Config struct {
@@ -18,6 +20,26 @@ type ClusterConfig struct {
 
 type ProviderConfig struct {
 	AWS AWSConfig `json:"aws"`
+	// SubnetLayouts contains default subnet layouts for the broker plans, keyed by plan name
+	SubnetLayouts map[string]SubnetLayoutConfig `json:"subnetLayouts" validate:"dive"`
+	// SingleZonePlans lists the broker plans which get a single zone selected for workers without zones, other plans get three zones
+	SingleZonePlans []string        `json:"singleZonePlans"`
+	OpenStack       OpenStackConfig `json:"openstack"`
+}
+
+type OpenStackConfig struct {
+	// Regions contain the floating pool and load balancer provider of the Converged Cloud regions, keyed by region name
+	Regions map[string]OpenStackRegionConfig `json:"regions"`
+}
+
+type OpenStackRegionConfig struct {
+	FloatingPoolName     string `json:"floatingPoolName"`
+	LoadBalancerProvider string `json:"loadBalancerProvider"`
+}
+
+type SubnetLayoutConfig struct {
+	Strategy          string `json:"strategy" validate:"required,oneof=kyma-weighted uniform custom"`
+	ZonePrefixLengths []int  `json:"zonePrefixLengths"`
 }
 
 type AWSConfig struct {
@@ -31,10 +53,30 @@ type DNSConfig struct {
 }
 
 type KubernetesConfig struct {
-	DefaultVersion                      string       `json:"defaultVersion" validate:"required"`
-	EnableKubernetesVersionAutoUpdate   bool         `json:"enableKubernetesVersionAutoUpdate"`
-	EnableMachineImageVersionAutoUpdate bool         `json:"enableMachineImageVersionVersionAutoUpdate"`
-	DefaultOperatorOidc                 OidcProvider `json:"defaultOperatorOidc" validate:"required"`
+	DefaultVersion                      string                  `json:"defaultVersion" validate:"required"`
+	EnableKubernetesVersionAutoUpdate   bool                    `json:"enableKubernetesVersionAutoUpdate"`
+	EnableMachineImageVersionAutoUpdate bool                    `json:"enableMachineImageVersionVersionAutoUpdate"`
+	DefaultOperatorOidc                 OidcProvider            `json:"defaultOperatorOidc" validate:"required"`
+	ClusterAutoscaler                   ClusterAutoscalerConfig `json:"clusterAutoscaler"`
+	KubeAPIServer                       KubeAPIServerConfig     `json:"kubeAPIServer"`
+}
+
+type KubeAPIServerConfig struct {
+	// AllowedAdmissionPlugins and AllowedFeatureGates list the admission plugins and feature gates which can be set on the Runtime CR
+	AllowedAdmissionPlugins []string `json:"allowedAdmissionPlugins"`
+	AllowedFeatureGates     []string `json:"allowedFeatureGates"`
+}
+
+type ClusterAutoscalerConfig struct {
+	// PlanDefaults contain cluster autoscaler and worker scaling defaults for the broker plans, keyed by plan name
+	PlanDefaults map[string]ClusterAutoscalerPlanDefaults `json:"planDefaults"`
+}
+
+type ClusterAutoscalerPlanDefaults struct {
+	Settings gardener.ClusterAutoscaler `json:"settings"`
+	// WorkerMaxSurge and WorkerMaxUnavailable are set on the workers which don't define them on the Runtime CR
+	WorkerMaxSurge       *intstr.IntOrString `json:"workerMaxSurge"`
+	WorkerMaxUnavailable *intstr.IntOrString `json:"workerMaxUnavailable"`
 }
 
 type OidcProvider struct {
@@ -60,6 +102,35 @@ func (p OidcProvider) ToOIDCConfig() gardener.OIDCConfig {
 type AuditLogConfig struct {
 	PolicyConfigMapName string `json:"policyConfigMapName" validate:"required"`
 	TenantConfigPath    string `json:"tenantConfigPath" validate:"required"`
+	// PolicyProfiles contain the audit policies which can be selected for the broker plans or on the Runtime CR, keyed by profile name
+	PolicyProfiles map[string]AuditPolicyProfile `json:"policyProfiles" validate:"dive"`
+	// PlanPolicyProfiles contain the profile names used for the broker plans when the Runtime CR does not select a profile
+	PlanPolicyProfiles map[string]string `json:"planPolicyProfiles"`
+}
+
+type AuditPolicyProfile struct {
+	ConfigMapName string `json:"configMapName" validate:"required"`
+	// Policy is the audit policy kept in the ConfigMap, when empty the ConfigMap must already exist in the garden project namespace
+	Policy string `json:"policy"`
+}
+
+// GetPolicyProfile returns the audit policy profile selected on the Runtime CR, or configured for the broker plan.
+// When no profile is selected, found is false and the default policy ConfigMap should be used.
+func (c AuditLogConfig) GetPolicyProfile(profileName, planName string) (profile AuditPolicyProfile, found bool, err error) {
+	if profileName == "" {
+		profileName = c.PlanPolicyProfiles[planName]
+	}
+
+	if profileName == "" {
+		return AuditPolicyProfile{}, false, nil
+	}
+
+	profile, found = c.PolicyProfiles[profileName]
+	if !found {
+		return AuditPolicyProfile{}, false, fmt.Errorf("audit policy profile %s is not configured", profileName)
+	}
+
+	return profile, true, nil
 }
 
 type MaintenanceWindowConfig struct {
@@ -83,6 +154,12 @@ type ConverterConfig struct {
 	Gardener          GardenerConfig          `json:"gardener" validate:"required"`
 	AuditLog          AuditLogConfig          `json:"auditLogging" validate:"required"`
 	MaintenanceWindow MaintenanceWindowConfig `json:"maintenanceWindow"`
+	Hibernation       HibernationConfig       `json:"hibernation"`
+}
+
+type HibernationConfig struct {
+	// PlanDefaults contain hibernation settings for the broker plans, keyed by plan name, e.g. to hibernate trial clusters at night
+	PlanDefaults map[string]gardener.Hibernation `json:"planDefaults"`
 }
 
 // special case for own Gardener's DNS solution
@@ -0,0 +1,61 @@
+package auditpolicy
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PolicyKey is the ConfigMap key Gardener reads the audit policy from
+const PolicyKey = "policy"
+
+// EnsureAuditPolicyConfigMap makes sure the audit policy ConfigMap exists in the garden project namespace.
+// When the policy is set, the ConfigMap is created or updated with it, otherwise the ConfigMap must already exist.
+func EnsureAuditPolicyConfigMap(ctx context.Context, gardenClient client.Client, cmKey types.NamespacedName, policy string) error {
+	var existingCM v1.ConfigMap
+	err := gardenClient.Get(ctx, cmKey, &existingCM)
+
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	configMapAlreadyExists := err == nil
+
+	if policy == "" {
+		if !configMapAlreadyExists {
+			return errors.Errorf("audit policy config map %s does not exist in namespace %s", cmKey.Name, cmKey.Namespace)
+		}
+		return nil
+	}
+
+	if configMapAlreadyExists {
+		if existingCM.Data[PolicyKey] == policy {
+			return nil
+		}
+
+		if existingCM.Data == nil {
+			existingCM.Data = map[string]string{}
+		}
+		existingCM.Data[PolicyKey] = policy
+		return gardenClient.Update(ctx, &existingCM)
+	}
+
+	return gardenClient.Create(ctx, &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmKey.Name,
+			Namespace: cmKey.Namespace,
+		},
+		Data: map[string]string{
+			PolicyKey: policy,
+		},
+	})
+}
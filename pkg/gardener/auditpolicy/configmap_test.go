@@ -0,0 +1,80 @@
+package auditpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureAuditPolicyConfigMap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cmKey := types.NamespacedName{Name: "audit-policy-strict", Namespace: "garden-kyma"}
+
+	for _, tc := range []struct {
+		name           string
+		existing       []client.Object
+		policy         string
+		expectedPolicy string
+		expectedErr    bool
+	}{
+		{
+			name:           "Should create config map with the policy",
+			policy:         "strict-policy",
+			expectedPolicy: "strict-policy",
+		},
+		{
+			name:           "Should update config map with a different policy",
+			existing:       []client.Object{fixAuditPolicyConfigMap(cmKey, "light-policy")},
+			policy:         "strict-policy",
+			expectedPolicy: "strict-policy",
+		},
+		{
+			name:           "Should accept existing config map when policy is not set",
+			existing:       []client.Object{fixAuditPolicyConfigMap(cmKey, "provided-policy")},
+			expectedPolicy: "provided-policy",
+		},
+		{
+			name:        "Should fail when policy is not set and config map does not exist",
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.existing...).Build()
+
+			// when
+			err := EnsureAuditPolicyConfigMap(context.Background(), fakeClient, cmKey, tc.policy)
+
+			// then
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var cm corev1.ConfigMap
+			require.NoError(t, fakeClient.Get(context.Background(), cmKey, &cm))
+			assert.Equal(t, tc.expectedPolicy, cm.Data[PolicyKey])
+		})
+	}
+}
+
+func fixAuditPolicyConfigMap(cmKey types.NamespacedName, policy string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmKey.Name,
+			Namespace: cmKey.Namespace,
+		},
+		Data: map[string]string{PolicyKey: policy},
+	}
+}
@@ -27,6 +27,7 @@ func baseExtenders() []Extend {
 		extender2.ExtendWithLabels,
 		extender2.ExtendWithSeedSelector,
 		extender2.NewOidcExtender(),
+		extender2.NewStructuredAuthorizationExtender(),
 		extender2.ExtendWithCloudProfile,
 		extender2.ExtendWithExposureClassName,
 		restrictions.ExtendWithAccessRestriction(),
@@ -50,6 +51,12 @@ type CreateOpts struct {
 	auditlogs.AuditLogData
 	*gardener.MaintenanceTimeWindow
 	StructuredAuthEnabled bool
+	// OIDCExtensionEnabled enables the shoot-oidc-service extension for the OIDC configs applied as OpenIDConnect resources in the SKR
+	OIDCExtensionEnabled bool
+	// AuditPolicyConfigMapName overrides the default audit policy ConfigMap with the one of the selected audit policy profile
+	AuditPolicyConfigMapName string
+	// SSHAccessEnabled enables SSH access to the worker nodes, it is evaluated by the caller against the expiry set in the Runtime CR
+	SSHAccessEnabled bool
 }
 
 type WorkerZones struct {
@@ -69,6 +76,14 @@ type PatchOpts struct {
 	ControlPlaneConfig   *runtime.RawExtension
 	Log                  *logr.Logger
 	RegistryCache        []registrycache.RegistryCache
+	ClusterAutoscaler    *gardener.ClusterAutoscaler
+	ShootHibernation     *gardener.Hibernation
+	// AuditPolicyConfigMapName overrides the default audit policy ConfigMap with the one of the selected audit policy profile
+	AuditPolicyConfigMapName string
+	// OIDCExtensionEnabled enables the shoot-oidc-service extension for the OIDC configs applied as OpenIDConnect resources in the SKR
+	OIDCExtensionEnabled bool
+	// SSHAccessEnabled enables SSH access to the worker nodes, it is evaluated by the caller against the expiry set in the Runtime CR
+	SSHAccessEnabled bool
 }
 
 func NewConverterCreate(opts CreateOpts) Converter {
@@ -76,9 +91,10 @@ func NewConverterCreate(opts CreateOpts) Converter {
 
 	extendersForCreate = append(extendersForCreate,
 		provider.NewProviderExtenderForCreateOperation(
-			opts.Provider.AWS.EnableIMDSv2,
+			opts.Provider,
 			opts.MachineImage.DefaultName,
 			opts.MachineImage.DefaultVersion,
+			opts.SSHAccessEnabled,
 		),
 		extender2.ExtendWithTolerations,
 	)
@@ -86,16 +102,19 @@ func NewConverterCreate(opts CreateOpts) Converter {
 	if !opts.DNS.IsGardenerInternal() {
 		extendersForCreate = append(extendersForCreate, extender2.NewDNSExtender(opts.DNS.SecretName, opts.DNS.DomainPrefix, opts.DNS.ProviderType))
 	}
-	extendersForCreate = append(extendersForCreate, extensions.NewExtensionsExtenderForCreate(opts.ConverterConfig, opts.AuditLogData, nil))
+	extendersForCreate = append(extendersForCreate, extensions.NewExtensionsExtenderForCreate(opts.ConverterConfig, opts.AuditLogData, nil, opts.OIDCExtensionEnabled))
 	extendersForCreate = append(extendersForCreate,
-		extender2.NewKubernetesExtender(opts.Kubernetes.DefaultVersion, ""))
+		extender2.NewKubernetesExtender(opts.Kubernetes.DefaultVersion, ""),
+		extender2.NewClusterAutoscalerExtender(opts.Kubernetes.ClusterAutoscaler.PlanDefaults, nil),
+		extender2.NewHibernationExtender(opts.Hibernation.PlanDefaults, nil),
+		extender2.NewKubeAPIServerExtender(opts.Kubernetes.KubeAPIServer))
 
 	extendersForCreate = append(extendersForCreate, maintenance.NewMaintenanceExtender(opts.Kubernetes.EnableKubernetesVersionAutoUpdate, opts.Kubernetes.EnableMachineImageVersionAutoUpdate, opts.MaintenanceTimeWindow))
 
 	if opts.AuditLogData != (auditlogs.AuditLogData{}) {
 		extendersForCreate = append(extendersForCreate,
 			auditlogs.NewAuditlogExtenderForCreate(
-				opts.AuditLog.PolicyConfigMapName,
+				auditPolicyConfigMapName(opts.ConverterConfig, opts.AuditPolicyConfigMapName),
 				opts.AuditLogData))
 	}
 
@@ -107,29 +126,44 @@ func NewConverterPatch(opts PatchOpts) Converter {
 
 	extendersForPatch = append(extendersForPatch,
 		provider.NewProviderExtenderPatchOperation(
-			opts.Provider.AWS.EnableIMDSv2,
+			opts.Provider,
 			opts.MachineImage.DefaultName,
 			opts.MachineImage.DefaultVersion,
+			opts.SSHAccessEnabled,
 			opts.Workers,
 			opts.InfrastructureConfig,
 			opts.ControlPlaneConfig))
 
 	extendersForPatch = append(extendersForPatch,
-		extensions.NewExtensionsExtenderForPatch(opts.AuditLogData, opts.RegistryCache, opts.Extensions),
+		extensions.NewExtensionsExtenderForPatch(opts.AuditLogData, opts.RegistryCache, opts.Extensions, opts.OIDCExtensionEnabled),
 		extender2.NewResourcesExtenderForPatch(opts.Resources))
 
-	extendersForPatch = append(extendersForPatch, extender2.NewKubernetesExtender(opts.Kubernetes.DefaultVersion, opts.ShootK8SVersion))
+	extendersForPatch = append(extendersForPatch,
+		extender2.NewKubernetesExtender(opts.Kubernetes.DefaultVersion, opts.ShootK8SVersion),
+		extender2.NewClusterAutoscalerExtender(opts.Kubernetes.ClusterAutoscaler.PlanDefaults, opts.ClusterAutoscaler),
+		extender2.NewHibernationExtender(opts.Hibernation.PlanDefaults, opts.ShootHibernation),
+		extender2.NewKubeAPIServerExtender(opts.Kubernetes.KubeAPIServer))
 
 	extendersForPatch = append(extendersForPatch, maintenance.NewMaintenanceExtender(opts.Kubernetes.EnableKubernetesVersionAutoUpdate, opts.Kubernetes.EnableMachineImageVersionAutoUpdate, opts.MaintenanceTimeWindow))
 
 	if opts.AuditLogData != (auditlogs.AuditLogData{}) {
 		extendersForPatch = append(extendersForPatch,
-			auditlogs.NewAuditlogExtenderForPatch(opts.AuditLog.PolicyConfigMapName))
+			auditlogs.NewAuditlogExtenderForPatch(
+				auditPolicyConfigMapName(opts.ConverterConfig, opts.AuditPolicyConfigMapName),
+				opts.AuditLogData))
 	}
 
 	return newConverter(opts.ConverterConfig, extendersForPatch...)
 }
 
+func auditPolicyConfigMapName(converterConfig config.ConverterConfig, profileConfigMapName string) string {
+	if profileConfigMapName != "" {
+		return profileConfigMapName
+	}
+
+	return converterConfig.AuditLog.PolicyConfigMapName
+}
+
 func (c Converter) ToShoot(runtime imv1.Runtime) (gardener.Shoot, error) {
 	// The original implementation in the Provisioner: https://github.com/kyma-project/control-plane/blob/3dd257826747384479986d5d79eb20f847741aa6/components/provisioner/internal/model/gardener_config.go#L127
 
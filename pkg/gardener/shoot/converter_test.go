@@ -5,10 +5,12 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/extensions"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/aws"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 
@@ -279,6 +281,106 @@ func TestConverter(t *testing.T) {
 	})
 }
 
+func TestConverterAuditPolicy(t *testing.T) {
+	auditLogData := auditlogs.AuditLogData{
+		TenantID:   "test-auditlog-tenant",
+		ServiceURL: "test-auditlog-service-url",
+		SecretName: "doesnt matter",
+	}
+
+	t.Run("Should reference the default audit policy config map", func(t *testing.T) {
+		// given
+		converterConfig := fixConverterConfig()
+		converterConfig.AuditLog.PolicyConfigMapName = "audit-policy-default"
+		converter := NewConverterCreate(CreateOpts{
+			ConverterConfig: converterConfig,
+			AuditLogData:    auditLogData,
+		})
+
+		// when
+		shoot, err := converter.ToShoot(fixRuntime(gardener.ShootPurposeProduction))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "audit-policy-default", shoot.Spec.Kubernetes.KubeAPIServer.AuditConfig.AuditPolicy.ConfigMapRef.Name)
+	})
+
+	t.Run("Should reference the config map of the selected audit policy profile", func(t *testing.T) {
+		// given
+		converterConfig := fixConverterConfig()
+		converterConfig.AuditLog.PolicyConfigMapName = "audit-policy-default"
+		converter := NewConverterCreate(CreateOpts{
+			ConverterConfig:          converterConfig,
+			AuditLogData:             auditLogData,
+			AuditPolicyConfigMapName: "audit-policy-strict",
+		})
+
+		// when
+		shoot, err := converter.ToShoot(fixRuntime(gardener.ShootPurposeProduction))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "audit-policy-strict", shoot.Spec.Kubernetes.KubeAPIServer.AuditConfig.AuditPolicy.ConfigMapRef.Name)
+	})
+}
+
+func TestConverterKubeAPIServerSettings(t *testing.T) {
+	t.Run("Should merge kube-apiserver settings with structured authentication and audit configuration", func(t *testing.T) {
+		// given
+		runtime := fixRuntime(gardener.ShootPurposeProduction)
+		eventTTL := v1.Duration{Duration: 2 * time.Hour}
+		runtime.Spec.Shoot.Kubernetes.KubeAPIServer.Settings = &imv1.KubeAPIServerSettings{
+			FeatureGates:       map[string]bool{"StructuredAuthorizationConfiguration": true},
+			EventTTL:           &eventTTL,
+			EncryptedResources: []string{"configmaps"},
+		}
+
+		converterConfig := fixConverterConfig()
+		converterConfig.Kubernetes.KubeAPIServer.AllowedFeatureGates = []string{"StructuredAuthorizationConfiguration"}
+		converterConfig.AuditLog.PolicyConfigMapName = "audit-policy"
+
+		converter := NewConverterCreate(CreateOpts{
+			ConverterConfig: converterConfig,
+			AuditLogData: auditlogs.AuditLogData{
+				TenantID:   "test-auditlog-tenant",
+				ServiceURL: "test-auditlog-service-url",
+				SecretName: "doesnt matter",
+			},
+		})
+
+		// when
+		shoot, err := converter.ToShoot(runtime)
+
+		// then
+		require.NoError(t, err)
+		kubeAPIServer := shoot.Spec.Kubernetes.KubeAPIServer
+		require.NotNil(t, kubeAPIServer)
+		assert.Equal(t, "structured-auth-config-"+runtime.Spec.Shoot.Name, kubeAPIServer.StructuredAuthentication.ConfigMapName)
+		assert.Equal(t, "audit-policy", kubeAPIServer.AuditConfig.AuditPolicy.ConfigMapRef.Name)
+		assert.Equal(t, map[string]bool{"StructuredAuthorizationConfiguration": true}, kubeAPIServer.FeatureGates)
+		assert.Equal(t, &eventTTL, kubeAPIServer.EventTTL)
+		assert.Equal(t, []string{"configmaps"}, kubeAPIServer.EncryptionConfig.Resources)
+	})
+
+	t.Run("Should fail when kube-apiserver feature gate is not allowed", func(t *testing.T) {
+		// given
+		runtime := fixRuntime(gardener.ShootPurposeProduction)
+		runtime.Spec.Shoot.Kubernetes.KubeAPIServer.Settings = &imv1.KubeAPIServerSettings{
+			FeatureGates: map[string]bool{"AnonymousAuthConfigurableEndpoints": true},
+		}
+
+		converter := NewConverterCreate(CreateOpts{
+			ConverterConfig: fixConverterConfig(),
+		})
+
+		// when
+		_, err := converter.ToShoot(runtime)
+
+		// then
+		require.Error(t, err)
+	})
+}
+
 func assertShootFields(t *testing.T, runtime imv1.Runtime, shoot gardener.Shoot) {
 	assert.Equal(t, runtime.Spec.Shoot.Purpose, *shoot.Spec.Purpose)
 	assert.Equal(t, runtime.Spec.Shoot.Region, shoot.Spec.Region)
@@ -369,7 +471,7 @@ func fixAllExtensionsOnTheShoot() []gardener.Extension {
 }
 
 func fixAWSInfrastructureConfig(workersCIDR string, zones []string) *runtime.RawExtension {
-	infraConfig, _ := aws.GetInfrastructureConfig(workersCIDR, zones)
+	infraConfig, _ := aws.GetInfrastructureConfig(workersCIDR, zones, networking.DefaultSubnetLayout())
 	return &runtime.RawExtension{Raw: infraConfig}
 }
 
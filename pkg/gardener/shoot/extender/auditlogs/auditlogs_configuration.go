@@ -1,11 +1,21 @@
 package auditlogs
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
 
 var (
 	ErrConfigurationNotFound = fmt.Errorf("audit logs configuration not found")
 )
 
+// RegionWildcard used as a region rule matches all regions of the provider type, used as a rule suffix it matches all regions starting with the rule prefix
+const RegionWildcard = "*"
+
 type region = string
 
 type providerType = string
@@ -14,25 +24,105 @@ type AuditLogData struct {
 	TenantID   string `json:"tenantID" validate:"required"`
 	ServiceURL string `json:"serviceURL" validate:"required,url"`
 	SecretName string `json:"secretName" validate:"required"`
+	// Disabled marks regions which explicitly have no audit log, the other fields are not used then
+	Disabled bool `json:"disabled,omitempty"`
 }
 
+// Configuration contains the audit log data of the regions, keyed by provider type and region rule.
+// A region rule is a region name, a region prefix followed by the RegionWildcard, or the RegionWildcard alone.
 type Configuration map[providerType]map[region]AuditLogData
 
+// DataProvider provides the audit log data configured for a provider type and region
+type DataProvider interface {
+	GetAuditLogData(providerType, region string) (AuditLogData, error)
+}
+
+// Match is the region rule of the configuration resolved for a provider type and region
+type Match struct {
+	ProviderType string
+	Rule         string
+	AuditLogData AuditLogData
+}
+
+// GetAuditLogData returns the audit log data resolved for the provider type and region.
+// Empty audit log data is returned for regions which explicitly have no audit log.
 func (a Configuration) GetAuditLogData(providerType, region string) (AuditLogData, error) {
+	match, err := a.Resolve(providerType, region)
+	if err != nil {
+		return AuditLogData{}, err
+	}
+
+	if match.AuditLogData.Disabled {
+		return AuditLogData{}, nil
+	}
+
+	return match.AuditLogData, nil
+}
+
+// Resolve returns the region rule matching the provider type and region.
+// The region name has precedence over the longest matching region prefix, the RegionWildcard alone is used as the provider type default.
+func (a Configuration) Resolve(providerType, region string) (Match, error) {
 	providerCfg, found := (a)[providerType]
 	if !found {
-		return AuditLogData{}, fmt.Errorf("%w: missing providerType: '%s'",
+		return Match{}, fmt.Errorf("%w: missing providerType: '%s'",
 			ErrConfigurationNotFound,
 			providerType)
 	}
 
-	providerCfgForRegion, found := providerCfg[region]
-	if !found {
-		return AuditLogData{}, fmt.Errorf("%w: missing region: '%s' for providerType: '%s'",
-			ErrConfigurationNotFound,
-			region,
-			providerType)
+	if auditLogData, found := providerCfg[region]; found {
+		return Match{ProviderType: providerType, Rule: region, AuditLogData: auditLogData}, nil
+	}
+
+	var matchedRule string
+	for rule := range providerCfg {
+		prefix, isPattern := strings.CutSuffix(rule, RegionWildcard)
+		if !isPattern || prefix == "" || !strings.HasPrefix(region, prefix) {
+			continue
+		}
+
+		if len(rule) > len(matchedRule) {
+			matchedRule = rule
+		}
+	}
+
+	if matchedRule != "" {
+		return Match{ProviderType: providerType, Rule: matchedRule, AuditLogData: providerCfg[matchedRule]}, nil
+	}
+
+	if auditLogData, found := providerCfg[RegionWildcard]; found {
+		return Match{ProviderType: providerType, Rule: RegionWildcard, AuditLogData: auditLogData}, nil
+	}
+
+	return Match{}, fmt.Errorf("%w: missing region: '%s' for providerType: '%s'",
+		ErrConfigurationNotFound,
+		region,
+		providerType)
+}
+
+// LoadConfiguration decodes the audit log tenant configuration and validates the region rules and their audit log data
+func LoadConfiguration(r io.Reader) (Configuration, error) {
+	var data Configuration
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	for providerType, nestedMap := range data {
+		for rule, auditLogData := range nestedMap {
+			if strings.Contains(strings.TrimSuffix(rule, RegionWildcard), RegionWildcard) {
+				return nil, fmt.Errorf("invalid region rule '%s' for providerType: '%s', the wildcard is allowed only at the end", rule, providerType)
+			}
+
+			if auditLogData.Disabled {
+				continue
+			}
+
+			if err := validate.Struct(auditLogData); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return providerCfgForRegion, nil
+	return data, nil
 }
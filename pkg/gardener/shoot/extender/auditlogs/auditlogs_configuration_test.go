@@ -2,6 +2,7 @@ package auditlogs
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,6 +57,19 @@ func Test_AuditlogsConfigurationNoError(t *testing.T) {
 			region:       "r3",
 			expected:     fixTestAuditlogData(3),
 		},
+		{
+			cfg: map[string]map[string]AuditLogData{
+				"aws": {
+					"eu-central-1": fixTestAuditlogData(1),
+				},
+				"alicloud": {
+					"cn-beijing": fixTestAuditlogData(2),
+				},
+			},
+			providerType: "alicloud",
+			region:       "cn-beijing",
+			expected:     fixTestAuditlogData(2),
+		},
 	} {
 		// when
 		actual, err := testCase.cfg.GetAuditLogData(testCase.providerType, testCase.region)
@@ -73,3 +87,97 @@ func fixTestAuditlogData(id int) AuditLogData {
 		SecretName: fmt.Sprintf("test-service-%d", id),
 	}
 }
+
+func Test_LoadConfiguration(t *testing.T) {
+	t.Run("Should load valid configuration", func(t *testing.T) {
+		// when
+		cfg, err := LoadConfiguration(strings.NewReader(`{"aws":{"eu-central-1":{"tenantID":"tenant","serviceURL":"https://auditlog.example.com","secretName":"auditlog-secret"}}}`))
+
+		// then
+		require.NoError(t, err)
+		data, err := cfg.GetAuditLogData("aws", "eu-central-1")
+		require.NoError(t, err)
+		assert.Equal(t, "tenant", data.TenantID)
+	})
+
+	t.Run("Should reject configuration with invalid audit log data", func(t *testing.T) {
+		// when
+		_, err := LoadConfiguration(strings.NewReader(`{"aws":{"eu-central-1":{"tenantID":"tenant","serviceURL":"not an url","secretName":"auditlog-secret"}}}`))
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("Should reject malformed configuration", func(t *testing.T) {
+		// when
+		_, err := LoadConfiguration(strings.NewReader(`{"aws":`))
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func Test_AuditlogsConfigurationResolve(t *testing.T) {
+	cfg := Configuration{
+		"aws": {
+			"eu-central-1": fixTestAuditlogData(1),
+			"eu-*":         fixTestAuditlogData(2),
+			"eu-west-*":    fixTestAuditlogData(3),
+			"*":            fixTestAuditlogData(4),
+			"cn-north-1":   {Disabled: true},
+		},
+	}
+
+	for _, tc := range []struct {
+		region       string
+		expectedRule string
+		expectedData AuditLogData
+	}{
+		{region: "eu-central-1", expectedRule: "eu-central-1", expectedData: fixTestAuditlogData(1)},
+		{region: "eu-north-1", expectedRule: "eu-*", expectedData: fixTestAuditlogData(2)},
+		{region: "eu-west-2", expectedRule: "eu-west-*", expectedData: fixTestAuditlogData(3)},
+		{region: "us-east-1", expectedRule: "*", expectedData: fixTestAuditlogData(4)},
+		{region: "cn-north-1", expectedRule: "cn-north-1", expectedData: AuditLogData{}},
+	} {
+		t.Run(tc.region, func(t *testing.T) {
+			// when
+			match, err := cfg.Resolve("aws", tc.region)
+			require.NoError(t, err)
+			data, err := cfg.GetAuditLogData("aws", tc.region)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRule, match.Rule)
+			assert.Equal(t, tc.expectedData, data)
+		})
+	}
+
+	t.Run("Should fail when no rule matches the region", func(t *testing.T) {
+		// when
+		_, err := Configuration{"gcp": {"europe-*": fixTestAuditlogData(1)}}.Resolve("gcp", "us-central1")
+
+		// then
+		assert.ErrorIs(t, err, ErrConfigurationNotFound)
+	})
+}
+
+func Test_LoadConfigurationRules(t *testing.T) {
+	t.Run("Should accept disabled entries without audit log data", func(t *testing.T) {
+		// when
+		cfg, err := LoadConfiguration(strings.NewReader(`{"aws":{"*":{"disabled":true}}}`))
+
+		// then
+		require.NoError(t, err)
+		data, err := cfg.GetAuditLogData("aws", "eu-central-1")
+		require.NoError(t, err)
+		assert.Equal(t, AuditLogData{}, data)
+	})
+
+	t.Run("Should reject wildcard which is not at the end of the region rule", func(t *testing.T) {
+		// when
+		_, err := LoadConfiguration(strings.NewReader(`{"aws":{"eu-*-1":{"disabled":true}}}`))
+
+		// then
+		require.EqualError(t, err, "invalid region rule 'eu-*-1' for providerType: 'aws', the wildcard is allowed only at the end")
+	})
+}
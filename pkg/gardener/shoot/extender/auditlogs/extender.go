@@ -10,6 +10,16 @@ type Extend = func(runtime imv1.Runtime, shoot *gardener.Shoot) error
 type operation = func(*gardener.Shoot) error
 
 func NewAuditlogExtenderForCreate(policyConfigMapName string, data AuditLogData) Extend {
+	return newAuditlogExtender(policyConfigMapName, data)
+}
+
+// NewAuditlogExtenderForPatch updates the audit log secret reference together with the policy config map,
+// the secret reference must follow the tenant configured in the auditlog extension when the tenant is migrated
+func NewAuditlogExtenderForPatch(policyConfigMapName string, data AuditLogData) Extend {
+	return newAuditlogExtender(policyConfigMapName, data)
+}
+
+func newAuditlogExtender(policyConfigMapName string, data AuditLogData) Extend {
 	return func(_ imv1.Runtime, shoot *gardener.Shoot) error {
 		for _, f := range []operation{
 			oSetSecret(data.SecretName),
@@ -22,9 +32,3 @@ func NewAuditlogExtenderForCreate(policyConfigMapName string, data AuditLogData)
 		return nil
 	}
 }
-
-func NewAuditlogExtenderForPatch(policyConfigMapName string) Extend {
-	return func(_ imv1.Runtime, shoot *gardener.Shoot) error {
-		return oSetPolicyConfigmap(policyConfigMapName)(shoot)
-	}
-}
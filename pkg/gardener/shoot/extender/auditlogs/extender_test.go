@@ -6,6 +6,7 @@ import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/autoscaling/v1"
 )
 
 func Test_AuditlogExtender(t *testing.T) {
@@ -33,3 +34,45 @@ func Test_AuditlogExtender(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func Test_AuditlogExtenderForPatch(t *testing.T) {
+	var zero imv1.Runtime
+
+	existingResources := []gardener.NamedResourceReference{
+		{
+			Name: "other-resource",
+			ResourceRef: v1.CrossVersionObjectReference{
+				Name:       "other-secret",
+				Kind:       "Secret",
+				APIVersion: "v1",
+			},
+		},
+		{
+			Name: auditlogSecretReference,
+			ResourceRef: v1.CrossVersionObjectReference{
+				Name:       "old-tenant-secret",
+				Kind:       "Secret",
+				APIVersion: "v1",
+			},
+		},
+	}
+
+	// given
+	shoot := gardener.Shoot{}
+	shoot.Spec.Resources = existingResources
+	extendWithAuditlogs := NewAuditlogExtenderForPatch("policy-config-map", AuditLogData{
+		TenantID:   "new-tenant-id",
+		ServiceURL: "testme",
+		SecretName: "new-tenant-secret",
+	})
+
+	// when
+	err := extendWithAuditlogs(zero, &shoot)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, shoot.Spec.Resources, 2)
+	requireNoErrorAssertContainsSecretResource(t, "new-tenant-secret", shoot.Spec.Resources)
+	require.Equal(t, "other-secret", shoot.Spec.Resources[0].ResourceRef.Name)
+	require.Equal(t, "old-tenant-secret", existingResources[1].ResourceRef.Name, "resources of the existing shoot must not be modified")
+}
@@ -28,6 +28,8 @@ func oSetSecret(secretName string) operation {
 			return nil
 		}
 
+		// resources can be shared with the existing shoot on patch, do not modify them in place
+		s.Spec.Resources = slices.Clone(s.Spec.Resources)
 		s.Spec.Resources[index] = resource
 		return nil
 	}
@@ -13,10 +13,12 @@ const (
 	DefaultAzureCloudProfileName     = "az"
 	DefaultGCPCloudProfileName       = "gcp"
 	DefaultOpenStackCloudProfileName = "converged-cloud-kyma"
+	DefaultAlicloudCloudProfileName  = "alicloud"
+	DefaultLocalCloudProfileName     = "local"
 )
 
 func ExtendWithCloudProfile(runtime imv1.Runtime, shoot *gardener.Shoot) error {
-	cloudProfileName, err := getCloudProfileName(runtime)
+	cloudProfileName, err := GetCloudProfileName(runtime)
 
 	if err != nil {
 		return err
@@ -27,7 +29,7 @@ func ExtendWithCloudProfile(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 	return nil
 }
 
-func getCloudProfileName(runtime imv1.Runtime) (string, error) {
+func GetCloudProfileName(runtime imv1.Runtime) (string, error) {
 	switch runtime.Spec.Shoot.Provider.Type {
 	case hyperscaler.TypeAWS:
 		return DefaultAWSCloudProfileName, nil
@@ -37,6 +39,10 @@ func getCloudProfileName(runtime imv1.Runtime) (string, error) {
 		return DefaultAzureCloudProfileName, nil
 	case hyperscaler.TypeOpenStack:
 		return DefaultOpenStackCloudProfileName, nil
+	case hyperscaler.TypeAlicloud:
+		return DefaultAlicloudCloudProfileName, nil
+	case hyperscaler.TypeLocal:
+		return DefaultLocalCloudProfileName, nil
 	}
 
 	return "", errors.New("provider not supported")
@@ -37,6 +37,16 @@ func TestExtendWithCloudProfile(t *testing.T) {
 			providerType:    hyperscaler.TypeOpenStack,
 			expectedProfile: ptr.To(DefaultOpenStackCloudProfileName),
 		},
+		{
+			name:            "Set cloud profile for alicloud",
+			providerType:    hyperscaler.TypeAlicloud,
+			expectedProfile: ptr.To(DefaultAlicloudCloudProfileName),
+		},
+		{
+			name:            "Set cloud profile for local",
+			providerType:    hyperscaler.TypeLocal,
+			expectedProfile: ptr.To(DefaultLocalCloudProfileName),
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			// given
@@ -0,0 +1,75 @@
+package extender
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+)
+
+// NewClusterAutoscalerExtender merges the Runtime CR autoscaler settings into the existing shoot settings or the plan defaults, and sets the plan worker scaling defaults.
+func NewClusterAutoscalerExtender(planDefaults map[string]config.ClusterAutoscalerPlanDefaults, existingClusterAutoscaler *gardener.ClusterAutoscaler) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		defaults, planDefaultsFound := planDefaults[runtime.Labels[imv1.LabelKymaBrokerPlanName]]
+
+		var clusterAutoscaler *gardener.ClusterAutoscaler
+
+		switch {
+		case existingClusterAutoscaler != nil:
+			clusterAutoscaler = existingClusterAutoscaler.DeepCopy()
+		case planDefaultsFound:
+			clusterAutoscaler = defaults.Settings.DeepCopy()
+		}
+
+		if runtimeClusterAutoscaler := runtime.Spec.Shoot.Kubernetes.ClusterAutoscaler; runtimeClusterAutoscaler != nil {
+			if clusterAutoscaler == nil {
+				clusterAutoscaler = &gardener.ClusterAutoscaler{}
+			}
+			setClusterAutoscalerSettings(clusterAutoscaler, *runtimeClusterAutoscaler)
+		}
+
+		shoot.Spec.Kubernetes.ClusterAutoscaler = clusterAutoscaler
+
+		if planDefaultsFound {
+			setWorkerScalingDefaults(shoot.Spec.Provider.Workers, defaults)
+		}
+
+		return nil
+	}
+}
+
+func setClusterAutoscalerSettings(clusterAutoscaler *gardener.ClusterAutoscaler, settings imv1.ClusterAutoscaler) {
+	if settings.ScaleDownDelayAfterAdd != nil {
+		clusterAutoscaler.ScaleDownDelayAfterAdd = settings.ScaleDownDelayAfterAdd
+	}
+
+	if settings.ScaleDownUnneededTime != nil {
+		clusterAutoscaler.ScaleDownUnneededTime = settings.ScaleDownUnneededTime
+	}
+
+	if settings.ScaleDownUtilizationThreshold != nil {
+		clusterAutoscaler.ScaleDownUtilizationThreshold = settings.ScaleDownUtilizationThreshold
+	}
+
+	if settings.Expander != nil {
+		expander := gardener.ExpanderMode(*settings.Expander)
+		clusterAutoscaler.Expander = &expander
+	}
+
+	if settings.MaxNodeProvisionTime != nil {
+		clusterAutoscaler.MaxNodeProvisionTime = settings.MaxNodeProvisionTime
+	}
+}
+
+func setWorkerScalingDefaults(workers []gardener.Worker, defaults config.ClusterAutoscalerPlanDefaults) {
+	for i := range workers {
+		if workers[i].MaxSurge == nil && defaults.WorkerMaxSurge != nil {
+			maxSurge := *defaults.WorkerMaxSurge
+			workers[i].MaxSurge = &maxSurge
+		}
+
+		if workers[i].MaxUnavailable == nil && defaults.WorkerMaxUnavailable != nil {
+			maxUnavailable := *defaults.WorkerMaxUnavailable
+			workers[i].MaxUnavailable = &maxUnavailable
+		}
+	}
+}
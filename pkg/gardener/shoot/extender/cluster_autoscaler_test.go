@@ -0,0 +1,125 @@
+package extender
+
+import (
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestClusterAutoscalerExtender(t *testing.T) {
+	planDefaults := map[string]config.ClusterAutoscalerPlanDefaults{
+		"aws": {
+			Settings: gardener.ClusterAutoscaler{
+				ScaleDownUtilizationThreshold: ptr.To(0.5),
+				Expander:                      ptr.To(gardener.ClusterAutoscalerExpanderLeastWaste),
+			},
+			WorkerMaxSurge:       ptr.To(intstr.FromInt32(2)),
+			WorkerMaxUnavailable: ptr.To(intstr.FromInt32(0)),
+		},
+	}
+
+	for _, testCase := range []struct {
+		name                      string
+		plan                      string
+		runtimeClusterAutoscaler  *imv1.ClusterAutoscaler
+		existingClusterAutoscaler *gardener.ClusterAutoscaler
+		workers                   []gardener.Worker
+		expectedClusterAutoscaler *gardener.ClusterAutoscaler
+		expectedWorkers           []gardener.Worker
+	}{
+		{
+			name:                      "Leave cluster autoscaler unset when plan has no defaults and Runtime CR has no settings",
+			plan:                      "azure",
+			expectedClusterAutoscaler: nil,
+		},
+		{
+			name: "Use plan defaults",
+			plan: "aws",
+			workers: []gardener.Worker{
+				{Name: "worker-0"},
+				{Name: "worker-1", MaxSurge: ptr.To(intstr.FromInt32(1))},
+			},
+			expectedClusterAutoscaler: &gardener.ClusterAutoscaler{
+				ScaleDownUtilizationThreshold: ptr.To(0.5),
+				Expander:                      ptr.To(gardener.ClusterAutoscalerExpanderLeastWaste),
+			},
+			expectedWorkers: []gardener.Worker{
+				{Name: "worker-0", MaxSurge: ptr.To(intstr.FromInt32(2)), MaxUnavailable: ptr.To(intstr.FromInt32(0))},
+				{Name: "worker-1", MaxSurge: ptr.To(intstr.FromInt32(1)), MaxUnavailable: ptr.To(intstr.FromInt32(0))},
+			},
+		},
+		{
+			name: "Apply Runtime CR settings on top of plan defaults",
+			plan: "aws",
+			runtimeClusterAutoscaler: &imv1.ClusterAutoscaler{
+				Expander:             ptr.To("most-pods"),
+				MaxNodeProvisionTime: &metav1.Duration{Duration: 15 * time.Minute},
+			},
+			expectedClusterAutoscaler: &gardener.ClusterAutoscaler{
+				ScaleDownUtilizationThreshold: ptr.To(0.5),
+				Expander:                      ptr.To(gardener.ClusterAutoscalerExpanderMostPods),
+				MaxNodeProvisionTime:          &metav1.Duration{Duration: 15 * time.Minute},
+			},
+		},
+		{
+			name: "Apply Runtime CR settings on top of existing shoot settings",
+			plan: "aws",
+			runtimeClusterAutoscaler: &imv1.ClusterAutoscaler{
+				ScaleDownDelayAfterAdd: &metav1.Duration{Duration: time.Hour},
+			},
+			existingClusterAutoscaler: &gardener.ClusterAutoscaler{
+				ScaleDownUnneededTime: &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			expectedClusterAutoscaler: &gardener.ClusterAutoscaler{
+				ScaleDownDelayAfterAdd: &metav1.Duration{Duration: time.Hour},
+				ScaleDownUnneededTime:  &metav1.Duration{Duration: 30 * time.Minute},
+			},
+		},
+		{
+			name: "Keep existing shoot settings when Runtime CR has no settings",
+			plan: "azure",
+			existingClusterAutoscaler: &gardener.ClusterAutoscaler{
+				ScaleDownUtilizationThreshold: ptr.To(0.7),
+			},
+			expectedClusterAutoscaler: &gardener.ClusterAutoscaler{
+				ScaleDownUtilizationThreshold: ptr.To(0.7),
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("test", "kcp-system")
+			shoot.Spec.Provider.Workers = testCase.workers
+			runtime := imv1.Runtime{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{imv1.LabelKymaBrokerPlanName: testCase.plan},
+				},
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Kubernetes: imv1.Kubernetes{
+							ClusterAutoscaler: testCase.runtimeClusterAutoscaler,
+						},
+					},
+				},
+			}
+
+			// when
+			extender := NewClusterAutoscalerExtender(planDefaults, testCase.existingClusterAutoscaler)
+			err := extender(runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedClusterAutoscaler, shoot.Spec.Kubernetes.ClusterAutoscaler)
+			assert.Equal(t, testCase.expectedWorkers, shoot.Spec.Provider.Workers)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,4 +37,26 @@ func TestDNSExtender(t *testing.T) {
 		assert.Equal(t, secretName, *shoot.Spec.DNS.Providers[0].SecretName)                               //nolint:staticcheck
 		assert.Equal(t, true, *shoot.Spec.DNS.Providers[0].Primary)                                        //nolint:staticcheck
 	})
+	t.Run("Use Gardener default domain for local provider", func(t *testing.T) {
+		// given
+		runtimeShoot := imv1.Runtime{
+			Spec: imv1.RuntimeSpec{
+				Shoot: imv1.RuntimeShoot{
+					Name: "myshoot",
+					Provider: imv1.Provider{
+						Type: hyperscaler.TypeLocal,
+					},
+				},
+			},
+		}
+		extender := NewDNSExtender("my-secret", "dev.mydomain.com", "aws-route53")
+		shoot := testutils.FixEmptyGardenerShoot("test", "dev")
+
+		// when
+		err := extender(runtimeShoot, &shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.DNS)
+	})
 }
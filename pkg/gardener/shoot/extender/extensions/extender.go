@@ -10,6 +10,7 @@ import (
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 )
 
 type CreateExtensionFunc func(runtime imv1.Runtime, shoot gardener.Shoot) (*gardener.Extension, error)
@@ -19,7 +20,7 @@ type Extension struct {
 	Create CreateExtensionFunc
 }
 
-func NewExtensionsExtenderForCreate(config config.ConverterConfig, auditLogData auditlogs.AuditLogData, registryCache []registrycache.RegistryCache) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+func NewExtensionsExtenderForCreate(config config.ConverterConfig, auditLogData auditlogs.AuditLogData, registryCache []registrycache.RegistryCache, oidcExtensionEnabled bool) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 	return newExtensionsExtender([]Extension{
 		{
 			Type: NetworkFilterType,
@@ -35,8 +36,8 @@ func NewExtensionsExtenderForCreate(config config.ConverterConfig, auditLogData
 		},
 		{
 			Type: DNSExtensionType,
-			Create: func(_ imv1.Runtime, shoot gardener.Shoot) (*gardener.Extension, error) {
-				if config.DNS.IsGardenerInternal() {
+			Create: func(runtime imv1.Runtime, shoot gardener.Shoot) (*gardener.Extension, error) {
+				if config.DNS.IsGardenerInternal() || runtime.Spec.Shoot.Provider.Type == hyperscaler.TypeLocal {
 					return NewDNSExtensionInternal()
 				}
 				return NewDNSExtensionExternal(shoot.Name, config.DNS.SecretName, config.DNS.DomainPrefix, config.DNS.ProviderType)
@@ -45,7 +46,7 @@ func NewExtensionsExtenderForCreate(config config.ConverterConfig, auditLogData
 		{
 			Type: OidcExtensionType,
 			Create: func(_ imv1.Runtime, _ gardener.Shoot) (*gardener.Extension, error) {
-				return NewOIDCExtension()
+				return NewOIDCExtension(oidcExtensionEnabled)
 			},
 		},
 		{
@@ -71,7 +72,7 @@ func NewExtensionsExtenderForCreate(config config.ConverterConfig, auditLogData
 	}, nil)
 }
 
-func NewExtensionsExtenderForPatch(auditLogData auditlogs.AuditLogData, registryCache []registrycache.RegistryCache, extensionsOnTheShoot []gardener.Extension) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+func NewExtensionsExtenderForPatch(auditLogData auditlogs.AuditLogData, registryCache []registrycache.RegistryCache, extensionsOnTheShoot []gardener.Extension, oidcExtensionEnabled bool) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 	return newExtensionsExtender([]Extension{
 		{
 			AuditlogExtensionType,
@@ -130,6 +131,22 @@ func NewExtensionsExtenderForPatch(auditLogData auditlogs.AuditLogData, registry
 					}
 				}
 
+				return nil, nil
+			},
+		},
+		{
+			Type: OidcExtensionType,
+			Create: func(_ imv1.Runtime, shoot gardener.Shoot) (*gardener.Extension, error) {
+				if oidcExtensionEnabled {
+					return NewOIDCExtension(true)
+				}
+
+				for _, ext := range shoot.Spec.Extensions {
+					if ext.Type == OidcExtensionType {
+						return NewOIDCExtension(false)
+					}
+				}
+
 				return nil, nil
 			},
 		},
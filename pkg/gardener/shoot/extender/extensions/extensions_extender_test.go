@@ -10,12 +10,14 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 
+	"slices"
 	"testing"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,6 +48,7 @@ func TestNewExtensionsExtenderForCreate(t *testing.T) {
 		caches              []registrycache.RegistryCache
 		enableNetworkFilter bool
 		enableImageCaching  bool
+		enableOIDCExtension bool
 		extensionOrderMap   map[string]int
 	}{
 		{
@@ -56,6 +59,15 @@ func TestNewExtensionsExtenderForCreate(t *testing.T) {
 			extensionOrderMap:   getExpectedExtensionsOrderMapForCreate(),
 			caches:              caches,
 		},
+		{
+			name:                "Should create all extensions for new Shoot in the right order, OIDC extension is enabled",
+			inputAuditLogData:   newAuditLogData,
+			enableNetworkFilter: true,
+			enableImageCaching:  true,
+			enableOIDCExtension: true,
+			extensionOrderMap:   getExpectedExtensionsOrderMapForCreate(),
+			caches:              caches,
+		},
 		{
 			name:                "Should create all extensions for new Shoot in the right order, network filter is disabled",
 			inputAuditLogData:   newAuditLogData,
@@ -81,7 +93,7 @@ func TestNewExtensionsExtenderForCreate(t *testing.T) {
 				},
 			}
 
-			extender := NewExtensionsExtenderForCreate(config, testcase.inputAuditLogData, testcase.caches)
+			extender := NewExtensionsExtenderForCreate(config, testcase.inputAuditLogData, testcase.caches, testcase.enableOIDCExtension)
 
 			err := extender(runtime, shoot)
 			assert.NoError(t, err)
@@ -109,7 +121,7 @@ func TestNewExtensionsExtenderForCreate(t *testing.T) {
 
 				case OidcExtensionType:
 
-					verifyOIDCExtension(t, ext)
+					verifyOIDCExtension(t, ext, testcase.enableOIDCExtension)
 
 				case RegistryCacheExtensionType:
 					verifyRegistryCacheExtension(t, &ext, testcase.caches, testcase.enableImageCaching)
@@ -119,6 +131,43 @@ func TestNewExtensionsExtenderForCreate(t *testing.T) {
 	}
 }
 
+func TestNewExtensionsExtenderForCreateLocalProvider(t *testing.T) {
+	// given
+	config := config.ConverterConfig{
+		DNS: config.DNSConfig{
+			SecretName:   "test-dns-secret",
+			DomainPrefix: "test-domain",
+			ProviderType: "test-provider",
+		},
+	}
+
+	runtime := fixRuntimeCRForExtensionExtenderTests(false, false)
+	runtime.Spec.Shoot.Provider.Type = hyperscaler.TypeLocal
+
+	shoot := &gardener.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-shoot-name",
+		},
+	}
+
+	// when
+	err := NewExtensionsExtenderForCreate(config, auditlogs.AuditLogData{}, nil, false)(runtime, shoot)
+
+	// then
+	require.NoError(t, err)
+
+	dnsIndex := slices.IndexFunc(shoot.Spec.Extensions, func(e gardener.Extension) bool {
+		return e.Type == DNSExtensionType
+	})
+	require.NotEqual(t, -1, dnsIndex)
+
+	var dnsConfig DNSExtensionProviderConfig
+	err = json.Unmarshal(shoot.Spec.Extensions[dnsIndex].ProviderConfig.Raw, &dnsConfig)
+	require.NoError(t, err)
+	assert.Empty(t, dnsConfig.Providers)
+	assert.Nil(t, dnsConfig.DNSProviderReplication)
+}
+
 func TestNewExtensionsExtenderForPatch(t *testing.T) {
 	oldAuditLogData := auditlogs.AuditLogData{
 		TenantID:   "test-auditlog-tenant",
@@ -143,6 +192,7 @@ func TestNewExtensionsExtenderForPatch(t *testing.T) {
 		registryCaches       []registrycache.RegistryCache
 		enableNetworkFilter  bool
 		enableImageCaching   bool
+		enableOIDCExtension  bool
 	}{
 		{
 			name:                 "Should add AuditLog extension at the end without changing order and data of other extensions",
@@ -252,6 +302,46 @@ func TestNewExtensionsExtenderForPatch(t *testing.T) {
 			enableNetworkFilter:  false,
 			enableImageCaching:   true,
 		},
+		{
+			name:                 "Should disable OIDC extension without changing order and data of other extensions",
+			previousExtensions:   fixAllExtensionsOnTheShoot(),
+			inputAuditLogData:    oldAuditLogData,
+			expectedAuditLogData: oldAuditLogData,
+			registryCaches:       oldCaches,
+			enableNetworkFilter:  false,
+			enableImageCaching:   true,
+			enableOIDCExtension:  false,
+		},
+		{
+			name:                 "Should keep OIDC extension enabled when OIDC configs are applied as OpenIDConnect resources",
+			previousExtensions:   fixAllExtensionsOnTheShoot(),
+			inputAuditLogData:    oldAuditLogData,
+			expectedAuditLogData: oldAuditLogData,
+			registryCaches:       oldCaches,
+			enableNetworkFilter:  false,
+			enableImageCaching:   true,
+			enableOIDCExtension:  true,
+		},
+		{
+			name:                 "Should add enabled OIDC extension at the end without changing order and data of other extensions",
+			previousExtensions:   []gardener.Extension{fixNetworkExtension(), fixDNSExtension(), fixCertExtension()},
+			inputAuditLogData:    auditlogs.AuditLogData{},
+			expectedAuditLogData: auditlogs.AuditLogData{},
+			registryCaches:       nil,
+			enableNetworkFilter:  false,
+			enableImageCaching:   false,
+			enableOIDCExtension:  true,
+		},
+		{
+			name:                 "Should not add disabled OIDC extension to existing shoot extensions",
+			previousExtensions:   []gardener.Extension{fixNetworkExtension(), fixDNSExtension(), fixCertExtension()},
+			inputAuditLogData:    auditlogs.AuditLogData{},
+			expectedAuditLogData: auditlogs.AuditLogData{},
+			registryCaches:       nil,
+			enableNetworkFilter:  false,
+			enableImageCaching:   false,
+			enableOIDCExtension:  false,
+		},
 		{
 			name:                 "Should not update existing AuditLog extension when input auditLogData is empty",
 			previousExtensions:   fixAllExtensionsOnTheShoot(),
@@ -274,8 +364,8 @@ func TestNewExtensionsExtenderForPatch(t *testing.T) {
 			auditLogDataProvided := testCase.inputAuditLogData != (auditlogs.AuditLogData{})
 			registryCacheDataProvided := testCase.enableImageCaching && len(testCase.registryCaches) != 0
 
-			extender := NewExtensionsExtenderForPatch(testCase.inputAuditLogData, testCase.registryCaches, testCase.previousExtensions)
-			orderMap := getExpectedExtensionsOrderMapForPatch(testCase.previousExtensions, testCase.enableNetworkFilter, auditLogDataProvided, registryCacheDataProvided)
+			extender := NewExtensionsExtenderForPatch(testCase.inputAuditLogData, testCase.registryCaches, testCase.previousExtensions, testCase.enableOIDCExtension)
+			orderMap := getExpectedExtensionsOrderMapForPatch(testCase.previousExtensions, testCase.enableNetworkFilter, auditLogDataProvided, registryCacheDataProvided, testCase.enableOIDCExtension)
 
 			err := extender(runtime, shoot)
 			assert.NoError(t, err)
@@ -297,7 +387,7 @@ func TestNewExtensionsExtenderForPatch(t *testing.T) {
 					verifyDNSExtension(t, ext)
 
 				case OidcExtensionType:
-					verifyOIDCExtension(t, ext)
+					verifyOIDCExtension(t, ext, testCase.enableOIDCExtension)
 
 				case AuditlogExtensionType:
 					verifyAuditLogExtension(t, ext, testCase.expectedAuditLogData)
@@ -373,7 +463,7 @@ func fixRegistryCacheExtension() gardener.Extension {
 
 }
 
-func getExpectedExtensionsOrderMapForPatch(previousExtensions []gardener.Extension, networkExtAdded bool, auditLogExtAdded bool, registryCacheExtAdded bool) map[string]int {
+func getExpectedExtensionsOrderMapForPatch(previousExtensions []gardener.Extension, networkExtAdded bool, auditLogExtAdded bool, registryCacheExtAdded bool, oidcExtAdded bool) map[string]int {
 	extensionOrderMap := make(map[string]int)
 
 	for idx, ext := range previousExtensions {
@@ -402,6 +492,14 @@ func getExpectedExtensionsOrderMapForPatch(previousExtensions []gardener.Extensi
 		}
 	}
 
+	if oidcExtAdded {
+		_, found := extensionOrderMap[OidcExtensionType]
+
+		if !found {
+			extensionOrderMap[OidcExtensionType] = len(extensionOrderMap)
+		}
+	}
+
 	return extensionOrderMap
 }
 
@@ -444,9 +542,9 @@ func verifyAuditLogExtension(t *testing.T, ext gardener.Extension, expected audi
 	assert.Equal(t, "AuditlogConfig", auditlogConfig.Kind)
 }
 
-func verifyOIDCExtension(t *testing.T, ext gardener.Extension) {
+func verifyOIDCExtension(t *testing.T, ext gardener.Extension, enabled bool) {
 	require.NotNil(t, ext.Disabled)
-	assert.Equal(t, false, *ext.Disabled)
+	assert.Equal(t, !enabled, *ext.Disabled)
 }
 
 func verifyDNSExtension(t *testing.T, ext gardener.Extension) {
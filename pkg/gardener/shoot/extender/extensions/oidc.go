@@ -9,9 +9,11 @@ const (
 	OidcExtensionType = "shoot-oidc-service"
 )
 
-func NewOIDCExtension() (*gardener.Extension, error) {
+// NewOIDCExtension enables the shoot-oidc-service extension only when OIDC configs are applied as OpenIDConnect resources in the SKR,
+// all other OIDC configs are rendered in the structured authentication configuration
+func NewOIDCExtension(enabled bool) (*gardener.Extension, error) {
 	return &gardener.Extension{
 		Type:     OidcExtensionType,
-		Disabled: ptr.To(false),
+		Disabled: ptr.To(!enabled),
 	}, nil
 }
@@ -0,0 +1,56 @@
+package extender
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+)
+
+// NewHibernationExtender merges the Runtime CR hibernation settings into the existing shoot settings, the plan defaults are used when the Runtime CR has none.
+func NewHibernationExtender(planDefaults map[string]gardener.Hibernation, existingHibernation *gardener.Hibernation) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		defaults, planDefaultsFound := planDefaults[runtime.Labels[imv1.LabelKymaBrokerPlanName]]
+
+		runtimeHibernation := runtime.Spec.Shoot.Hibernation
+		if runtimeHibernation == nil {
+			shoot.Spec.Hibernation = nil
+			if planDefaultsFound {
+				shoot.Spec.Hibernation = defaults.DeepCopy()
+			}
+			return nil
+		}
+
+		var hibernation *gardener.Hibernation
+
+		switch {
+		case existingHibernation != nil:
+			hibernation = existingHibernation.DeepCopy()
+		case planDefaultsFound:
+			hibernation = defaults.DeepCopy()
+		default:
+			hibernation = &gardener.Hibernation{}
+		}
+
+		setHibernationSettings(hibernation, *runtimeHibernation)
+		shoot.Spec.Hibernation = hibernation
+
+		return nil
+	}
+}
+
+func setHibernationSettings(hibernation *gardener.Hibernation, settings imv1.Hibernation) {
+	if settings.Enabled != nil {
+		enabled := *settings.Enabled
+		hibernation.Enabled = &enabled
+	}
+
+	if settings.Schedules != nil {
+		hibernation.Schedules = make([]gardener.HibernationSchedule, 0, len(settings.Schedules))
+		for _, schedule := range settings.Schedules {
+			hibernation.Schedules = append(hibernation.Schedules, gardener.HibernationSchedule{
+				Start:    schedule.Start,
+				End:      schedule.End,
+				Location: schedule.Location,
+			})
+		}
+	}
+}
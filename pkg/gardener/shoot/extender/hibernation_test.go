@@ -0,0 +1,111 @@
+package extender
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestHibernationExtender(t *testing.T) {
+	planDefaults := map[string]gardener.Hibernation{
+		"trial": {
+			Schedules: []gardener.HibernationSchedule{
+				{Start: ptr.To("00 20 * * 1,2,3,4,5"), End: ptr.To("00 06 * * 1,2,3,4,5"), Location: ptr.To("Europe/Berlin")},
+			},
+		},
+	}
+
+	for _, testCase := range []struct {
+		name                string
+		plan                string
+		runtimeHibernation  *imv1.Hibernation
+		existingHibernation *gardener.Hibernation
+		expectedHibernation *gardener.Hibernation
+	}{
+		{
+			name:                "Leave hibernation unset when plan has no defaults and Runtime CR has no settings",
+			plan:                "aws",
+			expectedHibernation: nil,
+		},
+		{
+			name: "Use plan defaults",
+			plan: "trial",
+			expectedHibernation: &gardener.Hibernation{
+				Schedules: []gardener.HibernationSchedule{
+					{Start: ptr.To("00 20 * * 1,2,3,4,5"), End: ptr.To("00 06 * * 1,2,3,4,5"), Location: ptr.To("Europe/Berlin")},
+				},
+			},
+		},
+		{
+			name:               "Enable hibernation on top of plan defaults",
+			plan:               "trial",
+			runtimeHibernation: &imv1.Hibernation{Enabled: ptr.To(true)},
+			expectedHibernation: &gardener.Hibernation{
+				Enabled: ptr.To(true),
+				Schedules: []gardener.HibernationSchedule{
+					{Start: ptr.To("00 20 * * 1,2,3,4,5"), End: ptr.To("00 06 * * 1,2,3,4,5"), Location: ptr.To("Europe/Berlin")},
+				},
+			},
+		},
+		{
+			name: "Replace schedules of existing shoot settings",
+			plan: "trial",
+			runtimeHibernation: &imv1.Hibernation{
+				Schedules: []imv1.HibernationSchedule{{Start: ptr.To("00 22 * * *"), Location: ptr.To("America/New_York")}},
+			},
+			existingHibernation: &gardener.Hibernation{
+				Enabled:   ptr.To(false),
+				Schedules: []gardener.HibernationSchedule{{Start: ptr.To("00 18 * * *")}},
+			},
+			expectedHibernation: &gardener.Hibernation{
+				Enabled:   ptr.To(false),
+				Schedules: []gardener.HibernationSchedule{{Start: ptr.To("00 22 * * *"), Location: ptr.To("America/New_York")}},
+			},
+		},
+		{
+			name:                "Fall back to plan defaults when hibernation is removed from the Runtime CR",
+			plan:                "trial",
+			existingHibernation: &gardener.Hibernation{Enabled: ptr.To(true), Schedules: []gardener.HibernationSchedule{{Start: ptr.To("00 18 * * *")}}},
+			expectedHibernation: &gardener.Hibernation{
+				Schedules: []gardener.HibernationSchedule{
+					{Start: ptr.To("00 20 * * 1,2,3,4,5"), End: ptr.To("00 06 * * 1,2,3,4,5"), Location: ptr.To("Europe/Berlin")},
+				},
+			},
+		},
+		{
+			name:                "Remove hibernation from hibernated shoot when it is removed from the Runtime CR and plan has no defaults",
+			plan:                "aws",
+			existingHibernation: &gardener.Hibernation{Enabled: ptr.To(true), Schedules: []gardener.HibernationSchedule{{Start: ptr.To("00 18 * * *")}}},
+			expectedHibernation: nil,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("test", "kcp-system")
+			runtime := imv1.Runtime{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{imv1.LabelKymaBrokerPlanName: testCase.plan},
+				},
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Hibernation: testCase.runtimeHibernation,
+					},
+				},
+			}
+
+			// when
+			extender := NewHibernationExtender(planDefaults, testCase.existingHibernation)
+			err := extender(runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedHibernation, shoot.Spec.Hibernation)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package extender
+
+import (
+	"slices"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// NewKubeAPIServerExtender merges the kube-apiserver settings from the Runtime CR into the shoot kube-apiserver configuration.
+// Settings managed by other extenders (structured authentication, audit config) are left untouched.
+func NewKubeAPIServerExtender(kubeAPIServerConfig config.KubeAPIServerConfig) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		settings := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.Settings
+		if settings == nil {
+			return nil
+		}
+
+		if err := validateKubeAPIServerSettings(*settings, kubeAPIServerConfig); err != nil {
+			return err
+		}
+
+		if shoot.Spec.Kubernetes.KubeAPIServer == nil {
+			shoot.Spec.Kubernetes.KubeAPIServer = &gardener.KubeAPIServerConfig{}
+		}
+
+		setKubeAPIServerSettings(shoot.Spec.Kubernetes.KubeAPIServer, *settings)
+
+		return nil
+	}
+}
+
+func validateKubeAPIServerSettings(settings imv1.KubeAPIServerSettings, kubeAPIServerConfig config.KubeAPIServerConfig) error {
+	for _, admissionPlugin := range settings.AdmissionPlugins {
+		if !slices.Contains(kubeAPIServerConfig.AllowedAdmissionPlugins, admissionPlugin.Name) {
+			return errors.Errorf("admission plugin %s is not allowed", admissionPlugin.Name)
+		}
+	}
+
+	for featureGate := range settings.FeatureGates {
+		if !slices.Contains(kubeAPIServerConfig.AllowedFeatureGates, featureGate) {
+			return errors.Errorf("kube-apiserver feature gate %s is not allowed", featureGate)
+		}
+	}
+
+	return nil
+}
+
+func setKubeAPIServerSettings(kubeAPIServer *gardener.KubeAPIServerConfig, settings imv1.KubeAPIServerSettings) {
+	for _, admissionPlugin := range settings.AdmissionPlugins {
+		kubeAPIServer.AdmissionPlugins = append(kubeAPIServer.AdmissionPlugins, gardener.AdmissionPlugin{
+			Name:     admissionPlugin.Name,
+			Config:   admissionPlugin.Config,
+			Disabled: admissionPlugin.Disabled,
+		})
+	}
+
+	if settings.Requests != nil {
+		kubeAPIServer.Requests = settings.Requests.DeepCopy()
+	}
+
+	if len(settings.FeatureGates) > 0 && kubeAPIServer.FeatureGates == nil {
+		kubeAPIServer.FeatureGates = make(map[string]bool, len(settings.FeatureGates))
+	}
+
+	for featureGate, enabled := range settings.FeatureGates {
+		kubeAPIServer.FeatureGates[featureGate] = enabled
+	}
+
+	if settings.EventTTL != nil {
+		eventTTL := *settings.EventTTL
+		kubeAPIServer.EventTTL = &eventTTL
+	}
+
+	if len(settings.EncryptedResources) > 0 {
+		if kubeAPIServer.EncryptionConfig == nil {
+			kubeAPIServer.EncryptionConfig = &gardener.EncryptionConfig{}
+		}
+
+		for _, resource := range settings.EncryptedResources {
+			if !slices.Contains(kubeAPIServer.EncryptionConfig.Resources, resource) {
+				kubeAPIServer.EncryptionConfig.Resources = append(kubeAPIServer.EncryptionConfig.Resources, resource)
+			}
+		}
+	}
+}
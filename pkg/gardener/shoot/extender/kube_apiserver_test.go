@@ -0,0 +1,109 @@
+package extender
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestKubeAPIServerExtender(t *testing.T) {
+	allowed := config.KubeAPIServerConfig{
+		AllowedAdmissionPlugins: []string{"EventRateLimit", "PodNodeSelector"},
+		AllowedFeatureGates:     []string{"StructuredAuthorizationConfiguration"},
+	}
+
+	for tname, tc := range map[string]struct {
+		settings              *imv1.KubeAPIServerSettings
+		existingKubeAPIServer *gardener.KubeAPIServerConfig
+		expectedKubeAPIServer *gardener.KubeAPIServerConfig
+		expectedErr           string
+	}{
+		"Should not change the shoot when no settings are defined": {
+			existingKubeAPIServer: fixStructuredAuthKubeAPIServer(),
+			expectedKubeAPIServer: fixStructuredAuthKubeAPIServer(),
+		},
+		"Should merge settings with the existing kube-apiserver configuration": {
+			settings: &imv1.KubeAPIServerSettings{
+				AdmissionPlugins: []imv1.AdmissionPlugin{
+					{Name: "EventRateLimit", Config: &runtime.RawExtension{Raw: []byte(`{"limits":[]}`)}},
+					{Name: "PodNodeSelector", Disabled: ptr.To(true)},
+				},
+				Requests: &gardener.APIServerRequests{
+					MaxNonMutatingInflight: ptr.To(int32(800)),
+					MaxMutatingInflight:    ptr.To(int32(400)),
+				},
+				FeatureGates:       map[string]bool{"StructuredAuthorizationConfiguration": true},
+				EncryptedResources: []string{"configmaps", "secrets"},
+			},
+			existingKubeAPIServer: fixStructuredAuthKubeAPIServer(),
+			expectedKubeAPIServer: func() *gardener.KubeAPIServerConfig {
+				kubeAPIServer := fixStructuredAuthKubeAPIServer()
+				kubeAPIServer.AdmissionPlugins = []gardener.AdmissionPlugin{
+					{Name: "EventRateLimit", Config: &runtime.RawExtension{Raw: []byte(`{"limits":[]}`)}},
+					{Name: "PodNodeSelector", Disabled: ptr.To(true)},
+				}
+				kubeAPIServer.Requests = &gardener.APIServerRequests{
+					MaxNonMutatingInflight: ptr.To(int32(800)),
+					MaxMutatingInflight:    ptr.To(int32(400)),
+				}
+				kubeAPIServer.FeatureGates = map[string]bool{"StructuredAuthorizationConfiguration": true}
+				kubeAPIServer.EncryptionConfig = &gardener.EncryptionConfig{Resources: []string{"configmaps", "secrets"}}
+				return kubeAPIServer
+			}(),
+		},
+		"Should create kube-apiserver configuration when shoot has none": {
+			settings: &imv1.KubeAPIServerSettings{
+				Requests: &gardener.APIServerRequests{MaxMutatingInflight: ptr.To(int32(300))},
+			},
+			expectedKubeAPIServer: &gardener.KubeAPIServerConfig{
+				Requests: &gardener.APIServerRequests{MaxMutatingInflight: ptr.To(int32(300))},
+			},
+		},
+		"Should fail when admission plugin is not allowed": {
+			settings: &imv1.KubeAPIServerSettings{
+				AdmissionPlugins: []imv1.AdmissionPlugin{{Name: "AlwaysAdmit"}},
+			},
+			expectedErr: "admission plugin AlwaysAdmit is not allowed",
+		},
+		"Should fail when feature gate is not allowed": {
+			settings: &imv1.KubeAPIServerSettings{
+				FeatureGates: map[string]bool{"AnonymousAuthConfigurableEndpoints": true},
+			},
+			expectedErr: "kube-apiserver feature gate AnonymousAuthConfigurableEndpoints is not allowed",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("test", "kcp-system")
+			shoot.Spec.Kubernetes.KubeAPIServer = tc.existingKubeAPIServer
+			runtime := imv1.Runtime{}
+			runtime.Spec.Shoot.Kubernetes.KubeAPIServer.Settings = tc.settings
+
+			// when
+			err := NewKubeAPIServerExtender(allowed)(runtime, &shoot)
+
+			// then
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedKubeAPIServer, shoot.Spec.Kubernetes.KubeAPIServer)
+		})
+	}
+}
+
+func fixStructuredAuthKubeAPIServer() *gardener.KubeAPIServerConfig {
+	return &gardener.KubeAPIServerConfig{
+		StructuredAuthentication: &gardener.StructuredAuthentication{
+			ConfigMapName: "structured-auth-config-test",
+		},
+	}
+}
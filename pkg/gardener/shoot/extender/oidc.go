@@ -15,13 +15,15 @@ func NewOidcExtender() func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 		cmName := fmt.Sprintf(StructuredAuthConfigFmt, runtime.Spec.Shoot.Name)
 
-		shoot.Spec.Kubernetes.KubeAPIServer = &gardener.KubeAPIServerConfig{
-			StructuredAuthentication: &gardener.StructuredAuthentication{
-				ConfigMapName: cmName,
-			},
-			OIDCConfig: nil,
+		if shoot.Spec.Kubernetes.KubeAPIServer == nil {
+			shoot.Spec.Kubernetes.KubeAPIServer = &gardener.KubeAPIServerConfig{}
 		}
 
+		shoot.Spec.Kubernetes.KubeAPIServer.StructuredAuthentication = &gardener.StructuredAuthentication{
+			ConfigMapName: cmName,
+		}
+		shoot.Spec.Kubernetes.KubeAPIServer.OIDCConfig = nil //nolint:staticcheck
+
 		return nil
 	}
 }
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/gcp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func getGCPSettings(gcpSettings *imv1.GCPSettings) gcp.Settings {
+	if gcpSettings == nil {
+		return gcp.Settings{}
+	}
+
+	settings := gcp.Settings{
+		ControlPlaneZone: gcpSettings.ControlPlaneZone,
+	}
+
+	if cloudNAT := gcpSettings.CloudNAT; cloudNAT != nil {
+		settings.CloudNAT = &gcp.CloudNATSettings{
+			MinPortsPerVM:              cloudNAT.MinPortsPerVM,
+			EndpointIndependentMapping: cloudNAT.EndpointIndependentMapping,
+			NatIPNames:                 cloudNAT.NatIPNames,
+		}
+	}
+
+	return settings
+}
+
+func getGCPConfigForPatch(gcpSettings *imv1.GCPSettings, workerZones []string, existingInfraConfig, existingControlPlaneConfig *runtime.RawExtension) (*runtime.RawExtension, *runtime.RawExtension, error) {
+	if gcpSettings == nil {
+		return existingInfraConfig, existingControlPlaneConfig, nil
+	}
+
+	if existingControlPlaneConfig == nil {
+		return nil, nil, errors.New("existing control plane config is required")
+	}
+
+	settings := getGCPSettings(gcpSettings)
+
+	infraConfigBytes, err := gcp.GetInfrastructureConfigForPatch(settings.CloudNAT, existingInfraConfig.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	controlPlaneConfigBytes, err := gcp.GetControlPlaneConfigForPatch(workerZones, settings.ControlPlaneZone, existingControlPlaneConfig.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &runtime.RawExtension{Raw: infraConfigBytes}, &runtime.RawExtension{Raw: controlPlaneConfigBytes}, nil
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/openstack"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Values set on the Runtime CR take precedence over the ones configured for the region
+func getOpenStackSettings(rt imv1.Runtime, providerConfig config.ProviderConfig) openstack.Settings {
+	regionConfig := providerConfig.OpenStack.Regions[rt.Spec.Shoot.Region]
+
+	settings := openstack.Settings{
+		FloatingPoolName:     regionConfig.FloatingPoolName,
+		LoadBalancerProvider: regionConfig.LoadBalancerProvider,
+	}
+
+	if override := rt.Spec.Shoot.Provider.OpenStack; override != nil {
+		if override.FloatingPoolName != "" {
+			settings.FloatingPoolName = override.FloatingPoolName
+		}
+
+		if override.LoadBalancerProvider != "" {
+			settings.LoadBalancerProvider = override.LoadBalancerProvider
+		}
+	}
+
+	return settings
+}
+
+// Region defaults are not applied to existing shoots, only the values set on the Runtime CR are
+func getOpenStackConfigForPatch(override *imv1.OpenStackSettings, existingInfraConfig, existingControlPlaneConfig *runtime.RawExtension) (*runtime.RawExtension, *runtime.RawExtension, error) {
+	if override == nil {
+		return existingInfraConfig, existingControlPlaneConfig, nil
+	}
+
+	if existingControlPlaneConfig == nil {
+		return nil, nil, errors.New("existing control plane config is required")
+	}
+
+	infraConfigBytes, err := openstack.GetInfrastructureConfigForPatch(override.FloatingPoolName, existingInfraConfig.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	controlPlaneConfigBytes, err := openstack.GetControlPlaneConfigForPatch(override.LoadBalancerProvider, existingControlPlaneConfig.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &runtime.RawExtension{Raw: infraConfigBytes}, &runtime.RawExtension{Raw: controlPlaneConfigBytes}, nil
+}
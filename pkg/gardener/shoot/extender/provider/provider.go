@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
 	"slices"
 	"sort"
@@ -8,16 +9,18 @@ import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/alicloud"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/aws"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/azure"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/gcp"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/openstack"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // InfrastructureConfig and ControlPlaneConfig are generated unless they are specified in the RuntimeCR
-func NewProviderExtenderForCreateOperation(enableIMDSv2 bool, defMachineImgName, defMachineImgVer string) func(rt imv1.Runtime, shoot *gardener.Shoot) error {
+func NewProviderExtenderForCreateOperation(providerConfig config.ProviderConfig, defMachineImgName, defMachineImgVer string, sshAccessEnabled bool) func(rt imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(rt imv1.Runtime, shoot *gardener.Shoot) error {
 		provider := &shoot.Spec.Provider
 		provider.Type = rt.Spec.Shoot.Provider.Type
@@ -34,7 +37,7 @@ func NewProviderExtenderForCreateOperation(enableIMDSv2 bool, defMachineImgName,
 
 		workerZones := getNetworkingZonesFromWorkers(provider.Workers)
 
-		infraConfig, controlPlaneConf, err := getConfig(rt.Spec.Shoot, workerZones, nil)
+		infraConfig, controlPlaneConf, err := getConfig(rt, providerConfig, workerZones, nil)
 		if err != nil {
 			return err
 		}
@@ -43,23 +46,24 @@ func NewProviderExtenderForCreateOperation(enableIMDSv2 bool, defMachineImgName,
 		provider.InfrastructureConfig = infraConfig
 
 		setMachineImage(provider, defMachineImgName, defMachineImgVer)
-		if err = setWorkerConfig(provider, provider.Type, enableIMDSv2); err != nil {
+		if err = setWorkerConfig(provider, rt.Spec.Shoot.Provider.WorkersTuning, providerConfig.AWS.EnableIMDSv2); err != nil {
 			return err
 		}
-		setWorkerSettings(provider)
+		setWorkerSettings(provider, sshAccessEnabled)
 
 		return err
 	}
 }
 
 // Zones for patching workes are taken from existing shoot workers
-func NewProviderExtenderPatchOperation(enableIMDSv2 bool, defMachineImgName, defMachineImgVer string, shootWorkers []gardener.Worker, existingInfraConfig, existingControlPlaneConfig *runtime.RawExtension) func(rt imv1.Runtime, shoot *gardener.Shoot) error {
+func NewProviderExtenderPatchOperation(providerConfig config.ProviderConfig, defMachineImgName, defMachineImgVer string, sshAccessEnabled bool, shootWorkers []gardener.Worker, existingInfraConfig, existingControlPlaneConfig *runtime.RawExtension) func(rt imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(rt imv1.Runtime, shoot *gardener.Shoot) error {
 		provider := &shoot.Spec.Provider
 		provider.Type = rt.Spec.Shoot.Provider.Type
 		provider.Workers = rt.Spec.Shoot.Provider.Workers
 
-		if existingInfraConfig == nil {
+		// provider-local shoots have no infrastructure config
+		if existingInfraConfig == nil && provider.Type != hyperscaler.TypeLocal {
 			return errors.New("existing infrastructure config is required")
 		}
 
@@ -81,18 +85,37 @@ func NewProviderExtenderPatchOperation(enableIMDSv2 bool, defMachineImgName, def
 		workerZonesFromShoot := getNetworkingZonesFromWorkers(shootWorkers)
 
 		zonesAdded := newZonesAdded(workerZonesFromShoot, workerZonesFromRuntime)
-		azureLiteCluster, err := isAzureLiteSetup(rt.Spec.Shoot.Provider.Type, existingInfraConfig.Raw)
+		azureLiteCluster, err := isAzureLiteSetup(rt.Spec.Shoot.Provider.Type, existingInfraConfig)
 		if err != nil {
 			return err
 		}
 
-		if len(zonesAdded) == 0 || azureLiteCluster {
+		switch {
+		case provider.Type == hyperscaler.TypeOpenStack:
+			// OpenStack configs don't depend on zones, values of existing shoots are changed only when requested on the Runtime CR
+			infraConfig, controlPlaneConfig, err := getOpenStackConfigForPatch(rt.Spec.Shoot.Provider.OpenStack, existingInfraConfig, existingControlPlaneConfig)
+			if err != nil {
+				return err
+			}
+
+			provider.ControlPlaneConfig = controlPlaneConfig
+			provider.InfrastructureConfig = infraConfig
+		case provider.Type == hyperscaler.TypeGCP:
+			// GCP configs don't depend on added zones, values of existing shoots are changed only when requested on the Runtime CR
+			infraConfig, controlPlaneConfig, err := getGCPConfigForPatch(rt.Spec.Shoot.Provider.GCP, workerZonesFromRuntime, existingInfraConfig, existingControlPlaneConfig)
+			if err != nil {
+				return err
+			}
+
+			provider.ControlPlaneConfig = controlPlaneConfig
+			provider.InfrastructureConfig = infraConfig
+		case len(zonesAdded) == 0 || azureLiteCluster || existingInfraConfig == nil:
 			provider.ControlPlaneConfig = existingControlPlaneConfig
 			provider.InfrastructureConfig = existingInfraConfig
-		} else {
+		default:
 			mergedWorkerZones := append(workerZonesFromShoot, zonesAdded...)
 
-			infraConfig, controlPlaneConfig, err := getConfig(rt.Spec.Shoot, mergedWorkerZones, existingInfraConfig.Raw)
+			infraConfig, controlPlaneConfig, err := getConfig(rt, providerConfig, mergedWorkerZones, existingInfraConfig.Raw)
 			if err != nil {
 				return err
 			}
@@ -103,23 +126,23 @@ func NewProviderExtenderPatchOperation(enableIMDSv2 bool, defMachineImgName, def
 
 		setMachineImage(provider, defMachineImgName, defMachineImgVer)
 
-		if err := setWorkerConfig(provider, provider.Type, enableIMDSv2); err != nil {
+		if err := setWorkerConfig(provider, rt.Spec.Shoot.Provider.WorkersTuning, providerConfig.AWS.EnableIMDSv2); err != nil {
 			return err
 		}
 
-		setWorkerSettings(provider)
+		setWorkerSettings(provider, sshAccessEnabled)
 		alignWorkersWithGardener(provider, shootWorkers)
 
 		return nil
 	}
 }
 
-func isAzureLiteSetup(providerType string, infraConfigBytes []byte) (bool, error) {
-	if providerType != hyperscaler.TypeAzure {
+func isAzureLiteSetup(providerType string, existingInfraConfig *runtime.RawExtension) (bool, error) {
+	if providerType != hyperscaler.TypeAzure || existingInfraConfig == nil {
 		return false, nil
 	}
 
-	infraConfig, err := azure.DecodeInfrastructureConfig(infraConfigBytes)
+	infraConfig, err := azure.DecodeInfrastructureConfig(existingInfraConfig.Raw)
 
 	if err != nil {
 		return false, err
@@ -166,10 +189,32 @@ func sortWorkersToShootOrder(runtimeWorkers []gardener.Worker, shootWorkers []ga
 	return sortedWorkers
 }
 
+// Subnet layout set on the Runtime CR takes precedence over the default layout configured for the broker plan
+func getSubnetLayout(rt imv1.Runtime, providerConfig config.ProviderConfig) networking.SubnetLayout {
+	if layout := rt.Spec.Shoot.Networking.SubnetLayout; layout != nil {
+		return networking.SubnetLayout{
+			Strategy:          layout.Strategy,
+			ZonePrefixLengths: layout.ZonePrefixLengths,
+		}
+	}
+
+	if layout, found := providerConfig.SubnetLayouts[rt.Labels[imv1.LabelKymaBrokerPlanName]]; found {
+		return networking.SubnetLayout{
+			Strategy:          layout.Strategy,
+			ZonePrefixLengths: layout.ZonePrefixLengths,
+		}
+	}
+
+	return networking.DefaultSubnetLayout()
+}
+
 type InfrastructureProviderFunc func(workersCidr string, zones []string) ([]byte, error)
 type ControlPlaneProviderFunc func(zones []string) ([]byte, error)
 
-func getConfig(runtimeShoot imv1.RuntimeShoot, zones []string, existingInfrastructureConfig []byte) (infrastructureConfig *runtime.RawExtension, controlPlaneConfig *runtime.RawExtension, err error) {
+func getConfig(rt imv1.Runtime, providerConfig config.ProviderConfig, zones []string, existingInfrastructureConfig []byte) (infrastructureConfig *runtime.RawExtension, controlPlaneConfig *runtime.RawExtension, err error) {
+	runtimeShoot := rt.Spec.Shoot
+	subnetLayout := getSubnetLayout(rt, providerConfig)
+
 	getConfigForProvider := func(runtimeShoot imv1.RuntimeShoot, infrastructureConfigFunc InfrastructureProviderFunc, controlPlaneConfigFunc ControlPlaneProviderFunc) (*runtime.RawExtension, *runtime.RawExtension, error) {
 		infrastructureConfigBytes, err := infrastructureConfigFunc(runtimeShoot.Networking.Nodes, zones)
 		if err != nil {
@@ -189,43 +234,62 @@ func getConfig(runtimeShoot imv1.RuntimeShoot, zones []string, existingInfrastru
 		{
 			if existingInfrastructureConfig != nil {
 				return getConfigForProvider(runtimeShoot, func(workersCidr string, zones []string) ([]byte, error) {
-					return aws.GetInfrastructureConfigForPatch(workersCidr, zones, existingInfrastructureConfig)
+					return aws.GetInfrastructureConfigForPatch(workersCidr, zones, subnetLayout, existingInfrastructureConfig)
 				}, aws.GetControlPlaneConfig)
 			}
-			return getConfigForProvider(runtimeShoot, aws.GetInfrastructureConfig, aws.GetControlPlaneConfig)
+			return getConfigForProvider(runtimeShoot, func(workersCidr string, zones []string) ([]byte, error) {
+				return aws.GetInfrastructureConfig(workersCidr, zones, subnetLayout)
+			}, aws.GetControlPlaneConfig)
 		}
 	case hyperscaler.TypeAzure:
 		{
 			if existingInfrastructureConfig != nil {
 				return getConfigForProvider(runtimeShoot, func(workersCidr string, zones []string) ([]byte, error) {
-					return azure.GetInfrastructureConfigForPatch(workersCidr, zones, existingInfrastructureConfig)
+					return azure.GetInfrastructureConfigForPatch(workersCidr, zones, subnetLayout, existingInfrastructureConfig)
 				}, azure.GetControlPlaneConfig)
 			}
 			// Azure shoots are all zoned, put probably it not be validated here.
-			return getConfigForProvider(runtimeShoot, azure.GetInfrastructureConfig, azure.GetControlPlaneConfig)
+			return getConfigForProvider(runtimeShoot, func(workersCidr string, zones []string) ([]byte, error) {
+				return azure.GetInfrastructureConfig(workersCidr, zones, subnetLayout)
+			}, azure.GetControlPlaneConfig)
 		}
 	case hyperscaler.TypeGCP:
 		{
-			return getConfigForProvider(runtimeShoot, gcp.GetInfrastructureConfig, gcp.GetControlPlaneConfig)
+			settings := getGCPSettings(rt.Spec.Shoot.Provider.GCP)
+			return getConfigForProvider(runtimeShoot, func(workersCidr string, _ []string) ([]byte, error) {
+				return gcp.GetInfrastructureConfig(workersCidr, settings)
+			}, func(zones []string) ([]byte, error) {
+				return gcp.GetControlPlaneConfig(zones, settings)
+			})
 		}
 	case hyperscaler.TypeOpenStack:
 		{
-			return getConfigForProvider(runtimeShoot, openstack.GetInfrastructureConfig, openstack.GetControlPlaneConfig)
+			settings := getOpenStackSettings(rt, providerConfig)
+			return getConfigForProvider(runtimeShoot, func(workersCidr string, _ []string) ([]byte, error) {
+				return openstack.GetInfrastructureConfig(workersCidr, settings)
+			}, func(_ []string) ([]byte, error) {
+				return openstack.GetControlPlaneConfig(settings)
+			})
+		}
+	case hyperscaler.TypeAlicloud:
+		{
+			if existingInfrastructureConfig != nil {
+				return getConfigForProvider(runtimeShoot, func(workersCidr string, zones []string) ([]byte, error) {
+					return alicloud.GetInfrastructureConfigForPatch(workersCidr, zones, existingInfrastructureConfig)
+				}, alicloud.GetControlPlaneConfig)
+			}
+			return getConfigForProvider(runtimeShoot, alicloud.GetInfrastructureConfig, alicloud.GetControlPlaneConfig)
+		}
+	case hyperscaler.TypeLocal:
+		{
+			// provider-local does not define InfrastructureConfig and ControlPlaneConfig APIs
+			return nil, nil, nil
 		}
 	default:
 		return nil, nil, errors.New("provider not supported")
 	}
 }
 
-func getAWSWorkerConfig() (*runtime.RawExtension, error) {
-	workerConfigBytes, err := aws.GetWorkerConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	return &runtime.RawExtension{Raw: workerConfigBytes}, nil
-}
-
 func getNetworkingZonesFromWorkers(workers []gardener.Worker) []string {
 	var zones []string
 
@@ -240,27 +304,11 @@ func getNetworkingZonesFromWorkers(workers []gardener.Worker) []string {
 	return zones
 }
 
-func setWorkerConfig(provider *gardener.Provider, providerType string, enableIMDSv2 bool) error {
-	if providerType != hyperscaler.TypeAWS || !enableIMDSv2 {
-		return nil
-	}
-
-	for i := 0; i < len(provider.Workers); i++ {
-		var err error
-		provider.Workers[i].ProviderConfig, err = getAWSWorkerConfig()
-
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-func setWorkerSettings(provider *gardener.Provider) {
+// SSH access to the worker nodes is disabled unless a time-boxed access is requested in the Runtime CR
+func setWorkerSettings(provider *gardener.Provider, sshAccessEnabled bool) {
 	provider.WorkersSettings = &gardener.WorkersSettings{
 		SSHAccess: &gardener.SSHAccess{
-			Enabled: false,
+			Enabled: sshAccessEnabled,
 		},
 	}
 }
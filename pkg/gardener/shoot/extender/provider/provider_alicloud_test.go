@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"encoding/json"
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/alicloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"testing"
+)
+
+func TestProviderExtenderForCreateAlicloud(t *testing.T) {
+	// tests of NewProviderExtenderForCreateOperation for workers create operation
+	for tname, tc := range map[string]struct {
+		Runtime                    imv1.Runtime
+		DefaultMachineImageVersion string
+		DefaultMachineImageName    string
+		ExpectedShootWorkers       []gardener.Worker
+		ExpectedZones              []alicloud.Zone
+	}{
+		"Create single Alicloud worker": {
+			Runtime: imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Provider: fixProviderWithMultipleWorkers(hyperscaler.TypeAlicloud, fixMultipleWorkers([]workerConfig{
+							{"main-worker", "ecs.g7.large", "gardenlinux", "1310.4.0", 1, 3, []string{"cn-beijing-a", "cn-beijing-b", "cn-beijing-c"}},
+						})),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/22",
+						},
+					},
+				},
+			},
+			DefaultMachineImageName:    "gardenlinux",
+			DefaultMachineImageVersion: "1312.3.0",
+			ExpectedShootWorkers: fixMultipleWorkers([]workerConfig{
+				{"main-worker", "ecs.g7.large", "gardenlinux", "1310.4.0", 1, 3, []string{"cn-beijing-a", "cn-beijing-b", "cn-beijing-c"}},
+			}),
+			ExpectedZones: []alicloud.Zone{
+				{Name: "cn-beijing-a", Workers: "10.250.0.0/25"},
+				{Name: "cn-beijing-b", Workers: "10.250.0.128/25"},
+				{Name: "cn-beijing-c", Workers: "10.250.1.0/25"},
+			},
+		},
+		"Create multiple Alicloud workers": {
+			Runtime: imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Provider: fixProviderWithMultipleWorkers(hyperscaler.TypeAlicloud, fixMultipleWorkers([]workerConfig{
+							{"main-worker", "ecs.g7.large", "gardenlinux", "1310.4.0", 1, 3, []string{"cn-beijing-a"}},
+							{"additional", "ecs.g7.xlarge", "gardenlinux", "1311.2.0", 2, 4, []string{"cn-beijing-b", "cn-beijing-c"}},
+						})),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/22",
+						},
+					},
+				},
+			},
+			DefaultMachineImageName:    "gardenlinux",
+			DefaultMachineImageVersion: "1312.3.0",
+			ExpectedShootWorkers: fixMultipleWorkers([]workerConfig{
+				{"main-worker", "ecs.g7.large", "gardenlinux", "1310.4.0", 1, 3, []string{"cn-beijing-a"}},
+				{"additional", "ecs.g7.xlarge", "gardenlinux", "1311.2.0", 2, 4, []string{"cn-beijing-b", "cn-beijing-c"}},
+			}),
+			ExpectedZones: []alicloud.Zone{
+				{Name: "cn-beijing-a", Workers: "10.250.0.0/25"},
+				{Name: "cn-beijing-b", Workers: "10.250.0.128/25"},
+				{Name: "cn-beijing-c", Workers: "10.250.1.0/25"},
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+
+			// when
+			extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false)
+			err := extender(tc.Runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+
+			assertProviderMultipleWorkers(t, tc.Runtime.Spec.Shoot, shoot, false, tc.ExpectedShootWorkers)
+			assertProviderSpecificConfigAlicloud(t, shoot, tc.Runtime.Spec.Shoot.Networking.Nodes, tc.ExpectedZones)
+		})
+	}
+}
+
+func TestProviderExtenderForPatchWorkersUpdateAlicloud(t *testing.T) {
+	// tests of NewProviderExtenderPatch for workers update operation for Alicloud provider
+	for tname, tc := range map[string]struct {
+		Runtime                    imv1.Runtime
+		DefaultMachineImageVersion string
+		DefaultMachineImageName    string
+		CurrentShootWorkers        []gardener.Worker
+		ExistingInfraConfig        *runtime.RawExtension
+		ExistingControlPlaneConfig *runtime.RawExtension
+		ExpectedShootWorkers       []gardener.Worker
+		ExpectedZones              []alicloud.Zone
+	}{
+		"Add additional worker": {
+			Runtime: imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Provider: fixProviderWithMultipleWorkers(hyperscaler.TypeAlicloud, fixMultipleWorkers([]workerConfig{
+							{"main-worker", "ecs.g7.large", "gardenlinux", "1312.4.0", 1, 3, []string{"cn-beijing-a"}},
+							{"next-worker", "ecs.g7.xlarge", "gardenlinux", "1312.2.0", 1, 3, []string{"cn-beijing-a"}},
+						})),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/22",
+						},
+					},
+				},
+			},
+			DefaultMachineImageName:    "gardenlinux",
+			DefaultMachineImageVersion: "1312.3.0",
+			CurrentShootWorkers:        fixWorkers("main-worker", "ecs.g7.large", "gardenlinux", "1312.4.0", 1, 3, []string{"cn-beijing-a"}),
+			ExpectedShootWorkers: fixMultipleWorkers([]workerConfig{
+				{"main-worker", "ecs.g7.large", "gardenlinux", "1312.4.0", 1, 3, []string{"cn-beijing-a"}},
+				{"next-worker", "ecs.g7.xlarge", "gardenlinux", "1312.2.0", 1, 3, []string{"cn-beijing-a"}}}),
+			ExistingInfraConfig:        fixAlicloudInfrastructureConfig(t, "10.250.0.0/22", []string{"cn-beijing-a"}),
+			ExistingControlPlaneConfig: fixAlicloudControlPlaneConfig(),
+			ExpectedZones: []alicloud.Zone{
+				{Name: "cn-beijing-a", Workers: "10.250.0.0/25"},
+			},
+		},
+		"Add additional worker with new zones": {
+			Runtime: imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Provider: fixProviderWithMultipleWorkers(hyperscaler.TypeAlicloud, fixMultipleWorkers([]workerConfig{
+							{"main-worker", "ecs.g7.large", "gardenlinux", "1312.4.0", 1, 3, []string{"cn-beijing-c"}},
+							{"next-worker", "ecs.g7.xlarge", "gardenlinux", "1312.2.0", 1, 3, []string{"cn-beijing-a", "cn-beijing-b"}},
+						})),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/22",
+						},
+					},
+				},
+			},
+			DefaultMachineImageName:    "gardenlinux",
+			DefaultMachineImageVersion: "1312.3.0",
+			CurrentShootWorkers:        fixWorkers("main-worker", "ecs.g7.large", "gardenlinux", "1312.4.0", 1, 3, []string{"cn-beijing-c"}),
+			ExpectedShootWorkers: fixMultipleWorkers([]workerConfig{
+				{"main-worker", "ecs.g7.large", "gardenlinux", "1312.4.0", 1, 3, []string{"cn-beijing-c"}},
+				{"next-worker", "ecs.g7.xlarge", "gardenlinux", "1312.2.0", 1, 3, []string{"cn-beijing-a", "cn-beijing-b"}}}),
+			ExistingInfraConfig:        fixAlicloudInfrastructureConfig(t, "10.250.0.0/22", []string{"cn-beijing-c"}),
+			ExistingControlPlaneConfig: fixAlicloudControlPlaneConfig(),
+			ExpectedZones: []alicloud.Zone{
+				{Name: "cn-beijing-c", Workers: "10.250.0.0/25"},
+				{Name: "cn-beijing-a", Workers: "10.250.0.128/25"},
+				{Name: "cn-beijing-b", Workers: "10.250.1.0/25"},
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+
+			// when
+			extender := NewProviderExtenderPatchOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
+			err := extender(tc.Runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+
+			assertProviderMultipleWorkers(t, tc.Runtime.Spec.Shoot, shoot, false, tc.ExpectedShootWorkers)
+			assertProviderSpecificConfigAlicloud(t, shoot, tc.Runtime.Spec.Shoot.Networking.Nodes, tc.ExpectedZones)
+		})
+	}
+}
+
+func fixAlicloudInfrastructureConfig(t *testing.T, workersCIDR string, zones []string) *runtime.RawExtension {
+	infraConfig, err := alicloud.GetInfrastructureConfig(workersCIDR, zones)
+	require.NoError(t, err)
+
+	return &runtime.RawExtension{Raw: infraConfig}
+}
+
+func fixAlicloudControlPlaneConfig() *runtime.RawExtension {
+	controlPlaneConfig, _ := alicloud.GetControlPlaneConfig([]string{})
+	return &runtime.RawExtension{Raw: controlPlaneConfig}
+}
+
+func assertProviderSpecificConfigAlicloud(t *testing.T, shoot gardener.Shoot, expectedVpcCIDR string, expectedZones []alicloud.Zone) {
+	var ctrlPlaneConfig alicloud.ControlPlaneConfig
+	var infraConfig alicloud.InfrastructureConfig
+
+	err := json.Unmarshal(shoot.Spec.Provider.ControlPlaneConfig.Raw, &ctrlPlaneConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "alicloud.provider.extensions.gardener.cloud/v1alpha1", ctrlPlaneConfig.APIVersion)
+
+	err = json.Unmarshal(shoot.Spec.Provider.InfrastructureConfig.Raw, &infraConfig)
+	require.NoError(t, err)
+	require.NotNil(t, infraConfig.Networks.VPC.CIDR)
+	assert.Equal(t, expectedVpcCIDR, *infraConfig.Networks.VPC.CIDR)
+	assert.Equal(t, expectedZones, infraConfig.Networks.Zones)
+}
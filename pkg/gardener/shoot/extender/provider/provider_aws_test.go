@@ -5,11 +5,14 @@ import (
 	awsext "github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/aws"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	"testing"
@@ -101,7 +104,7 @@ func TestProviderExtenderForCreateAWS(t *testing.T) {
 
 			// when
 
-			extender := NewProviderExtenderForCreateOperation(tc.EnableIMDSv2, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion)
+			extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{AWS: config.AWSConfig{EnableIMDSv2: tc.EnableIMDSv2}}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -263,7 +266,7 @@ func TestProviderExtenderForPatchSingleWorkerAWS(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderPatchOperation(tc.EnableIMDSv2, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
+			extender := NewProviderExtenderPatchOperation(config.ProviderConfig{AWS: config.AWSConfig{EnableIMDSv2: tc.EnableIMDSv2}}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -275,8 +278,77 @@ func TestProviderExtenderForPatchSingleWorkerAWS(t *testing.T) {
 	}
 }
 
+func TestProviderExtenderSubnetLayoutAWS(t *testing.T) {
+	providerConfig := config.ProviderConfig{
+		SubnetLayouts: map[string]config.SubnetLayoutConfig{
+			"aws": {Strategy: networking.SubnetLayoutUniform},
+		},
+	}
+
+	for tname, tc := range map[string]struct {
+		PlanName             string
+		SubnetLayout         *imv1.SubnetLayout
+		ExpectedWorkersCIDRs []string
+	}{
+		"Use kyma-weighted layout when no layout is configured for the plan": {
+			PlanName:             "azure",
+			ExpectedWorkersCIDRs: []string{"10.250.0.0/19", "10.250.64.0/19", "10.250.128.0/19"},
+		},
+		"Use layout configured for the plan": {
+			PlanName:             "aws",
+			ExpectedWorkersCIDRs: []string{"10.250.0.0/20", "10.250.32.0/20", "10.250.64.0/20"},
+		},
+		"Use layout from the Runtime CR over the one configured for the plan": {
+			PlanName: "aws",
+			SubnetLayout: &imv1.SubnetLayout{
+				Strategy:          networking.SubnetLayoutCustom,
+				ZonePrefixLengths: []int{18, 22, 22},
+			},
+			ExpectedWorkersCIDRs: []string{"10.250.0.0/18", "10.250.128.0/22", "10.250.136.0/22"},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+			runtime := imv1.Runtime{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						imv1.LabelKymaBrokerPlanName: tc.PlanName,
+					},
+				},
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Provider: fixProvider(hyperscaler.TypeAWS, "gardenlinux", "1312.2.0", []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"}),
+						Networking: imv1.Networking{
+							Nodes:        "10.250.0.0/16",
+							SubnetLayout: tc.SubnetLayout,
+						},
+					},
+				},
+			}
+
+			// when
+			extender := NewProviderExtenderForCreateOperation(providerConfig, "gardenlinux", "1312.2.0", false)
+			err := extender(runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+
+			var infrastructureConfig awsext.InfrastructureConfig
+			err = json.Unmarshal(shoot.Spec.Provider.InfrastructureConfig.Raw, &infrastructureConfig)
+			require.NoError(t, err)
+
+			var workersCIDRs []string
+			for _, zone := range infrastructureConfig.Networks.Zones {
+				workersCIDRs = append(workersCIDRs, zone.Workers)
+			}
+			assert.Equal(t, tc.ExpectedWorkersCIDRs, workersCIDRs)
+		})
+	}
+}
+
 func fixAWSInfrastructureConfig(t *testing.T, workersCIDR string, zones []string) *runtime.RawExtension {
-	infraConfig, err := aws.NewInfrastructureConfig(workersCIDR, zones)
+	infraConfig, err := aws.NewInfrastructureConfig(workersCIDR, zones, networking.DefaultSubnetLayout())
 
 	assert.NoError(t, err)
 
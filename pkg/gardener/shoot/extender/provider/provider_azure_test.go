@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/azure"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -81,7 +83,7 @@ func TestProviderExtenderForCreateAzure(t *testing.T) {
 
 			// when
 
-			extender := NewProviderExtenderForCreateOperation(false, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion)
+			extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -336,7 +338,7 @@ func TestProviderExtenderForPatchWorkersUpdateAzure(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderPatchOperation(false, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
+			extender := NewProviderExtenderPatchOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -411,7 +413,7 @@ func TestProviderExtenderForCreateMultipleWorkersAzure(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderForCreateOperation(false, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion)
+			extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -424,7 +426,7 @@ func TestProviderExtenderForCreateMultipleWorkersAzure(t *testing.T) {
 }
 
 func fixAzureInfrastructureConfig(t *testing.T, workersCIDR string, zones []string) *runtime.RawExtension {
-	infraConfig, err := azure.NewInfrastructureConfig(workersCIDR, zones)
+	infraConfig, err := azure.NewInfrastructureConfig(workersCIDR, zones, networking.DefaultSubnetLayout())
 
 	require.NoError(t, err)
 
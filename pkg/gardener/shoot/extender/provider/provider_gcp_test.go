@@ -5,12 +5,14 @@ import (
 	gcpext "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/gcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"testing"
 )
 
@@ -77,7 +79,7 @@ func TestProviderExtenderForCreateGCP(t *testing.T) {
 
 			// when
 
-			extender := NewProviderExtenderForCreateOperation(false, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion)
+			extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -323,7 +325,7 @@ func TestProviderExtenderForPatchWorkersUpdateGCP(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderPatchOperation(false, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
+			extender := NewProviderExtenderPatchOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -374,7 +376,7 @@ func TestProviderExtenderForCreateMultipleWorkersGCP(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderForCreateOperation(false, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion)
+			extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -386,13 +388,121 @@ func TestProviderExtenderForCreateMultipleWorkersGCP(t *testing.T) {
 	}
 }
 
+func TestProviderExtenderGCPSettings(t *testing.T) {
+	fixRuntime := func(gcpSettings *imv1.GCPSettings) imv1.Runtime {
+		runtime := imv1.Runtime{
+			Spec: imv1.RuntimeSpec{
+				Shoot: imv1.RuntimeShoot{
+					Provider: fixProviderWithMultipleWorkers(hyperscaler.TypeGCP, fixMultipleWorkers([]workerConfig{
+						{"main-worker", "n2-standard-2", "gardenlinux", "1312.4.0", 1, 3, []string{"us-central1-a", "us-central1-b", "us-central1-c"}},
+					})),
+					Networking: imv1.Networking{
+						Nodes: "10.250.0.0/22",
+					},
+				},
+			},
+		}
+		runtime.Spec.Shoot.Provider.GCP = gcpSettings
+		return runtime
+	}
+
+	t.Run("Create shoot with control plane zone and CloudNAT settings", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+		rt := fixRuntime(&imv1.GCPSettings{
+			ControlPlaneZone: "us-central1-b",
+			CloudNAT: &imv1.GCPCloudNAT{
+				MinPortsPerVM: ptr.To(int32(4096)),
+				NatIPNames:    []string{"nat-ip"},
+			},
+		})
+
+		// when
+		err := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", false)(rt, &shoot)
+
+		// then
+		require.NoError(t, err)
+
+		var ctrlPlaneConfig gcpext.ControlPlaneConfig
+		require.NoError(t, json.Unmarshal(shoot.Spec.Provider.ControlPlaneConfig.Raw, &ctrlPlaneConfig))
+		assert.Equal(t, "us-central1-b", ctrlPlaneConfig.Zone)
+
+		var infraConfig gcpext.InfrastructureConfig
+		require.NoError(t, json.Unmarshal(shoot.Spec.Provider.InfrastructureConfig.Raw, &infraConfig))
+		assert.Equal(t, int32(4096), *infraConfig.Networks.CloudNAT.MinPortsPerVM)
+		assert.Equal(t, []gcpext.NatIPName{{Name: "nat-ip"}}, infraConfig.Networks.CloudNAT.NatIPNames)
+	})
+
+	t.Run("Fail to create shoot with control plane zone outside of worker zones", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+		rt := fixRuntime(&imv1.GCPSettings{ControlPlaneZone: "us-central1-f"})
+
+		// when
+		err := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", false)(rt, &shoot)
+
+		// then
+		assert.EqualError(t, err, "control plane zone us-central1-f is not one of the worker zones [us-central1-a us-central1-b us-central1-c]")
+	})
+
+	t.Run("Keep existing configs on patch when zones are added", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+		rt := fixRuntime(nil)
+		existingInfraConfig := &runtime.RawExtension{Raw: []byte(`{"apiVersion":"gcp.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","networks":{"worker":"10.250.0.0/22","workers":"10.250.0.0/22","cloudNAT":{"minPortsPerVM":2048}}}`)}
+		existingControlPlaneConfig := fixGCPControlPlaneConfig([]string{"us-central1-c"})
+		shootWorkers := fixWorkers("main-worker", "n2-standard-2", "gardenlinux", "1312.4.0", 1, 3, []string{"us-central1-c"})
+
+		// when
+		err := NewProviderExtenderPatchOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", false, shootWorkers, existingInfraConfig, existingControlPlaneConfig)(rt, &shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, existingInfraConfig, shoot.Spec.Provider.InfrastructureConfig)
+		assert.Equal(t, existingControlPlaneConfig, shoot.Spec.Provider.ControlPlaneConfig)
+	})
+
+	t.Run("Apply CloudNAT settings on patch", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+		rt := fixRuntime(&imv1.GCPSettings{
+			ControlPlaneZone: "us-central1-a",
+			CloudNAT:         &imv1.GCPCloudNAT{EndpointIndependentMapping: ptr.To(true)},
+		})
+		shootWorkers := fixWorkers("main-worker", "n2-standard-2", "gardenlinux", "1312.4.0", 1, 3, []string{"us-central1-a", "us-central1-b", "us-central1-c"})
+
+		// when
+		err := NewProviderExtenderPatchOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", false, shootWorkers, fixGCPInfrastructureConfig("10.250.0.0/22"), fixGCPControlPlaneConfig([]string{"us-central1-a"}))(rt, &shoot)
+
+		// then
+		require.NoError(t, err)
+
+		var infraConfig gcpext.InfrastructureConfig
+		require.NoError(t, json.Unmarshal(shoot.Spec.Provider.InfrastructureConfig.Raw, &infraConfig))
+		assert.True(t, infraConfig.Networks.CloudNAT.EndpointIndependentMapping.Enabled)
+	})
+
+	t.Run("Fail to change control plane zone on patch", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+		rt := fixRuntime(&imv1.GCPSettings{ControlPlaneZone: "us-central1-b"})
+		shootWorkers := fixWorkers("main-worker", "n2-standard-2", "gardenlinux", "1312.4.0", 1, 3, []string{"us-central1-a", "us-central1-b", "us-central1-c"})
+
+		// when
+		err := NewProviderExtenderPatchOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", false, shootWorkers, fixGCPInfrastructureConfig("10.250.0.0/22"), fixGCPControlPlaneConfig([]string{"us-central1-a"}))(rt, &shoot)
+
+		// then
+		assert.EqualError(t, err, "control plane zone cannot be changed from us-central1-a to us-central1-b")
+	})
+}
+
 func fixGCPInfrastructureConfig(workersCIDR string) *runtime.RawExtension {
-	infraConfig, _ := gcp.GetInfrastructureConfig(workersCIDR, []string{})
+	infraConfig, _ := gcp.GetInfrastructureConfig(workersCIDR, gcp.Settings{})
 	return &runtime.RawExtension{Raw: infraConfig}
 }
 
 func fixGCPControlPlaneConfig(zones []string) *runtime.RawExtension {
-	controlPlaneConfig, _ := gcp.GetControlPlaneConfig(zones)
+	controlPlaneConfig, _ := gcp.GetControlPlaneConfig(zones, gcp.Settings{})
 	return &runtime.RawExtension{Raw: controlPlaneConfig}
 }
 
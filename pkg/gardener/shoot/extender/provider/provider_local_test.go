@@ -0,0 +1,75 @@
+package provider
+
+import (
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProviderExtenderLocal(t *testing.T) {
+	runtime := imv1.Runtime{
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{
+				Provider: fixProviderWithMultipleWorkers(hyperscaler.TypeLocal, fixMultipleWorkers([]workerConfig{
+					{"main-worker", "local", "local", "1.0.0", 1, 3, []string{"0"}},
+					{"additional", "local", "local", "1.0.0", 1, 1, []string{"0", "1"}},
+				})),
+				Networking: imv1.Networking{
+					Nodes: "10.10.0.0/16",
+				},
+			},
+		},
+	}
+
+	t.Run("Create provider config for local provider without infrastructure and control plane config", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("cluster", "garden-local")
+
+		// when
+		extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", false)
+		err := extender(runtime, &shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, hyperscaler.TypeLocal, shoot.Spec.Provider.Type)
+		assert.Nil(t, shoot.Spec.Provider.InfrastructureConfig)
+		assert.Nil(t, shoot.Spec.Provider.ControlPlaneConfig)
+		assert.Len(t, shoot.Spec.Provider.Workers, 2)
+		assert.Equal(t, "local", shoot.Spec.Provider.Workers[0].Machine.Image.Name)
+	})
+
+	t.Run("Patch provider config for local provider when zones are added", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("cluster", "garden-local")
+		currentWorkers := fixWorkers("main-worker", "local", "local", "1.0.0", 1, 3, []string{"0"})
+
+		// when
+		extender := NewProviderExtenderPatchOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", false, currentWorkers, nil, nil)
+		err := extender(runtime, &shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.Provider.InfrastructureConfig)
+		assert.Nil(t, shoot.Spec.Provider.ControlPlaneConfig)
+		assert.Equal(t, currentWorkers[0].Zones, shoot.Spec.Provider.Workers[0].Zones)
+		assert.Equal(t, []string{"0", "1"}, shoot.Spec.Provider.Workers[1].Zones)
+	})
+
+	t.Run("Return error when infrastructure config is missing for non local provider", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+		awsRuntime := runtime.DeepCopy()
+		awsRuntime.Spec.Shoot.Provider.Type = hyperscaler.TypeAWS
+
+		// when
+		extender := NewProviderExtenderPatchOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", false, fixWorkers("main-worker", "local", "local", "1.0.0", 1, 3, []string{"0"}), nil, nil)
+		err := extender(*awsRuntime, &shoot)
+
+		// then
+		assert.ErrorContains(t, err, "existing infrastructure config is required")
+	})
+}
@@ -5,6 +5,7 @@ import (
 	ostext "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	ops "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/openstack"
@@ -75,7 +76,7 @@ func TestProviderExtenderForCreateOpenstack(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderForCreateOperation(false, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion)
+			extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -278,7 +279,7 @@ func TestProviderExtenderForPatchWorkersUpdateOpenstack(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderPatchOperation(false, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
+			extender := NewProviderExtenderPatchOperation(config.ProviderConfig{}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -290,13 +291,122 @@ func TestProviderExtenderForPatchWorkersUpdateOpenstack(t *testing.T) {
 	}
 }
 
+func TestProviderExtenderOpenstackSettings(t *testing.T) {
+	providerConfig := config.ProviderConfig{
+		OpenStack: config.OpenStackConfig{
+			Regions: map[string]config.OpenStackRegionConfig{
+				"eu-de-2": {
+					FloatingPoolName:     "FloatingIP-external-kyma-02",
+					LoadBalancerProvider: "octavia",
+				},
+			},
+		},
+	}
+
+	fixRuntime := func(region string, override *imv1.OpenStackSettings) imv1.Runtime {
+		runtime := imv1.Runtime{
+			Spec: imv1.RuntimeSpec{
+				Shoot: imv1.RuntimeShoot{
+					Region: region,
+					Provider: fixProviderWithMultipleWorkers(hyperscaler.TypeOpenStack, fixMultipleWorkers([]workerConfig{
+						{"main-worker", "openstack.small", "gardenlinux", "1312.4.0", 1, 3, []string{"eu-de-2a"}},
+					})),
+					Networking: imv1.Networking{
+						Nodes: "10.250.0.0/22",
+					},
+				},
+			},
+		}
+		runtime.Spec.Shoot.Provider.OpenStack = override
+		return runtime
+	}
+
+	for tname, tc := range map[string]struct {
+		Runtime                      imv1.Runtime
+		ExpectedFloatingPoolName     string
+		ExpectedLoadBalancerProvider string
+	}{
+		"Use defaults for region without settings": {
+			Runtime:                      fixRuntime("eu-de-1", nil),
+			ExpectedFloatingPoolName:     "FloatingIP-external-kyma-01",
+			ExpectedLoadBalancerProvider: "f5",
+		},
+		"Use region settings": {
+			Runtime:                      fixRuntime("eu-de-2", nil),
+			ExpectedFloatingPoolName:     "FloatingIP-external-kyma-02",
+			ExpectedLoadBalancerProvider: "octavia",
+		},
+		"Override region settings with values from the Runtime CR": {
+			Runtime:                      fixRuntime("eu-de-2", &imv1.OpenStackSettings{FloatingPoolName: "FloatingIP-external-custom"}),
+			ExpectedFloatingPoolName:     "FloatingIP-external-custom",
+			ExpectedLoadBalancerProvider: "octavia",
+		},
+	} {
+		t.Run("Create: "+tname, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+
+			// when
+			err := NewProviderExtenderForCreateOperation(providerConfig, "gardenlinux", "1312.3.0", false)(tc.Runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+			assertOpenstackSettings(t, shoot, tc.ExpectedFloatingPoolName, tc.ExpectedLoadBalancerProvider)
+		})
+	}
+
+	for tname, tc := range map[string]struct {
+		Runtime                      imv1.Runtime
+		ExpectedFloatingPoolName     string
+		ExpectedLoadBalancerProvider string
+	}{
+		"Keep existing values when region settings change": {
+			Runtime:                      fixRuntime("eu-de-2", nil),
+			ExpectedFloatingPoolName:     "FloatingIP-external-kyma-01",
+			ExpectedLoadBalancerProvider: "f5",
+		},
+		"Change values requested on the Runtime CR": {
+			Runtime:                      fixRuntime("eu-de-2", &imv1.OpenStackSettings{LoadBalancerProvider: "octavia"}),
+			ExpectedFloatingPoolName:     "FloatingIP-external-kyma-01",
+			ExpectedLoadBalancerProvider: "octavia",
+		},
+	} {
+		t.Run("Patch: "+tname, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+			shootWorkers := fixWorkers("main-worker", "openstack.small", "gardenlinux", "1312.4.0", 1, 3, []string{"eu-de-2b"})
+
+			// when
+			extender := NewProviderExtenderPatchOperation(providerConfig, "gardenlinux", "1312.3.0", false, shootWorkers, fixOpenstackInfrastructureConfig("10.250.0.0/22"), fixOpenstackControlPlaneConfig())
+			err := extender(tc.Runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+			assertOpenstackSettings(t, shoot, tc.ExpectedFloatingPoolName, tc.ExpectedLoadBalancerProvider)
+		})
+	}
+}
+
+func assertOpenstackSettings(t *testing.T, shoot gardener.Shoot, expectedFloatingPoolName, expectedLoadBalancerProvider string) {
+	var ctrlPlaneConfig ostext.ControlPlaneConfig
+	var infraConfig ostext.InfrastructureConfig
+
+	err := json.Unmarshal(shoot.Spec.Provider.ControlPlaneConfig.Raw, &ctrlPlaneConfig)
+	require.NoError(t, err)
+	assert.Equal(t, expectedLoadBalancerProvider, ctrlPlaneConfig.LoadBalancerProvider)
+
+	err = json.Unmarshal(shoot.Spec.Provider.InfrastructureConfig.Raw, &infraConfig)
+	require.NoError(t, err)
+	assert.Equal(t, expectedFloatingPoolName, infraConfig.FloatingPoolName)
+}
+
 func fixOpenstackInfrastructureConfig(workersCIDR string) *runtime.RawExtension {
-	infraConfig, _ := ops.GetInfrastructureConfig(workersCIDR, []string{})
+	infraConfig, _ := ops.GetInfrastructureConfig(workersCIDR, ops.Settings{})
 	return &runtime.RawExtension{Raw: infraConfig}
 }
 
 func fixOpenstackControlPlaneConfig() *runtime.RawExtension {
-	controlPlaneConfig, _ := ops.GetControlPlaneConfig([]string{})
+	controlPlaneConfig, _ := ops.GetControlPlaneConfig(ops.Settings{})
 	return &runtime.RawExtension{Raw: controlPlaneConfig}
 }
 
@@ -311,4 +421,5 @@ func assertProviderSpecificConfigOpenstack(t *testing.T, shoot gardener.Shoot, e
 	err = json.Unmarshal(shoot.Spec.Provider.InfrastructureConfig.Raw, &infraConfig)
 	require.NoError(t, err)
 	assert.Equal(t, expectedWorkersCIDR, infraConfig.Networks.Workers)
+	assert.Equal(t, "FloatingIP-external-kyma-01", infraConfig.FloatingPoolName)
 }
@@ -10,6 +10,7 @@ import (
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,7 +31,7 @@ func TestValidations(t *testing.T) {
 		}
 
 		// when
-		extender := NewProviderExtenderForCreateOperation(false, "", "")
+		extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, "", "", false)
 		err := extender(rt, &shoot)
 
 		// then
@@ -63,7 +64,7 @@ func TestFixAlignWorkerZonesWithGardener(t *testing.T) {
 		})
 
 		// when
-		extender := NewProviderExtenderPatchOperation(false, "gardenlinux", "1311.2.0", currentWorkers, fixAWSInfrastructureConfig(t, "10.250.0.0/22", []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"}), fixAWSControlPlaneConfig())
+		extender := NewProviderExtenderPatchOperation(config.ProviderConfig{}, "gardenlinux", "1311.2.0", false, currentWorkers, fixAWSInfrastructureConfig(t, "10.250.0.0/22", []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"}), fixAWSControlPlaneConfig())
 		err := extender(runtime, &shoot)
 
 		// then
@@ -204,7 +205,7 @@ func TestProviderExtenderForCreateMultipleWorkersAWS(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderForCreateOperation(tc.EnableIMDSv2, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion)
+			extender := NewProviderExtenderForCreateOperation(config.ProviderConfig{AWS: config.AWSConfig{EnableIMDSv2: tc.EnableIMDSv2}}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -496,7 +497,7 @@ func TestProviderExtenderForPatchWorkersUpdateAWS(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderPatchOperation(tc.EnableIMDSv2, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
+			extender := NewProviderExtenderPatchOperation(config.ProviderConfig{AWS: config.AWSConfig{EnableIMDSv2: tc.EnableIMDSv2}}, tc.DefaultMachineImageName, tc.DefaultMachineImageVersion, false, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -558,7 +559,7 @@ func TestProviderExtenderForPatchWorkersUpdateErrors(t *testing.T) {
 			shoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
 
 			// when
-			extender := NewProviderExtenderPatchOperation(false, "", "", tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
+			extender := NewProviderExtenderPatchOperation(config.ProviderConfig{}, "", "", false, tc.CurrentShootWorkers, tc.ExistingInfraConfig, tc.ExistingControlPlaneConfig)
 			err := extender(tc.Runtime, &shoot)
 
 			// then
@@ -747,3 +748,48 @@ func assertProviderMultipleWorkers(t *testing.T, runtimeShoot imv1.RuntimeShoot,
 		assert.Equal(t, *expected.Machine.Image.Version, *worker.Machine.Image.Version)
 	}
 }
+
+func TestProviderExtenderSSHAccess(t *testing.T) {
+	for tname, tc := range map[string]struct {
+		SSHAccessEnabled bool
+	}{
+		"Enable SSH access when it is active on the Runtime CR": {
+			SSHAccessEnabled: true,
+		},
+		"Disable SSH access when it is not active on the Runtime CR": {
+			SSHAccessEnabled: false,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			rt := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Provider: fixProviderWithMultipleWorkers(hyperscaler.TypeAWS, fixMultipleWorkers([]workerConfig{
+							{"main-worker", "m6i.large", "gardenlinux", "1310.4.0", 1, 3, []string{"eu-central-1a"}},
+						})),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/22",
+						},
+					},
+				},
+			}
+			createShoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+			patchShoot := testutils.FixEmptyGardenerShoot("cluster", "kcp-system")
+			shootWorkers := fixMultipleWorkers([]workerConfig{
+				{"main-worker", "m6i.large", "gardenlinux", "1310.4.0", 1, 3, []string{"eu-central-1a"}},
+			})
+
+			// when
+			createErr := NewProviderExtenderForCreateOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", tc.SSHAccessEnabled)(rt, &createShoot)
+			patchErr := NewProviderExtenderPatchOperation(config.ProviderConfig{}, "gardenlinux", "1312.3.0", tc.SSHAccessEnabled, shootWorkers,
+				fixAWSInfrastructureConfig(t, "10.250.0.0/22", []string{"eu-central-1a"}), fixAWSControlPlaneConfig())(rt, &patchShoot)
+
+			// then
+			require.NoError(t, createErr)
+			require.NoError(t, patchErr)
+			assert.Equal(t, tc.SSHAccessEnabled, createShoot.Spec.Provider.WorkersSettings.SSHAccess.Enabled)
+			assert.Equal(t, tc.SSHAccessEnabled, patchShoot.Spec.Provider.WorkersSettings.SSHAccess.Enabled)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"slices"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/aws"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/azure"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/gcp"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/workers"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Worker configs set on the Runtime CR are not replaced, IMDSv2 settings and the worker tuning are merged into them
+func setWorkerConfig(provider *gardener.Provider, tunings []imv1.WorkerTuning, enableIMDSv2 bool) error {
+	if err := verifyWorkerTunings(provider.Workers, tunings); err != nil {
+		return err
+	}
+
+	for i := range provider.Workers {
+		worker := &provider.Workers[i]
+
+		var existingWorkerConfigBytes []byte
+		if worker.ProviderConfig != nil {
+			existingWorkerConfigBytes = worker.ProviderConfig.Raw
+		}
+
+		workerConfigBytes, err := getWorkerConfig(provider.Type, existingWorkerConfigBytes, getWorkerTuning(worker.Name, tunings), enableIMDSv2)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create worker config for worker %s", worker.Name)
+		}
+
+		if workerConfigBytes != nil {
+			worker.ProviderConfig = &runtime.RawExtension{Raw: workerConfigBytes}
+		}
+	}
+
+	return nil
+}
+
+func getWorkerConfig(providerType string, existingWorkerConfigBytes []byte, tuning workers.Tuning, enableIMDSv2 bool) ([]byte, error) {
+	switch providerType {
+	case hyperscaler.TypeAWS:
+		if !enableIMDSv2 && tuning.IsEmpty() {
+			return nil, nil
+		}
+		return aws.GetWorkerConfig(existingWorkerConfigBytes, enableIMDSv2, tuning)
+	case hyperscaler.TypeAzure:
+		if tuning.IsEmpty() {
+			return nil, nil
+		}
+		return azure.GetWorkerConfig(existingWorkerConfigBytes, tuning)
+	case hyperscaler.TypeGCP:
+		if tuning.IsEmpty() {
+			return nil, nil
+		}
+		return gcp.GetWorkerConfig(existingWorkerConfigBytes, tuning)
+	default:
+		if !tuning.IsEmpty() {
+			return nil, errors.Errorf("worker tuning is not supported for provider %s", providerType)
+		}
+		return nil, nil
+	}
+}
+
+func verifyWorkerTunings(providerWorkers []gardener.Worker, tunings []imv1.WorkerTuning) error {
+	for _, tuning := range tunings {
+		index := slices.IndexFunc(providerWorkers, func(worker gardener.Worker) bool {
+			return worker.Name == tuning.WorkerName
+		})
+
+		if index == -1 {
+			return errors.Errorf("tuning settings refer to worker %s which does not exist", tuning.WorkerName)
+		}
+
+		for _, dataVolumeTuning := range tuning.DataVolumes {
+			if !slices.ContainsFunc(providerWorkers[index].DataVolumes, func(dataVolume gardener.DataVolume) bool {
+				return dataVolume.Name == dataVolumeTuning.Name
+			}) {
+				return errors.Errorf("tuning settings refer to data volume %s which is not defined for worker %s", dataVolumeTuning.Name, tuning.WorkerName)
+			}
+		}
+	}
+
+	return nil
+}
+
+func getWorkerTuning(workerName string, tunings []imv1.WorkerTuning) workers.Tuning {
+	index := slices.IndexFunc(tunings, func(tuning imv1.WorkerTuning) bool {
+		return tuning.WorkerName == workerName
+	})
+
+	if index == -1 {
+		return workers.Tuning{}
+	}
+
+	tuning := workers.Tuning{
+		LocalSSDInterface: tunings[index].LocalSSDInterface,
+	}
+
+	if volume := tunings[index].Volume; volume != nil {
+		tuning.Volume = &workers.VolumeTuning{
+			IOPS:       volume.IOPS,
+			Throughput: volume.Throughput,
+		}
+	}
+
+	for _, dataVolume := range tunings[index].DataVolumes {
+		tuning.DataVolumes = append(tuning.DataVolumes, workers.DataVolumeTuning{
+			Name: dataVolume.Name,
+			VolumeTuning: workers.VolumeTuning{
+				IOPS:       dataVolume.IOPS,
+				Throughput: dataVolume.Throughput,
+			},
+			SourceImage: dataVolume.SourceImage,
+		})
+	}
+
+	return tuning
+}
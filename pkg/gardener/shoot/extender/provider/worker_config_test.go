@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/azure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestSetWorkerConfig(t *testing.T) {
+	fixWorkers := func(workerConfig *runtime.RawExtension) []gardener.Worker {
+		return []gardener.Worker{
+			{
+				Name:           "main-worker",
+				ProviderConfig: workerConfig,
+			},
+			{
+				Name: "data-worker",
+				DataVolumes: []gardener.DataVolume{
+					{Name: "data", VolumeSize: "100Gi"},
+				},
+			},
+		}
+	}
+
+	t.Run("Keep worker config from the Runtime CR when IMDSv2 is enabled", func(t *testing.T) {
+		// given
+		provider := gardener.Provider{
+			Type:    hyperscaler.TypeAWS,
+			Workers: fixWorkers(&runtime.RawExtension{Raw: []byte(`{"volume":{"iops":3000,"throughput":250}}`)}),
+		}
+
+		// when
+		err := setWorkerConfig(&provider, nil, true)
+
+		// then
+		require.NoError(t, err)
+
+		var workerConfig v1alpha1.WorkerConfig
+		require.NoError(t, json.Unmarshal(provider.Workers[0].ProviderConfig.Raw, &workerConfig))
+		assert.Equal(t, int64(3000), *workerConfig.Volume.IOPS)
+		assert.Equal(t, int64(250), *workerConfig.Volume.Throughput)
+		assert.Equal(t, v1alpha1.HTTPTokensRequired, *workerConfig.InstanceMetadataOptions.HTTPTokens)
+
+		require.NoError(t, json.Unmarshal(provider.Workers[1].ProviderConfig.Raw, &workerConfig))
+		assert.Equal(t, v1alpha1.HTTPTokensRequired, *workerConfig.InstanceMetadataOptions.HTTPTokens)
+	})
+
+	t.Run("Apply tuning to the selected worker only", func(t *testing.T) {
+		// given
+		provider := gardener.Provider{
+			Type:    hyperscaler.TypeAWS,
+			Workers: fixWorkers(nil),
+		}
+		tunings := []imv1.WorkerTuning{
+			{
+				WorkerName: "data-worker",
+				DataVolumes: []imv1.DataVolumeTuning{
+					{Name: "data", VolumeTuning: imv1.VolumeTuning{IOPS: ptr.To(int64(6000))}},
+				},
+			},
+		}
+
+		// when
+		err := setWorkerConfig(&provider, tunings, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, provider.Workers[0].ProviderConfig)
+
+		var workerConfig v1alpha1.WorkerConfig
+		require.NoError(t, json.Unmarshal(provider.Workers[1].ProviderConfig.Raw, &workerConfig))
+		require.Len(t, workerConfig.DataVolumes, 1)
+		assert.Equal(t, int64(6000), *workerConfig.DataVolumes[0].IOPS)
+		assert.Nil(t, workerConfig.InstanceMetadataOptions)
+	})
+
+	t.Run("Keep Azure worker config untouched", func(t *testing.T) {
+		// given
+		azureWorkerConfig := &runtime.RawExtension{Raw: []byte(`{"dataVolumes":[{"name":"data","imageRef":{"urn":"image"}}]}`)}
+		provider := gardener.Provider{
+			Type:    hyperscaler.TypeAzure,
+			Workers: fixWorkers(azureWorkerConfig),
+		}
+
+		// when
+		err := setWorkerConfig(&provider, nil, true)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, azureWorkerConfig, provider.Workers[0].ProviderConfig)
+		assert.Nil(t, provider.Workers[1].ProviderConfig)
+	})
+
+	t.Run("Merge data volume tuning into Azure worker config", func(t *testing.T) {
+		// given
+		provider := gardener.Provider{
+			Type: hyperscaler.TypeAzure,
+			Workers: []gardener.Worker{
+				{
+					Name:           "data-worker",
+					ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"diagnosticsProfile":{"enabled":true},"dataVolumes":[{"name":"logs","imageRef":{"id":"logs-image"}}]}`)},
+					DataVolumes: []gardener.DataVolume{
+						{Name: "data", VolumeSize: "100Gi"},
+						{Name: "logs", VolumeSize: "50Gi"},
+					},
+				},
+			},
+		}
+		tunings := []imv1.WorkerTuning{
+			{
+				WorkerName: "data-worker",
+				DataVolumes: []imv1.DataVolumeTuning{
+					{Name: "data", SourceImage: ptr.To("publisher:offer:sku:1.0.0")},
+				},
+			},
+		}
+
+		// when
+		err := setWorkerConfig(&provider, tunings, true)
+
+		// then
+		require.NoError(t, err)
+
+		var workerConfig azure.WorkerConfig
+		require.NoError(t, json.Unmarshal(provider.Workers[0].ProviderConfig.Raw, &workerConfig))
+		assert.Equal(t, "WorkerConfig", workerConfig.Kind)
+		assert.Equal(t, "azure.provider.extensions.gardener.cloud/v1alpha1", workerConfig.APIVersion)
+		assert.True(t, workerConfig.DiagnosticsProfile.Enabled)
+		require.Len(t, workerConfig.DataVolumes, 2)
+		assert.Equal(t, "logs-image", *workerConfig.DataVolumes[0].ImageRef.ID)
+		assert.Equal(t, "data", workerConfig.DataVolumes[1].Name)
+		assert.Equal(t, "publisher:offer:sku:1.0.0", *workerConfig.DataVolumes[1].ImageRef.URN)
+	})
+
+	for tname, tc := range map[string]struct {
+		ProviderType  string
+		Tunings       []imv1.WorkerTuning
+		ExpectedError string
+	}{
+		"Reject tuning for unknown worker": {
+			ProviderType:  hyperscaler.TypeAWS,
+			Tunings:       []imv1.WorkerTuning{{WorkerName: "unknown"}},
+			ExpectedError: "tuning settings refer to worker unknown which does not exist",
+		},
+		"Reject tuning for unknown data volume": {
+			ProviderType: hyperscaler.TypeGCP,
+			Tunings: []imv1.WorkerTuning{
+				{WorkerName: "main-worker", DataVolumes: []imv1.DataVolumeTuning{{Name: "data"}}},
+			},
+			ExpectedError: "tuning settings refer to data volume data which is not defined for worker main-worker",
+		},
+		"Reject tuning not supported by the provider": {
+			ProviderType: hyperscaler.TypeOpenStack,
+			Tunings: []imv1.WorkerTuning{
+				{WorkerName: "data-worker", Volume: &imv1.VolumeTuning{IOPS: ptr.To(int64(3000))}},
+			},
+			ExpectedError: "failed to create worker config for worker data-worker: worker tuning is not supported for provider openstack",
+		},
+		"Reject tuning setting not supported on Azure": {
+			ProviderType: hyperscaler.TypeAzure,
+			Tunings: []imv1.WorkerTuning{
+				{WorkerName: "data-worker", DataVolumes: []imv1.DataVolumeTuning{{Name: "data", VolumeTuning: imv1.VolumeTuning{IOPS: ptr.To(int64(3000))}}}},
+			},
+			ExpectedError: "failed to create worker config for worker data-worker: IOPS and throughput of data volume data are not supported on Azure",
+		},
+		"Reject tuning setting not supported on GCP": {
+			ProviderType: hyperscaler.TypeGCP,
+			Tunings: []imv1.WorkerTuning{
+				{WorkerName: "main-worker", Volume: &imv1.VolumeTuning{IOPS: ptr.To(int64(3000))}},
+			},
+			ExpectedError: "failed to create worker config for worker main-worker: root volume tuning is not supported on GCP",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			provider := gardener.Provider{
+				Type:    tc.ProviderType,
+				Workers: fixWorkers(nil),
+			}
+
+			// when
+			err := setWorkerConfig(&provider, tc.Tunings, true)
+
+			// then
+			assert.EqualError(t, err, tc.ExpectedError)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package extender
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauthz"
+)
+
+// NewStructuredAuthorizationExtender references the AuthorizationConfiguration ConfigMap and the webhook kubeconfig secrets written to the project namespace
+func NewStructuredAuthorizationExtender() func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		authorization := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.StructuredAuthorization
+		if authorization == nil {
+			return nil
+		}
+
+		if shoot.Spec.Kubernetes.KubeAPIServer == nil {
+			shoot.Spec.Kubernetes.KubeAPIServer = &gardener.KubeAPIServerConfig{}
+		}
+
+		kubeconfigs := make([]gardener.AuthorizerKubeconfigReference, 0, len(authorization.Webhooks))
+		for _, webhook := range authorization.Webhooks {
+			kubeconfigs = append(kubeconfigs, gardener.AuthorizerKubeconfigReference{
+				AuthorizerName: webhook.Name,
+				SecretName:     structuredauthz.KubeconfigSecretName(runtime.Spec.Shoot.Name, webhook.Name),
+			})
+		}
+
+		shoot.Spec.Kubernetes.KubeAPIServer.StructuredAuthorization = &gardener.StructuredAuthorization{
+			ConfigMapName: structuredauthz.ConfigMapName(runtime.Spec.Shoot.Name),
+			Kubeconfigs:   kubeconfigs,
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,49 @@
+package extender
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredAuthorizationExtender(t *testing.T) {
+	t.Run("Should reference the config map and the webhook kubeconfig secrets", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("test", "kcp-system")
+		runtime := imv1.Runtime{}
+		runtime.Spec.Shoot.Name = "shoot"
+		runtime.Spec.Shoot.Kubernetes.KubeAPIServer.StructuredAuthorization = &imv1.StructuredAuthorization{
+			Webhooks: []imv1.WebhookAuthorizer{
+				{Name: "policy", KubeconfigSecretName: "policy-kubeconfig"},
+			},
+		}
+
+		// when
+		err := NewStructuredAuthorizationExtender()(runtime, &shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &gardener.StructuredAuthorization{
+			ConfigMapName: "structured-authz-config-shoot",
+			Kubeconfigs: []gardener.AuthorizerKubeconfigReference{
+				{AuthorizerName: "policy", SecretName: "structured-authz-kubeconfig-shoot-policy"},
+			},
+		}, shoot.Spec.Kubernetes.KubeAPIServer.StructuredAuthorization)
+	})
+
+	t.Run("Should not set structured authorization when not configured", func(t *testing.T) {
+		// given
+		shoot := testutils.FixEmptyGardenerShoot("test", "kcp-system")
+
+		// when
+		err := NewStructuredAuthorizationExtender()(imv1.Runtime{}, &shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.Kubernetes.KubeAPIServer)
+	})
+}
@@ -0,0 +1,170 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"net/netip"
+
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	infrastructureConfigKind = "InfrastructureConfig"
+	controlPlaneConfigKind   = "ControlPlaneConfig"
+	apiVersion               = "alicloud.provider.extensions.gardener.cloud/v1alpha1"
+)
+
+func GetInfrastructureConfig(workerCIDR string, zones []string) ([]byte, error) {
+	config, err := NewInfrastructureConfig(workerCIDR, zones)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(config)
+}
+
+func GetInfrastructureConfigForPatch(workersCidr string, zones []string, existingInfrastructureConfigBytes []byte) ([]byte, error) {
+	newConfig, err := NewInfrastructureConfigForPatch(workersCidr, zones, existingInfrastructureConfigBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(newConfig)
+}
+
+func GetControlPlaneConfig(_ []string) ([]byte, error) {
+	return json.Marshal(NewControlPlaneConfig())
+}
+
+func NewInfrastructureConfig(workerCIDR string, zones []string) (InfrastructureConfig, error) {
+	alicloudZones, err := generateAlicloudZones(workerCIDR, zones)
+	if err != nil {
+		return InfrastructureConfig{}, err
+	}
+
+	return InfrastructureConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       infrastructureConfigKind,
+			APIVersion: apiVersion,
+		},
+		Networks: Networks{
+			VPC: VPC{
+				CIDR: &workerCIDR,
+			},
+			Zones: alicloudZones,
+		},
+	}, nil
+}
+
+// NewInfrastructureConfigForPatch keeps the VPC settings and subnets of the existing zones untouched
+func NewInfrastructureConfigForPatch(workersCidr string, zones []string, existingInfrastructureConfigBytes []byte) (InfrastructureConfig, error) {
+	newConfig, err := NewInfrastructureConfig(workersCidr, zones)
+	if err != nil {
+		return InfrastructureConfig{}, err
+	}
+
+	var existingInfrastructureConfig InfrastructureConfig
+	if err := json.Unmarshal(existingInfrastructureConfigBytes, &existingInfrastructureConfig); err != nil {
+		return InfrastructureConfig{}, errors.Wrap(err, "failed to decode existing infrastructure config")
+	}
+
+	existingZones := map[string]bool{}
+	for _, zone := range existingInfrastructureConfig.Networks.Zones {
+		for i := 0; i < len(newConfig.Networks.Zones); i++ {
+			newZone := &newConfig.Networks.Zones[i]
+			if newZone.Name == zone.Name {
+				newZone.Workers = zone.Workers
+				newZone.NatGateway = zone.NatGateway
+				existingZones[zone.Name] = true
+			}
+		}
+	}
+
+	if err := relocateAddedZones(workersCidr, newConfig.Networks.Zones, existingZones); err != nil {
+		return InfrastructureConfig{}, err
+	}
+
+	if err := verifyZonesDoNotOverlap(newConfig.Networks.Zones); err != nil {
+		return InfrastructureConfig{}, err
+	}
+
+	newConfig.Networks.VPC.ID = existingInfrastructureConfig.Networks.VPC.ID
+	newConfig.Networks.VPC.GardenerManagedNATGateway = existingInfrastructureConfig.Networks.VPC.GardenerManagedNATGateway
+	newConfig.Networks.VPC.Bandwidth = existingInfrastructureConfig.Networks.VPC.Bandwidth
+
+	return newConfig, nil
+}
+
+// relocateAddedZones moves the subnets of added zones which collide with the subnets of the existing zones after the highest existing subnet.
+// Subnets of existing zones may have been built with another layout than the current one.
+func relocateAddedZones(workersCidr string, zones []Zone, existingZones map[string]bool) error {
+	cidr, err := netip.ParsePrefix(workersCidr)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse worker network CIDR")
+	}
+
+	var taken []netip.Prefix
+	for _, zone := range zones {
+		if !existingZones[zone.Name] {
+			continue
+		}
+
+		subnets, err := networking.ParsePrefixes(zone.Workers)
+		if err != nil {
+			return err
+		}
+		taken = append(taken, subnets...)
+	}
+
+	for i := range zones {
+		zone := &zones[i]
+		if existingZones[zone.Name] {
+			continue
+		}
+
+		subnets, err := networking.ParsePrefixes(zone.Workers)
+		if err != nil {
+			return err
+		}
+
+		if networking.OverlapsAny(subnets, taken) {
+			subnets, err = networking.AllocateBlocksAfter(cidr, taken, networking.PrefixLengths(subnets))
+			if err != nil {
+				return errors.Wrapf(err, "failed to allocate subnet for added zone %s", zone.Name)
+			}
+
+			zone.Workers = subnets[0].String()
+		}
+
+		taken = append(taken, subnets...)
+	}
+
+	return nil
+}
+
+func verifyZonesDoNotOverlap(zones []Zone) error {
+	for i := 0; i < len(zones); i++ {
+		for j := i + 1; j < len(zones); j++ {
+			overlaps, err := networking.Overlaps(zones[i].Workers, zones[j].Workers)
+			if err != nil {
+				return err
+			}
+
+			if overlaps {
+				return errors.Errorf("subnet %s of zone %s overlaps with subnet %s of zone %s", zones[i].Workers, zones[i].Name, zones[j].Workers, zones[j].Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func NewControlPlaneConfig() *ControlPlaneConfig {
+	return &ControlPlaneConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       controlPlaneConfigKind,
+			APIVersion: apiVersion,
+		},
+	}
+}
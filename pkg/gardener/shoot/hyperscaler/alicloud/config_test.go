@@ -0,0 +1,178 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestControlPlaneConfig(t *testing.T) {
+	t.Run("Create Control Plane config", func(t *testing.T) {
+		// when
+		controlPlaneConfigBytes, err := GetControlPlaneConfig([]string{"cn-beijing-a"})
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, readGoldenFile(t, "control_plane_config.json"), string(controlPlaneConfigBytes))
+	})
+}
+
+func TestInfrastructureConfig(t *testing.T) {
+	t.Run("Create Infrastructure config", func(t *testing.T) {
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfig("10.250.0.0/16", []string{"cn-beijing-a", "cn-beijing-b", "cn-beijing-c"})
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, readGoldenFile(t, "infrastructure_config.json"), string(infrastructureConfigBytes))
+	})
+
+	for tname, tcase := range map[string]struct {
+		givenNodesCidr string
+		givenZoneNames []string
+	}{
+		"Error when no zone": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{},
+		},
+		"Error when invalid nodes CIDR": {
+			givenNodesCidr: "10.250.0/16",
+			givenZoneNames: []string{"cn-beijing-a"},
+		},
+		"Error when too big prefix 10.250.0.0/25": {
+			givenNodesCidr: "10.250.0.0/25",
+			givenZoneNames: []string{"cn-beijing-a"},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenNodesCidr, tcase.givenZoneNames)
+
+			// then
+			assert.Error(t, err)
+			assert.Nil(t, infrastructureConfigBytes)
+		})
+	}
+}
+
+func TestInfrastructureConfigPatch(t *testing.T) {
+	// given
+	existingInfrastructureConfig := InfrastructureConfig{
+		Networks: Networks{
+			VPC: VPC{
+				ID:                        ptr.To("vpc-123456"),
+				CIDR:                      ptr.To("10.250.0.0/16"),
+				GardenerManagedNATGateway: ptr.To(true),
+				Bandwidth:                 ptr.To("100"),
+			},
+			Zones: []Zone{
+				{
+					Name:    "cn-beijing-a",
+					Workers: "10.250.0.0/19",
+					NatGateway: &NatGatewayConfig{
+						EIPAllocationID: ptr.To("eip-123456"),
+					},
+				},
+			},
+		},
+	}
+
+	existingInfrastructureConfigBytes, err := json.Marshal(existingInfrastructureConfig)
+	require.NoError(t, err)
+
+	t.Run("Create Infrastructure config for patch", func(t *testing.T) {
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch("10.250.0.0/16", []string{"cn-beijing-a", "cn-beijing-b"}, existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, readGoldenFile(t, "infrastructure_config_patch.json"), string(infrastructureConfigBytes))
+	})
+
+	t.Run("Fail to create Infrastructure config for patch with duplicated zones", func(t *testing.T) {
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch("10.250.0.0/16", []string{"cn-beijing-a", "cn-beijing-a"}, existingInfrastructureConfigBytes)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, infrastructureConfigBytes)
+	})
+
+	t.Run("Allocate added zones after existing zones built with another layout than the default", func(t *testing.T) {
+		// given
+		existingConfigBytes := existingConfigWithZones(t, Zone{Name: "cn-beijing-a", Workers: "10.250.0.0/18"})
+
+		// when
+		config, err := NewInfrastructureConfigForPatch("10.250.0.0/16", []string{"cn-beijing-a", "cn-beijing-b", "cn-beijing-c"}, existingConfigBytes)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, config.Networks.Zones, 3)
+		assert.Equal(t, "10.250.0.0/18", config.Networks.Zones[0].Workers)
+		assert.Equal(t, "10.250.64.0/19", config.Networks.Zones[1].Workers)
+		assert.Equal(t, "10.250.96.0/19", config.Networks.Zones[2].Workers)
+	})
+
+	t.Run("Keep subnets of added zones which do not collide with existing zones", func(t *testing.T) {
+		// given
+		existingConfigBytes := existingConfigWithZones(t, Zone{Name: "cn-beijing-b", Workers: "10.250.32.0/19"})
+
+		// when
+		config, err := NewInfrastructureConfigForPatch("10.250.0.0/16", []string{"cn-beijing-a", "cn-beijing-b"}, existingConfigBytes)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, config.Networks.Zones, 2)
+		assert.Equal(t, "10.250.0.0/19", config.Networks.Zones[0].Workers)
+		assert.Equal(t, "10.250.32.0/19", config.Networks.Zones[1].Workers)
+	})
+
+	t.Run("Fail to create Infrastructure config for patch when added zones do not fit after existing zones", func(t *testing.T) {
+		// given
+		existingConfigBytes := existingConfigWithZones(t, Zone{Name: "cn-beijing-a", Workers: "10.250.0.0/17"}, Zone{Name: "cn-beijing-b", Workers: "10.250.128.0/17"})
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch("10.250.0.0/16", []string{"cn-beijing-a", "cn-beijing-b", "cn-beijing-c"}, existingConfigBytes)
+
+		// then
+		assert.ErrorContains(t, err, "failed to allocate subnet for added zone cn-beijing-c")
+		assert.Nil(t, infrastructureConfigBytes)
+	})
+
+	t.Run("Fail to create Infrastructure config for patch with invalid existing config", func(t *testing.T) {
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch("10.250.0.0/16", []string{"cn-beijing-a"}, []byte("invalid"))
+
+		// then
+		assert.ErrorContains(t, err, "failed to decode existing infrastructure config")
+		assert.Nil(t, infrastructureConfigBytes)
+	})
+}
+
+func existingConfigWithZones(t *testing.T, zones ...Zone) []byte {
+	config := InfrastructureConfig{
+		Networks: Networks{
+			VPC: VPC{
+				CIDR: ptr.To("10.250.0.0/16"),
+			},
+			Zones: zones,
+		},
+	}
+
+	configBytes, err := json.Marshal(config)
+	require.NoError(t, err)
+
+	return configBytes
+}
+
+func readGoldenFile(t *testing.T, name string) string {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	return string(data)
+}
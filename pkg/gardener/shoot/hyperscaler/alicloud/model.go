@@ -0,0 +1,79 @@
+package alicloud
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// This types are copied from https://github.com/gardener/gardener-extension-provider-alicloud/blob/master/pkg/apis/alicloud/v1alpha1 as the extension is not a dependency of this module
+
+// InfrastructureConfig infrastructure configuration resource
+type InfrastructureConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// Networks is the network configuration (VPC, subnets, etc.)
+	Networks Networks `json:"networks"`
+}
+
+// Networks holds information about the Kubernetes and infrastructure networks.
+type Networks struct {
+	// VPC indicates whether to use an existing VPC or create a new one.
+	VPC VPC `json:"vpc"`
+	// Zones belonging to the same region
+	Zones []Zone `json:"zones"`
+}
+
+// VPC contains information about the VPC and some related resources.
+type VPC struct {
+	// ID is the VPC id.
+	// +optional
+	ID *string `json:"id,omitempty"`
+	// CIDR is the VPC CIDR.
+	// +optional
+	CIDR *string `json:"cidr,omitempty"`
+	// GardenerManagedNATGateway indicates whether Gardener should create the NAT gateway in the existing VPC.
+	// +optional
+	GardenerManagedNATGateway *bool `json:"gardenerManagedNATGateway,omitempty"`
+	// Bandwidth is the bandwidth of the elastic IP addresses.
+	// +optional
+	Bandwidth *string `json:"bandwidth,omitempty"`
+}
+
+// Zone is an availability zone with its workers subnet.
+type Zone struct {
+	// Name is the name for this zone.
+	Name string `json:"name"`
+	// Workers is the CIDR range used for the workers subnet in the zone.
+	Workers string `json:"workers"`
+	// NatGateway contains configuration for the NAT gateway and the attached EIP.
+	// +optional
+	NatGateway *NatGatewayConfig `json:"natGateway,omitempty"`
+}
+
+// NatGatewayConfig contains configuration for the NAT gateway and the attached EIP.
+type NatGatewayConfig struct {
+	// EIPAllocationID is the id of the elastic IP attached to the NAT gateway.
+	// +optional
+	EIPAllocationID *string `json:"eipAllocationID,omitempty"`
+}
+
+// ControlPlaneConfig contains configuration settings for the control plane.
+type ControlPlaneConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
+	// +optional
+	CloudControllerManager *CloudControllerManagerConfig `json:"cloudControllerManager,omitempty"`
+	// CSI is the config for CSI plugin.
+	// +optional
+	CSI *CSI `json:"csi,omitempty"`
+}
+
+// CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
+type CloudControllerManagerConfig struct {
+	// FeatureGates contains information about enabled feature gates.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// CSI is config for CSI plugin.
+type CSI struct {
+	// EnableADController enables disks to be attached/detached in the kube-controller-manager.
+	// +optional
+	EnableADController *bool `json:"enableADController,omitempty"`
+}
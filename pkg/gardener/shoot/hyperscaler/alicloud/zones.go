@@ -0,0 +1,69 @@
+package alicloud
+
+import (
+	"net/netip"
+
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxNumberOfZones = 8
+	minNumberOfZones = 1
+	maxPrefixSize    = 24
+	minPrefixSize    = 16
+	zoneBlockBits    = 3 // nodes CIDR is split into 8 zone subnets, same as on Azure
+)
+
+/*
+
+generateAlicloudZones - creates a list of zones with the workers subnet for every zone.
+Nodes CIDR is split into 8 equal workers subnets assigned to the zones in the order of zone names, for 10.250.0.0/16:
+  - name: cn-beijing-a
+    workers: 10.250.0.0/19
+  - name: cn-beijing-b
+    workers: 10.250.32.0/19
+*/
+
+func generateAlicloudZones(workerCidr string, zoneNames []string) ([]Zone, error) {
+	if len(zoneNames) < minNumberOfZones || len(zoneNames) > maxNumberOfZones {
+		return nil, errors.New("Number of networking zones must be between 1 and 8")
+	}
+
+	cidr, err := netip.ParsePrefix(workerCidr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse worker network CIDR")
+	}
+
+	prefixLength := cidr.Bits()
+	if prefixLength > maxPrefixSize || prefixLength < minPrefixSize {
+		return nil, errors.New("CIDR prefix length must be between 16 and 24")
+	}
+
+	prefixLengths := make([]int, 0, len(zoneNames))
+	for range zoneNames {
+		prefixLengths = append(prefixLengths, prefixLength+zoneBlockBits)
+	}
+
+	blocks, err := networking.AllocateBlocks(cidr, prefixLengths)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to allocate zone subnets")
+	}
+
+	var zones []Zone
+	processed := make(map[string]bool)
+
+	for i, name := range zoneNames {
+		if _, ok := processed[name]; ok {
+			return nil, errors.Errorf("zone name %s is duplicated", name)
+		}
+		processed[name] = true
+
+		zones = append(zones, Zone{
+			Name:    name,
+			Workers: blocks[i].String(),
+		})
+	}
+
+	return zones, nil
+}
@@ -0,0 +1,95 @@
+package alicloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlicloudZonesWithCustomNodeIPRange(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenNodesCidr string
+		givenZoneNames []string
+		expectedZones  []Zone
+	}{
+		"Alicloud one zone and 10.250.0.0/16": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{"cn-beijing-a"},
+			expectedZones: []Zone{
+				{Name: "cn-beijing-a", Workers: "10.250.0.0/19"},
+			},
+		},
+		"Alicloud three zones and 10.250.0.0/22": {
+			givenNodesCidr: "10.250.0.0/22",
+			givenZoneNames: []string{"cn-shanghai-e", "cn-shanghai-f", "cn-shanghai-g"},
+			expectedZones: []Zone{
+				{Name: "cn-shanghai-e", Workers: "10.250.0.0/25"},
+				{Name: "cn-shanghai-f", Workers: "10.250.0.128/25"},
+				{Name: "cn-shanghai-g", Workers: "10.250.1.0/25"},
+			},
+		},
+		"Alicloud eight zones and 10.180.0.0/24": {
+			givenNodesCidr: "10.180.0.0/24",
+			givenZoneNames: []string{"z1", "z2", "z3", "z4", "z5", "z6", "z7", "z8"},
+			expectedZones: []Zone{
+				{Name: "z1", Workers: "10.180.0.0/27"},
+				{Name: "z2", Workers: "10.180.0.32/27"},
+				{Name: "z3", Workers: "10.180.0.64/27"},
+				{Name: "z4", Workers: "10.180.0.96/27"},
+				{Name: "z5", Workers: "10.180.0.128/27"},
+				{Name: "z6", Workers: "10.180.0.160/27"},
+				{Name: "z7", Workers: "10.180.0.192/27"},
+				{Name: "z8", Workers: "10.180.0.224/27"},
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			zones, err := generateAlicloudZones(tcase.givenNodesCidr, tcase.givenZoneNames)
+
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expectedZones, zones)
+		})
+	}
+}
+
+func TestAlicloudZonesErrors(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenNodesCidr string
+		givenZoneNames []string
+		message        string
+	}{
+		"Alicloud should return error when there is no zone": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{},
+			message:        "Number of networking zones must be between 1 and 8",
+		},
+		"Alicloud should return error when there are more than 8 zones": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{"z1", "z2", "z3", "z4", "z5", "z6", "z7", "z8", "z9"},
+			message:        "Number of networking zones must be between 1 and 8",
+		},
+		"Alicloud should return error when zone name is duplicated": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{"cn-beijing-a", "cn-beijing-a"},
+			message:        "zone name cn-beijing-a is duplicated",
+		},
+		"Alicloud should return error when cannot parse nodes CIDR": {
+			givenNodesCidr: "888.888.888.0/77",
+			givenZoneNames: []string{"cn-beijing-a"},
+			message:        "failed to parse worker network CIDR",
+		},
+		"Alicloud should return error when prefix is too small for ex 10.250.0.0/15": {
+			givenNodesCidr: "10.250.0.0/15",
+			givenZoneNames: []string{"cn-beijing-a"},
+			message:        "CIDR prefix length must be between 16 and 24",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			zones, err := generateAlicloudZones(tcase.givenNodesCidr, tcase.givenZoneNames)
+
+			assert.ErrorContains(t, err, tcase.message)
+			assert.Equal(t, 0, len(zones))
+		})
+	}
+}
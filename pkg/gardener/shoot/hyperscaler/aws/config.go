@@ -2,7 +2,12 @@ package aws
 
 import (
 	"encoding/json"
+	"slices"
+
 	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/workers"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -13,8 +18,8 @@ const workerConfigKind = "WorkerConfig"
 
 const awsIMDSv2HTTPPutResponseHopLimit int64 = 2
 
-func GetInfrastructureConfig(workersCidr string, zones []string) ([]byte, error) {
-	config, err := NewInfrastructureConfig(workersCidr, zones)
+func GetInfrastructureConfig(workersCidr string, zones []string, layout networking.SubnetLayout) ([]byte, error) {
+	config, err := NewInfrastructureConfig(workersCidr, zones, layout)
 	if err != nil {
 		return nil, err
 	}
@@ -22,8 +27,8 @@ func GetInfrastructureConfig(workersCidr string, zones []string) ([]byte, error)
 	return json.Marshal(config)
 }
 
-func GetInfrastructureConfigForPatch(workersCidr string, zones []string, existingInfrastructureConfigBytes []byte) ([]byte, error) {
-	newConfig, err := NewInfrastructureConfigForPatch(workersCidr, zones, existingInfrastructureConfigBytes)
+func GetInfrastructureConfigForPatch(workersCidr string, zones []string, layout networking.SubnetLayout, existingInfrastructureConfigBytes []byte) ([]byte, error) {
+	newConfig, err := NewInfrastructureConfigForPatch(workersCidr, zones, layout, existingInfrastructureConfigBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +40,13 @@ func GetControlPlaneConfig(_ []string) ([]byte, error) {
 	return json.Marshal(NewControlPlaneConfig())
 }
 
-func GetWorkerConfig() ([]byte, error) {
-	return json.Marshal(NewWorkerConfig())
+func GetWorkerConfig(existingWorkerConfigBytes []byte, enableIMDSv2 bool, tuning workers.Tuning) ([]byte, error) {
+	config, err := NewWorkerConfig(existingWorkerConfigBytes, enableIMDSv2, tuning)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(config)
 }
 
 func DecodeInfrastructureConfig(data []byte) (*v1alpha1.InfrastructureConfig, error) {
@@ -48,8 +58,8 @@ func DecodeInfrastructureConfig(data []byte) (*v1alpha1.InfrastructureConfig, er
 	return infrastructureConfig, nil
 }
 
-func NewInfrastructureConfig(workersCidr string, zones []string) (v1alpha1.InfrastructureConfig, error) {
-	awsZones, err := generateAWSZones(workersCidr, zones)
+func NewInfrastructureConfig(workersCidr string, zones []string, layout networking.SubnetLayout) (v1alpha1.InfrastructureConfig, error) {
+	awsZones, err := generateZones(workersCidr, zones, layout)
 	if err != nil {
 		return v1alpha1.InfrastructureConfig{}, err
 	}
@@ -68,34 +78,62 @@ func NewInfrastructureConfig(workersCidr string, zones []string) (v1alpha1.Infra
 	}, nil
 }
 
-func NewInfrastructureConfigForPatch(workersCidr string, zones []string, existingInfrastructureConfigBytes []byte) (v1alpha1.InfrastructureConfig, error) {
-	newConfig, err := NewInfrastructureConfig(workersCidr, zones)
+// NewInfrastructureConfigForPatch keeps subnets of the existing zones untouched, the subnet layout is applied only to the added zones.
+func NewInfrastructureConfigForPatch(workersCidr string, zones []string, layout networking.SubnetLayout, existingInfrastructureConfigBytes []byte) (v1alpha1.InfrastructureConfig, error) {
+	existingInfrastructureConfig, err := DecodeInfrastructureConfig(existingInfrastructureConfigBytes)
+
 	if err != nil {
 		return v1alpha1.InfrastructureConfig{}, err
 	}
 
-	existingInfrastructureConfig, err := DecodeInfrastructureConfig(existingInfrastructureConfigBytes)
-
+	awsZones, err := generateZonesForPatch(workersCidr, zones, layout, existingInfrastructureConfig.Networks.Zones)
 	if err != nil {
 		return v1alpha1.InfrastructureConfig{}, err
 	}
 
-	newConfig.IgnoreTags = existingInfrastructureConfig.IgnoreTags
-	newConfig.EnableECRAccess = existingInfrastructureConfig.EnableECRAccess
-	newConfig.DualStack = existingInfrastructureConfig.DualStack
-	newConfig.Networks.VPC.ID = existingInfrastructureConfig.Networks.VPC.ID
-	newConfig.Networks.VPC.GatewayEndpoints = existingInfrastructureConfig.Networks.VPC.GatewayEndpoints
+	if err := verifyZonesDoNotOverlap(awsZones); err != nil {
+		return v1alpha1.InfrastructureConfig{}, err
+	}
+
+	return v1alpha1.InfrastructureConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       infrastructureConfigKind,
+			APIVersion: apiVersion,
+		},
+		IgnoreTags:      existingInfrastructureConfig.IgnoreTags,
+		EnableECRAccess: existingInfrastructureConfig.EnableECRAccess,
+		DualStack:       existingInfrastructureConfig.DualStack,
+		Networks: v1alpha1.Networks{
+			Zones: awsZones,
+			VPC: v1alpha1.VPC{
+				ID:               existingInfrastructureConfig.Networks.VPC.ID,
+				CIDR:             &workersCidr,
+				GatewayEndpoints: existingInfrastructureConfig.Networks.VPC.GatewayEndpoints,
+			},
+		},
+	}, nil
+}
+
+func verifyZonesDoNotOverlap(zones []v1alpha1.Zone) error {
+	var subnets []string
+	for _, zone := range zones {
+		subnets = append(subnets, zone.Workers, zone.Public, zone.Internal)
+	}
+
+	for i := 0; i < len(subnets); i++ {
+		for j := i + 1; j < len(subnets); j++ {
+			overlaps, err := networking.Overlaps(subnets[i], subnets[j])
+			if err != nil {
+				return err
+			}
 
-	for _, zone := range existingInfrastructureConfig.Networks.Zones {
-		for i := 0; i < len(newConfig.Networks.Zones); i++ {
-			newZone := &newConfig.Networks.Zones[i]
-			if newZone.Name == zone.Name {
-				newZone.ElasticIPAllocationID = zone.ElasticIPAllocationID
+			if overlaps {
+				return errors.Errorf("subnet %s overlaps with subnet %s", subnets[i], subnets[j])
 			}
 		}
 	}
 
-	return newConfig, nil
+	return nil
 }
 
 func NewControlPlaneConfig() *v1alpha1.ControlPlaneConfig {
@@ -107,18 +145,86 @@ func NewControlPlaneConfig() *v1alpha1.ControlPlaneConfig {
 	}
 }
 
-func NewWorkerConfig() *v1alpha1.WorkerConfig {
+// NewWorkerConfig merges IMDSv2 settings and the worker tuning into the worker config set on the Runtime CR, all other settings are kept
+func NewWorkerConfig(existingWorkerConfigBytes []byte, enableIMDSv2 bool, tuning workers.Tuning) (*v1alpha1.WorkerConfig, error) {
+	config := &v1alpha1.WorkerConfig{}
+
+	if len(existingWorkerConfigBytes) > 0 {
+		if err := json.Unmarshal(existingWorkerConfigBytes, config); err != nil {
+			return nil, errors.Wrap(err, "failed to decode worker config")
+		}
+	}
+
+	config.TypeMeta = metav1.TypeMeta{
+		APIVersion: apiVersion,
+		Kind:       workerConfigKind,
+	}
+
+	if enableIMDSv2 {
+		setIMDSv2(config)
+	}
+
+	if err := applyTuning(config, tuning); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Usage of IMDSv2 is enforced, only the hop limit may be changed on the Runtime CR
+func setIMDSv2(config *v1alpha1.WorkerConfig) {
 	httpTokens := v1alpha1.HTTPTokensRequired
-	hopLimit := awsIMDSv2HTTPPutResponseHopLimit
 
-	return &v1alpha1.WorkerConfig{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: apiVersion,
-			Kind:       workerConfigKind,
-		},
-		InstanceMetadataOptions: &v1alpha1.InstanceMetadataOptions{
-			HTTPTokens:              &httpTokens,
-			HTTPPutResponseHopLimit: &hopLimit,
-		},
+	if config.InstanceMetadataOptions == nil {
+		config.InstanceMetadataOptions = &v1alpha1.InstanceMetadataOptions{}
+	}
+
+	config.InstanceMetadataOptions.HTTPTokens = &httpTokens
+
+	if config.InstanceMetadataOptions.HTTPPutResponseHopLimit == nil {
+		hopLimit := awsIMDSv2HTTPPutResponseHopLimit
+		config.InstanceMetadataOptions.HTTPPutResponseHopLimit = &hopLimit
+	}
+}
+
+func applyTuning(config *v1alpha1.WorkerConfig, tuning workers.Tuning) error {
+	if tuning.LocalSSDInterface != nil {
+		return errors.New("local SSD interface is not supported on AWS")
+	}
+
+	if tuning.Volume != nil {
+		if config.Volume == nil {
+			config.Volume = &v1alpha1.Volume{}
+		}
+		setVolumeTuning(config.Volume, *tuning.Volume)
+	}
+
+	for _, dataVolumeTuning := range tuning.DataVolumes {
+		index := slices.IndexFunc(config.DataVolumes, func(dataVolume v1alpha1.DataVolume) bool {
+			return dataVolume.Name == dataVolumeTuning.Name
+		})
+
+		if index == -1 {
+			config.DataVolumes = append(config.DataVolumes, v1alpha1.DataVolume{Name: dataVolumeTuning.Name})
+			index = len(config.DataVolumes) - 1
+		}
+
+		if dataVolumeTuning.SourceImage != nil {
+			return errors.New("source image of data volumes is not supported on AWS")
+		}
+
+		setVolumeTuning(&config.DataVolumes[index].Volume, dataVolumeTuning.VolumeTuning)
+	}
+
+	return nil
+}
+
+func setVolumeTuning(volume *v1alpha1.Volume, tuning workers.VolumeTuning) {
+	if tuning.IOPS != nil {
+		volume.IOPS = tuning.IOPS
+	}
+
+	if tuning.Throughput != nil {
+		volume.Throughput = tuning.Throughput
 	}
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/workers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -92,7 +94,7 @@ func TestInfrastructureConfig(t *testing.T) {
 	} {
 		t.Run(tname, func(t *testing.T) {
 			// when
-			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenNodesCidr, tcase.givenZoneNames)
+			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenNodesCidr, tcase.givenZoneNames, networking.DefaultSubnetLayout())
 
 			// then
 			assert.NoError(t, err)
@@ -164,7 +166,7 @@ func TestFailedInfrastructureConfig(t *testing.T) {
 	} {
 		t.Run(tname, func(t *testing.T) {
 			// when
-			bytes, err := GetInfrastructureConfig(tcase.givenNodesCidr, tcase.givenZoneNames)
+			bytes, err := GetInfrastructureConfig(tcase.givenNodesCidr, tcase.givenZoneNames, networking.DefaultSubnetLayout())
 
 			// then
 			assert.Error(t, err)
@@ -242,7 +244,7 @@ func TestInfrastructureConfigPatch(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenZoneNames, existingInfrastructureConfigBytes)
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenZoneNames, networking.DefaultSubnetLayout(), existingInfrastructureConfigBytes)
 
 		// then
 		assert.NoError(t, err)
@@ -278,12 +280,188 @@ func TestInfrastructureConfigPatch(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenDuplicatedZoneNames, existingInfrastructureConfigBytes)
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenDuplicatedZoneNames, networking.DefaultSubnetLayout(), existingInfrastructureConfigBytes)
 
 		// then
 		assert.Error(t, err)
 		assert.Nil(t, infrastructureConfigBytes)
 	})
+	t.Run("Keep subnets of existing zones when patching with a different subnet layout", func(t *testing.T) {
+		existingInfrastructureConfigBytes, err := json.Marshal(existingInfrastructureConfig)
+		require.NoError(t, err)
+
+		// when
+		layout := networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{19, 22, 22}}
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenZoneNames, layout, existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		expectedZones := []v1alpha1.Zone{
+			expectedAwsZones[0],
+			{
+				Name:     "eu-central-1b",
+				Workers:  "10.250.64.0/22",
+				Public:   "10.250.68.0/23",
+				Internal: "10.250.70.0/23",
+			},
+			{
+				Name:     "eu-central-1c",
+				Workers:  "10.250.72.0/22",
+				Public:   "10.250.76.0/23",
+				Internal: "10.250.78.0/23",
+			},
+		}
+
+		require.Len(t, infrastructureConfig.Networks.Zones, len(expectedZones))
+		for i, actualZone := range infrastructureConfig.Networks.Zones {
+			assertIPRanges(t, expectedZones[i], actualZone)
+		}
+	})
+
+	t.Run("Allocate added zones after existing zones when their subnets collide", func(t *testing.T) {
+		existingInfrastructureConfigBytes, err := json.Marshal(existingInfrastructureConfig)
+		require.NoError(t, err)
+
+		// when
+		layout := networking.SubnetLayout{Strategy: networking.SubnetLayoutUniform}
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenZoneNames, layout, existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		expectedZones := []v1alpha1.Zone{
+			expectedAwsZones[0],
+			{
+				Name:     "eu-central-1b",
+				Workers:  "10.250.64.0/20",
+				Public:   "10.250.80.0/21",
+				Internal: "10.250.88.0/21",
+			},
+			{
+				Name:     "eu-central-1c",
+				Workers:  "10.250.96.0/20",
+				Public:   "10.250.112.0/21",
+				Internal: "10.250.120.0/21",
+			},
+		}
+
+		require.Len(t, infrastructureConfig.Networks.Zones, len(expectedZones))
+		for i, actualZone := range infrastructureConfig.Networks.Zones {
+			assertIPRanges(t, expectedZones[i], actualZone)
+		}
+	})
+
+	t.Run("Allocate added zones after existing zones built with another layout than the default", func(t *testing.T) {
+		existingConfig := existingInfrastructureConfig
+		existingConfig.Networks.Zones = []v1alpha1.Zone{{
+			Name:     "eu-central-1a",
+			Workers:  "10.250.0.0/18",
+			Public:   "10.250.64.0/19",
+			Internal: "10.250.96.0/19",
+		}}
+		existingInfrastructureConfigBytes, err := json.Marshal(existingConfig)
+		require.NoError(t, err)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenZoneNames, networking.DefaultSubnetLayout(), existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		expectedZones := []v1alpha1.Zone{
+			existingConfig.Networks.Zones[0],
+			{
+				Name:     "eu-central-1b",
+				Workers:  "10.250.128.0/19",
+				Public:   "10.250.160.0/20",
+				Internal: "10.250.176.0/20",
+			},
+			{
+				Name:     "eu-central-1c",
+				Workers:  "10.250.192.0/19",
+				Public:   "10.250.224.0/20",
+				Internal: "10.250.240.0/20",
+			},
+		}
+
+		require.Len(t, infrastructureConfig.Networks.Zones, len(expectedZones))
+		for i, actualZone := range infrastructureConfig.Networks.Zones {
+			assertIPRanges(t, expectedZones[i], actualZone)
+		}
+	})
+
+	t.Run("Add zones when the subnet layout does not fit the full list of zones", func(t *testing.T) {
+		existingInfrastructureConfigBytes, err := json.Marshal(existingInfrastructureConfig)
+		require.NoError(t, err)
+
+		// the first zone of the layout takes the whole nodes CIDR
+		layout := networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{17, 20, 20}}
+		_, err = NewInfrastructureConfig(givenNodesCidr, givenZoneNames, layout)
+		require.Error(t, err)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenZoneNames, layout, existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		expectedZones := []v1alpha1.Zone{
+			expectedAwsZones[0],
+			{
+				Name:     "eu-central-1b",
+				Workers:  "10.250.64.0/20",
+				Public:   "10.250.80.0/21",
+				Internal: "10.250.88.0/21",
+			},
+			{
+				Name:     "eu-central-1c",
+				Workers:  "10.250.96.0/20",
+				Public:   "10.250.112.0/21",
+				Internal: "10.250.120.0/21",
+			},
+		}
+
+		require.Len(t, infrastructureConfig.Networks.Zones, len(expectedZones))
+		for i, actualZone := range infrastructureConfig.Networks.Zones {
+			assertIPRanges(t, expectedZones[i], actualZone)
+		}
+	})
+
+	t.Run("Fail to create Infrastructure config for patch when added zones do not fit after existing zones", func(t *testing.T) {
+		existingConfig := existingInfrastructureConfig
+		existingConfig.Networks.Zones = []v1alpha1.Zone{{
+			Name:     "eu-central-1a",
+			Workers:  "10.250.0.0/17",
+			Public:   "10.250.128.0/18",
+			Internal: "10.250.192.0/18",
+		}}
+		existingInfrastructureConfigBytes, err := json.Marshal(existingConfig)
+		require.NoError(t, err)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenNodesCidr, givenZoneNames, networking.DefaultSubnetLayout(), existingInfrastructureConfigBytes)
+
+		// then
+		assert.ErrorContains(t, err, "failed to allocate subnets for added zone eu-central-1b")
+		assert.Nil(t, infrastructureConfigBytes)
+	})
 }
 
 func assertIPRanges(t *testing.T, expectedZone v1alpha1.Zone, actualZone v1alpha1.Zone) {
@@ -296,7 +474,7 @@ func assertIPRanges(t *testing.T, expectedZone v1alpha1.Zone, actualZone v1alpha
 func TestWorkerConfig(t *testing.T) {
 	t.Run("Create worker config", func(t *testing.T) {
 		// when
-		configBytes, err := GetWorkerConfig()
+		configBytes, err := GetWorkerConfig(nil, true, workers.Tuning{})
 
 		// then
 		require.NoError(t, err)
@@ -308,4 +486,68 @@ func TestWorkerConfig(t *testing.T) {
 		assert.Equal(t, awsIMDSv2HTTPPutResponseHopLimit, *config.InstanceMetadataOptions.HTTPPutResponseHopLimit)
 		assert.Equal(t, v1alpha1.HTTPTokensRequired, *config.InstanceMetadataOptions.HTTPTokens)
 	})
+
+	t.Run("Merge IMDSv2 settings and tuning into existing worker config", func(t *testing.T) {
+		// given
+		existingConfigBytes := []byte(`{"apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","iamInstanceProfile":{"name":"custom-profile"},"instanceMetadataOptions":{"httpTokens":"optional","httpPutResponseHopLimit":3},"volume":{"iops":3000},"dataVolumes":[{"name":"data","iops":4000,"snapshotID":"snap-1"}]}`)
+		tuning := workers.Tuning{
+			Volume: &workers.VolumeTuning{Throughput: ptr.To(int64(250))},
+			DataVolumes: []workers.DataVolumeTuning{
+				{Name: "data", VolumeTuning: workers.VolumeTuning{Throughput: ptr.To(int64(500))}},
+				{Name: "logs", VolumeTuning: workers.VolumeTuning{IOPS: ptr.To(int64(6000))}},
+			},
+		}
+
+		// when
+		config, err := NewWorkerConfig(existingConfigBytes, true, tuning)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, workerConfigKind, config.Kind)
+		assert.Equal(t, "custom-profile", *config.IAMInstanceProfile.Name)
+		assert.Equal(t, v1alpha1.HTTPTokensRequired, *config.InstanceMetadataOptions.HTTPTokens)
+		assert.Equal(t, int64(3), *config.InstanceMetadataOptions.HTTPPutResponseHopLimit)
+		assert.Equal(t, int64(3000), *config.Volume.IOPS)
+		assert.Equal(t, int64(250), *config.Volume.Throughput)
+		require.Len(t, config.DataVolumes, 2)
+		assert.Equal(t, int64(4000), *config.DataVolumes[0].IOPS)
+		assert.Equal(t, int64(500), *config.DataVolumes[0].Throughput)
+		assert.Equal(t, "snap-1", *config.DataVolumes[0].SnapshotID)
+		assert.Equal(t, "logs", config.DataVolumes[1].Name)
+		assert.Equal(t, int64(6000), *config.DataVolumes[1].IOPS)
+	})
+
+	t.Run("Apply tuning without IMDSv2", func(t *testing.T) {
+		// when
+		config, err := NewWorkerConfig(nil, false, workers.Tuning{Volume: &workers.VolumeTuning{IOPS: ptr.To(int64(3000))}})
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, config.InstanceMetadataOptions)
+		assert.Equal(t, int64(3000), *config.Volume.IOPS)
+	})
+
+	t.Run("Reject local SSD interface", func(t *testing.T) {
+		// when
+		_, err := NewWorkerConfig(nil, true, workers.Tuning{LocalSSDInterface: ptr.To("NVME")})
+
+		// then
+		assert.EqualError(t, err, "local SSD interface is not supported on AWS")
+	})
+
+	t.Run("Reject source image of data volumes", func(t *testing.T) {
+		// when
+		_, err := NewWorkerConfig(nil, true, workers.Tuning{DataVolumes: []workers.DataVolumeTuning{{Name: "data", SourceImage: ptr.To("image")}}})
+
+		// then
+		assert.EqualError(t, err, "source image of data volumes is not supported on AWS")
+	})
+
+	t.Run("Reject invalid existing worker config", func(t *testing.T) {
+		// when
+		_, err := NewWorkerConfig([]byte("{"), true, workers.Tuning{})
+
+		// then
+		assert.ErrorContains(t, err, "failed to decode worker config")
+	})
 }
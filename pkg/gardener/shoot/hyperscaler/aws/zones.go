@@ -4,8 +4,10 @@ import (
 	"github.com/pkg/errors"
 	"math/big"
 	"net/netip"
+	"slices"
 
 	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
 )
 
 const (
@@ -17,8 +19,195 @@ const (
 	minPrefixSize        = 16
 	addSmallSubnetPrefix = 3
 	kymaWorkerPoolAZs    = 3 //first 3 zones are reserved for Kyma worker pool and bigger dimensioned than later added AZs
+	uniformZoneBlockBits = 3 //uniform layout splits the nodes CIDR into 8 equal zone blocks
 )
 
+// generateZones creates zones according to the subnet layout, kyma-weighted layout is the default one.
+func generateZones(workerCidr string, zoneNames []string, layout networking.SubnetLayout) ([]v1alpha1.Zone, error) {
+	if err := layout.Validate(len(zoneNames)); err != nil {
+		return nil, err
+	}
+
+	switch layout.Strategy {
+	case networking.SubnetLayoutUniform:
+		return generateAWSZonesFromWorkerPrefixes(workerCidr, zoneNames, func(prefixLength, _ int) int {
+			return prefixLength + uniformZoneBlockBits + 1
+		})
+	case networking.SubnetLayoutCustom:
+		return generateAWSZonesFromWorkerPrefixes(workerCidr, zoneNames, func(_, zoneIndex int) int {
+			return layout.ZonePrefixLengths[zoneIndex]
+		})
+	default:
+		return generateAWSZones(workerCidr, zoneNames)
+	}
+}
+
+// generateZonesForPatch keeps the existing zones, subnets of the added zones are allocated according to the subnet layout after the highest existing subnet.
+// Existing zones may have been built with another layout, so the layout is not applied to the full list of zones.
+func generateZonesForPatch(workerCidr string, zoneNames []string, layout networking.SubnetLayout, existingZones []v1alpha1.Zone) ([]v1alpha1.Zone, error) {
+	// prefix lengths of the custom layout are verified for the added zones only
+	if err := layout.Validate(0); err != nil {
+		return nil, err
+	}
+
+	if len(zoneNames) < minNumberOfZones {
+		return nil, errors.New("At least one networking zone is required")
+	}
+
+	cidr, err := netip.ParsePrefix(workerCidr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse worker network CIDR")
+	}
+
+	if cidr.Bits() > maxPrefixSize || cidr.Bits() < minPrefixSize {
+		return nil, errors.New("CIDR prefix length must be between 16 and 24")
+	}
+
+	var taken []netip.Prefix
+	for _, zone := range existingZones {
+		subnets, err := networking.ParsePrefixes(zone.Workers, zone.Public, zone.Internal)
+		if err != nil {
+			return nil, err
+		}
+		taken = append(taken, subnets...)
+	}
+
+	var zones []v1alpha1.Zone
+	processed := make(map[string]bool)
+
+	for i, name := range zoneNames {
+		if _, ok := processed[name]; ok {
+			return nil, errors.Errorf("zone name %s is duplicated", name)
+		}
+		processed[name] = true
+
+		existingZoneIndex := slices.IndexFunc(existingZones, func(zone v1alpha1.Zone) bool {
+			return zone.Name == name
+		})
+
+		if existingZoneIndex != -1 {
+			zones = append(zones, existingZones[existingZoneIndex])
+			continue
+		}
+
+		prefixLengths, err := zoneSubnetPrefixLengths(cidr.Bits(), i, layout)
+		if err != nil {
+			return nil, err
+		}
+
+		subnets, err := networking.AllocateBlocksAfter(cidr, taken, prefixLengths)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to allocate subnets for added zone %s", name)
+		}
+		taken = append(taken, subnets...)
+
+		zones = append(zones, v1alpha1.Zone{
+			Name:     name,
+			Workers:  subnets[0].String(),
+			Public:   subnets[1].String(),
+			Internal: subnets[2].String(),
+		})
+	}
+
+	return zones, nil
+}
+
+// zoneSubnetPrefixLengths returns the prefix lengths of the workers, public and internal subnets of the zone at the given position in the subnet layout
+func zoneSubnetPrefixLengths(cidrPrefixLength, zoneIndex int, layout networking.SubnetLayout) ([]int, error) {
+	var workersPrefixLength int
+
+	switch layout.Strategy {
+	case networking.SubnetLayoutUniform:
+		workersPrefixLength = cidrPrefixLength + uniformZoneBlockBits + 1
+	case networking.SubnetLayoutCustom:
+		if zoneIndex >= len(layout.ZonePrefixLengths) {
+			return nil, errors.Errorf("custom subnet layout defines prefix lengths for %d zones, but %d zones are used", len(layout.ZonePrefixLengths), zoneIndex+1)
+		}
+		workersPrefixLength = layout.ZonePrefixLengths[zoneIndex]
+	default:
+		if zoneIndex >= kymaWorkerPoolAZs {
+			smallPrefixLength := cidrPrefixLength + subNetworkBitsSize + addSmallSubnetPrefix
+			return []int{smallPrefixLength, smallPrefixLength, smallPrefixLength}, nil
+		}
+		workersPrefixLength = cidrPrefixLength + subNetworkBitsSize
+	}
+
+	return []int{workersPrefixLength, workersPrefixLength + 1, workersPrefixLength + 1}, nil
+}
+
+/*
+
+generateAWSZonesFromWorkerPrefixes - creates zones where each zone gets a block twice as big as its workers subnet.
+The workers subnet takes the first half of the block, the public and internal subnets take the remaining quarters, for workers prefix /19:
+  - workers: 10.250.0.0/19
+    public: 10.250.32.0/20
+    internal: 10.250.48.0/20
+
+Zone blocks are allocated one after another, each one aligned to its size.
+*/
+
+func generateAWSZonesFromWorkerPrefixes(workerCidr string, zoneNames []string, workerPrefixLength func(cidrPrefixLength, zoneIndex int) int) ([]v1alpha1.Zone, error) {
+	if len(zoneNames) < minNumberOfZones {
+		return nil, errors.New("At least one networking zone is required")
+	}
+
+	cidr, err := netip.ParsePrefix(workerCidr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse worker network CIDR")
+	}
+
+	prefixLength := cidr.Bits()
+	if prefixLength > maxPrefixSize || prefixLength < minPrefixSize {
+		return nil, errors.New("CIDR prefix length must be between 16 and 24")
+	}
+
+	blockPrefixLengths := make([]int, 0, len(zoneNames))
+	for i := range zoneNames {
+		blockPrefixLengths = append(blockPrefixLengths, workerPrefixLength(prefixLength, i)-1)
+	}
+
+	blocks, err := networking.AllocateBlocks(cidr, blockPrefixLengths)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to allocate zone subnets")
+	}
+
+	var zones []v1alpha1.Zone
+	processed := make(map[string]bool)
+
+	for i, name := range zoneNames {
+		if _, ok := processed[name]; ok {
+			return nil, errors.Errorf("zone name %s is duplicated", name)
+		}
+		processed[name] = true
+
+		block := blocks[i]
+
+		zoneWorkerCIDR, err := networking.SplitBlock(block, block.Bits()+1, 0)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get worker CIDR for zone %s", name)
+		}
+
+		zonePublicCIDR, err := networking.SplitBlock(block, block.Bits()+2, 2)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get public CIDR for zone %s", name)
+		}
+
+		zoneInternalCIDR, err := networking.SplitBlock(block, block.Bits()+2, 3)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get internal CIDR for zone %s", name)
+		}
+
+		zones = append(zones, v1alpha1.Zone{
+			Name:     name,
+			Workers:  zoneWorkerCIDR.String(),
+			Public:   zonePublicCIDR.String(),
+			Internal: zoneInternalCIDR.String(),
+		})
+	}
+
+	return zones, nil
+}
+
 /*
 
 generateAWSZones - creates a list of AWSZoneInput objects which contains a proper IP ranges.
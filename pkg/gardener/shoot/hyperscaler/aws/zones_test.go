@@ -1370,3 +1370,192 @@ func assertAWSZoneNetworkIPRanges(t *testing.T, nodesCIDR string, expectedZone v
 	assert.Equal(t, expectedZone.Public, checked.Public)
 	assert.Equal(t, expectedZone.Name, checked.Name)
 }
+
+func TestAWSZonesWithSubnetLayout(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenNodesCidr   string
+		givenZoneNames   []string
+		givenLayout      networking.SubnetLayout
+		expectedAwsZones []v1alpha1.Zone
+	}{
+		"AWS kyma-weighted layout is the same as the default one": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{
+				"eu-central-1a",
+				"eu-central-1b",
+			},
+			givenLayout: networking.DefaultSubnetLayout(),
+			expectedAwsZones: []v1alpha1.Zone{
+				{
+					Name:     "eu-central-1a",
+					Workers:  "10.250.0.0/19",
+					Public:   "10.250.32.0/20",
+					Internal: "10.250.48.0/20",
+				},
+				{
+					Name:     "eu-central-1b",
+					Workers:  "10.250.64.0/19",
+					Public:   "10.250.96.0/20",
+					Internal: "10.250.112.0/20",
+				},
+			},
+		},
+		"AWS uniform layout and 10.250.0.0/16": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{
+				"eu-central-1a",
+				"eu-central-1b",
+				"eu-central-1c",
+			},
+			givenLayout: networking.SubnetLayout{Strategy: networking.SubnetLayoutUniform},
+			expectedAwsZones: []v1alpha1.Zone{
+				{
+					Name:     "eu-central-1a",
+					Workers:  "10.250.0.0/20",
+					Public:   "10.250.16.0/21",
+					Internal: "10.250.24.0/21",
+				},
+				{
+					Name:     "eu-central-1b",
+					Workers:  "10.250.32.0/20",
+					Public:   "10.250.48.0/21",
+					Internal: "10.250.56.0/21",
+				},
+				{
+					Name:     "eu-central-1c",
+					Workers:  "10.250.64.0/20",
+					Public:   "10.250.80.0/21",
+					Internal: "10.250.88.0/21",
+				},
+			},
+		},
+		"AWS uniform layout with 8 zones and 10.250.0.0/22": {
+			givenNodesCidr: "10.250.0.0/22",
+			givenZoneNames: []string{
+				"eu-central-1a",
+				"eu-central-1b",
+				"eu-central-1c",
+				"eu-central-1d",
+				"eu-central-1e",
+				"eu-central-1f",
+				"eu-central-1g",
+				"eu-central-1h",
+			},
+			givenLayout: networking.SubnetLayout{Strategy: networking.SubnetLayoutUniform},
+			expectedAwsZones: []v1alpha1.Zone{
+				{Name: "eu-central-1a", Workers: "10.250.0.0/26", Public: "10.250.0.64/27", Internal: "10.250.0.96/27"},
+				{Name: "eu-central-1b", Workers: "10.250.0.128/26", Public: "10.250.0.192/27", Internal: "10.250.0.224/27"},
+				{Name: "eu-central-1c", Workers: "10.250.1.0/26", Public: "10.250.1.64/27", Internal: "10.250.1.96/27"},
+				{Name: "eu-central-1d", Workers: "10.250.1.128/26", Public: "10.250.1.192/27", Internal: "10.250.1.224/27"},
+				{Name: "eu-central-1e", Workers: "10.250.2.0/26", Public: "10.250.2.64/27", Internal: "10.250.2.96/27"},
+				{Name: "eu-central-1f", Workers: "10.250.2.128/26", Public: "10.250.2.192/27", Internal: "10.250.2.224/27"},
+				{Name: "eu-central-1g", Workers: "10.250.3.0/26", Public: "10.250.3.64/27", Internal: "10.250.3.96/27"},
+				{Name: "eu-central-1h", Workers: "10.250.3.128/26", Public: "10.250.3.192/27", Internal: "10.250.3.224/27"},
+			},
+		},
+		"AWS custom layout and 10.250.0.0/16": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{
+				"eu-central-1a",
+				"eu-central-1b",
+			},
+			givenLayout: networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{19, 22}},
+			expectedAwsZones: []v1alpha1.Zone{
+				{
+					Name:     "eu-central-1a",
+					Workers:  "10.250.0.0/19",
+					Public:   "10.250.32.0/20",
+					Internal: "10.250.48.0/20",
+				},
+				{
+					Name:     "eu-central-1b",
+					Workers:  "10.250.64.0/22",
+					Public:   "10.250.68.0/23",
+					Internal: "10.250.70.0/23",
+				},
+			},
+		},
+		"AWS custom layout aligns smaller zone followed by bigger one": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{
+				"eu-central-1a",
+				"eu-central-1b",
+			},
+			givenLayout: networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{22, 19}},
+			expectedAwsZones: []v1alpha1.Zone{
+				{
+					Name:     "eu-central-1a",
+					Workers:  "10.250.0.0/22",
+					Public:   "10.250.4.0/23",
+					Internal: "10.250.6.0/23",
+				},
+				{
+					Name:     "eu-central-1b",
+					Workers:  "10.250.64.0/19",
+					Public:   "10.250.96.0/20",
+					Internal: "10.250.112.0/20",
+				},
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			zones, err := generateZones(tcase.givenNodesCidr, tcase.givenZoneNames, tcase.givenLayout)
+
+			assert.NoError(t, err)
+			assert.Equal(t, len(tcase.expectedAwsZones), len(zones))
+
+			for i, expectedZone := range tcase.expectedAwsZones {
+				assert.Equal(t, expectedZone.Name, zones[i].Name)
+				assertAWSZoneNetworkIPRanges(t, tcase.givenNodesCidr, expectedZone, zones[i])
+			}
+		})
+	}
+}
+
+func TestAWSZonesWithInvalidSubnetLayout(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenNodesCidr string
+		givenZoneNames []string
+		givenLayout    networking.SubnetLayout
+		message        string
+	}{
+		"AWS should return error when layout is not supported": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{"eu-central-1a"},
+			givenLayout:    networking.SubnetLayout{Strategy: "random"},
+			message:        `subnet layout "random" is not supported`,
+		},
+		"AWS should return error when custom layout misses prefix lengths": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{"eu-central-1a", "eu-central-1b"},
+			givenLayout:    networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{19}},
+			message:        "custom subnet layout defines prefix lengths for 1 zones, but 2 zones are used",
+		},
+		"AWS should return error when custom zones do not fit into nodes CIDR": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{"eu-central-1a", "eu-central-1b"},
+			givenLayout:    networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{17, 17}},
+			message:        "failed to allocate zone subnets",
+		},
+		"AWS should return error when custom zone is duplicated": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{"eu-central-1a", "eu-central-1a"},
+			givenLayout:    networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{19, 19}},
+			message:        "zone name eu-central-1a is duplicated",
+		},
+		"AWS should return error when uniform layout has prefix lengths": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{"eu-central-1a"},
+			givenLayout:    networking.SubnetLayout{Strategy: networking.SubnetLayoutUniform, ZonePrefixLengths: []int{19}},
+			message:        "zone prefix lengths are supported only by the custom subnet layout",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			zones, err := generateZones(tcase.givenNodesCidr, tcase.givenZoneNames, tcase.givenLayout)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tcase.message)
+			assert.Equal(t, 0, len(zones))
+		})
+	}
+}
@@ -1,16 +1,23 @@
 package azure
 
 import (
+	"bytes"
 	"encoding/json"
+	"slices"
+
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/workers"
+	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const infrastructureConfigKind = "InfrastructureConfig"
 const controlPlaneConfigKind = "ControlPlaneConfig"
+const workerConfigKind = "WorkerConfig"
 const apiVersion = "azure.provider.extensions.gardener.cloud/v1alpha1"
 
-func GetInfrastructureConfig(workerCIDR string, zones []string) ([]byte, error) {
-	config, err := NewInfrastructureConfig(workerCIDR, zones)
+func GetInfrastructureConfig(workerCIDR string, zones []string, layout networking.SubnetLayout) ([]byte, error) {
+	config, err := NewInfrastructureConfig(workerCIDR, zones, layout)
 
 	if err != nil {
 		return nil, err
@@ -19,8 +26,8 @@ func GetInfrastructureConfig(workerCIDR string, zones []string) ([]byte, error)
 	return json.Marshal(config)
 }
 
-func GetInfrastructureConfigForPatch(workersCidr string, zones []string, existingInfrastructureConfigBytes []byte) ([]byte, error) {
-	newConfig, err := NewInfrastructureConfigForPatch(workersCidr, zones, existingInfrastructureConfigBytes)
+func GetInfrastructureConfigForPatch(workersCidr string, zones []string, layout networking.SubnetLayout, existingInfrastructureConfigBytes []byte) ([]byte, error) {
+	newConfig, err := NewInfrastructureConfigForPatch(workersCidr, zones, layout, existingInfrastructureConfigBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +39,15 @@ func GetControlPlaneConfig(_ []string) ([]byte, error) {
 	return json.Marshal(NewControlPlaneConfig())
 }
 
+func GetWorkerConfig(existingWorkerConfigBytes []byte, tuning workers.Tuning) ([]byte, error) {
+	config, err := NewWorkerConfig(existingWorkerConfigBytes, tuning)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(config)
+}
+
 func NewControlPlaneConfig() *ControlPlaneConfig {
 	return &ControlPlaneConfig{
 		TypeMeta: v1.TypeMeta{
@@ -50,11 +66,11 @@ func DecodeInfrastructureConfig(data []byte) (*InfrastructureConfig, error) {
 	return infrastructureConfig, nil
 }
 
-func NewInfrastructureConfig(workerCIDR string, zones []string) (InfrastructureConfig, error) {
+func NewInfrastructureConfig(workerCIDR string, zones []string, layout networking.SubnetLayout) (InfrastructureConfig, error) {
 	// All standard Azure shoots are zoned.
 	// No zones - old Azure lite shoots where config should be preserved.
 
-	azureZones, err := generateAzureZones(workerCIDR, zones)
+	azureZones, err := generateZones(workerCIDR, zones, layout)
 	if err != nil {
 		return InfrastructureConfig{}, err
 	}
@@ -78,32 +94,101 @@ func NewInfrastructureConfig(workerCIDR string, zones []string) (InfrastructureC
 	return azureConfig, nil
 }
 
-func NewInfrastructureConfigForPatch(workersCidr string, zones []string, existingInfrastructureConfigBytes []byte) (InfrastructureConfig, error) {
-	newConfig, err := NewInfrastructureConfig(workersCidr, zones)
+// NewInfrastructureConfigForPatch keeps subnets of the existing zones untouched, the subnet layout is applied only to the added zones.
+func NewInfrastructureConfigForPatch(workersCidr string, zones []string, layout networking.SubnetLayout, existingInfrastructureConfigBytes []byte) (InfrastructureConfig, error) {
+	existingInfrastructureConfig, err := DecodeInfrastructureConfig(existingInfrastructureConfigBytes)
+
 	if err != nil {
 		return InfrastructureConfig{}, err
 	}
 
-	existingInfrastructureConfig, err := DecodeInfrastructureConfig(existingInfrastructureConfigBytes)
-
+	azureZones, err := generateZonesForPatch(workersCidr, zones, layout, existingInfrastructureConfig.Networks.Zones)
 	if err != nil {
 		return InfrastructureConfig{}, err
 	}
-	for _, zone := range existingInfrastructureConfig.Networks.Zones {
-		for i := 0; i < len(newConfig.Networks.Zones); i++ {
-			newZone := &newConfig.Networks.Zones[i]
-			if newZone.Name == zone.Name {
-				newZone.NatGateway = zone.NatGateway
-				newZone.ServiceEndpoints = zone.ServiceEndpoints
+
+	if err := verifyZonesDoNotOverlap(azureZones); err != nil {
+		return InfrastructureConfig{}, err
+	}
+
+	return InfrastructureConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       infrastructureConfigKind,
+			APIVersion: apiVersion,
+		},
+		ResourceGroup: existingInfrastructureConfig.ResourceGroup,
+		Networks: NetworkConfig{
+			VNet:             existingInfrastructureConfig.Networks.VNet,
+			Zones:            azureZones,
+			ServiceEndpoints: existingInfrastructureConfig.Networks.ServiceEndpoints,
+			NatGateway:       existingInfrastructureConfig.Networks.NatGateway,
+			Workers:          existingInfrastructureConfig.Networks.Workers,
+		},
+		Zoned: len(zones) > 0,
+	}, nil
+}
+
+func verifyZonesDoNotOverlap(zones []Zone) error {
+	for i := 0; i < len(zones); i++ {
+		for j := i + 1; j < len(zones); j++ {
+			overlaps, err := networking.Overlaps(zones[i].CIDR, zones[j].CIDR)
+			if err != nil {
+				return err
+			}
+
+			if overlaps {
+				return errors.Errorf("subnet %s of zone %d overlaps with subnet %s of zone %d", zones[i].CIDR, zones[i].Name, zones[j].CIDR, zones[j].Name)
 			}
 		}
 	}
 
-	newConfig.ResourceGroup = existingInfrastructureConfig.ResourceGroup
-	newConfig.Networks.VNet = existingInfrastructureConfig.Networks.VNet
-	newConfig.Networks.ServiceEndpoints = existingInfrastructureConfig.Networks.ServiceEndpoints
-	newConfig.Networks.NatGateway = existingInfrastructureConfig.Networks.NatGateway
-	newConfig.Networks.Workers = existingInfrastructureConfig.Networks.Workers
+	return nil
+}
+
+// NewWorkerConfig merges the data volume tuning into the worker config set on the Runtime CR, all other settings are kept.
+// The worker config types are copied from the provider extension, unknown fields are rejected so that no settings are dropped silently.
+func NewWorkerConfig(existingWorkerConfigBytes []byte, tuning workers.Tuning) (*WorkerConfig, error) {
+	if tuning.Volume != nil {
+		return nil, errors.New("root volume tuning is not supported on Azure")
+	}
+
+	if tuning.LocalSSDInterface != nil {
+		return nil, errors.New("local SSD interface is not supported on Azure")
+	}
+
+	config := &WorkerConfig{}
+
+	if len(existingWorkerConfigBytes) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(existingWorkerConfigBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(config); err != nil {
+			return nil, errors.Wrap(err, "failed to decode worker config")
+		}
+	}
+
+	config.TypeMeta = v1.TypeMeta{
+		Kind:       workerConfigKind,
+		APIVersion: apiVersion,
+	}
+
+	for _, dataVolumeTuning := range tuning.DataVolumes {
+		if dataVolumeTuning.IOPS != nil || dataVolumeTuning.Throughput != nil {
+			return nil, errors.Errorf("IOPS and throughput of data volume %s are not supported on Azure", dataVolumeTuning.Name)
+		}
+
+		index := slices.IndexFunc(config.DataVolumes, func(dataVolume DataVolume) bool {
+			return dataVolume.Name == dataVolumeTuning.Name
+		})
+
+		if index == -1 {
+			config.DataVolumes = append(config.DataVolumes, DataVolume{Name: dataVolumeTuning.Name})
+			index = len(config.DataVolumes) - 1
+		}
+
+		if dataVolumeTuning.SourceImage != nil {
+			config.DataVolumes[index].ImageRef = &Image{URN: dataVolumeTuning.SourceImage}
+		}
+	}
 
-	return newConfig, nil
+	return config, nil
 }
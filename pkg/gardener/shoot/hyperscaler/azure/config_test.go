@@ -5,6 +5,8 @@ import (
 	"k8s.io/utils/ptr"
 	"testing"
 
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/workers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -125,7 +127,7 @@ func TestInfrastructureConfig(t *testing.T) {
 	} {
 		t.Run(tname, func(t *testing.T) {
 			// when
-			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenVnetCidr, tcase.givenZoneNames)
+			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenVnetCidr, tcase.givenZoneNames, networking.DefaultSubnetLayout())
 
 			// then
 			assert.NoError(t, err)
@@ -191,7 +193,7 @@ func TestFailedInfrastructureConfig(t *testing.T) {
 	} {
 		t.Run(tname, func(t *testing.T) {
 			// when
-			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenVnetCidr, tcase.givenZoneNames)
+			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenVnetCidr, tcase.givenZoneNames, networking.DefaultSubnetLayout())
 
 			// then
 			assert.Error(t, err)
@@ -281,7 +283,7 @@ func TestInfrastructureConfigPatch(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenVnetCidr, givenZoneNames, existingInfrastructureConfigBytes)
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenVnetCidr, givenZoneNames, networking.DefaultSubnetLayout(), existingInfrastructureConfigBytes)
 
 		// then
 		assert.NoError(t, err)
@@ -313,6 +315,114 @@ func TestInfrastructureConfigPatch(t *testing.T) {
 		assert.Equal(t, existingInfrastructureConfig.Networks.Zones[0].CIDR, infrastructureConfig.Networks.Zones[0].CIDR)
 	})
 
+	t.Run("Keep subnets of existing zones when patching with a custom subnet layout", func(t *testing.T) {
+		// given
+		existingInfrastructureConfigBytes, err := json.Marshal(existingInfrastructureConfig)
+		require.NoError(t, err)
+		layout := networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{25, 26, 26}}
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenVnetCidr, givenZoneNames, layout, existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		require.Len(t, infrastructureConfig.Networks.Zones, 3)
+		assert.Equal(t, "10.250.0.0/25", infrastructureConfig.Networks.Zones[0].CIDR)
+		assert.Equal(t, "10.250.0.128/26", infrastructureConfig.Networks.Zones[1].CIDR)
+		assert.Equal(t, "10.250.0.192/26", infrastructureConfig.Networks.Zones[2].CIDR)
+	})
+
+	t.Run("Allocate added zones after existing zones when their subnets collide", func(t *testing.T) {
+		// given
+		existingInfrastructureConfigBytes, err := json.Marshal(existingInfrastructureConfig)
+		require.NoError(t, err)
+		layout := networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{26, 26, 26}}
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenVnetCidr, givenZoneNames, layout, existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		require.Len(t, infrastructureConfig.Networks.Zones, 3)
+		assert.Equal(t, "10.250.0.0/25", infrastructureConfig.Networks.Zones[0].CIDR)
+		assert.Equal(t, "10.250.0.128/26", infrastructureConfig.Networks.Zones[1].CIDR)
+		assert.Equal(t, "10.250.0.192/26", infrastructureConfig.Networks.Zones[2].CIDR)
+	})
+
+	t.Run("Allocate added zones after existing zones built with another layout than the default", func(t *testing.T) {
+		// given
+		existingConfig := existingInfrastructureConfig
+		existingConfig.Networks.Zones = []Zone{newZone(1, "10.250.0.0/24")}
+		existingInfrastructureConfigBytes, err := json.Marshal(existingConfig)
+		require.NoError(t, err)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenVnetCidr, givenZoneNames, networking.DefaultSubnetLayout(), existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		require.Len(t, infrastructureConfig.Networks.Zones, 3)
+		assert.Equal(t, "10.250.0.0/24", infrastructureConfig.Networks.Zones[0].CIDR)
+		assert.Equal(t, "10.250.1.0/25", infrastructureConfig.Networks.Zones[1].CIDR)
+		assert.Equal(t, "10.250.1.128/25", infrastructureConfig.Networks.Zones[2].CIDR)
+	})
+
+	t.Run("Add zones when the subnet layout does not fit the full list of zones", func(t *testing.T) {
+		// given
+		existingInfrastructureConfigBytes, err := json.Marshal(existingInfrastructureConfig)
+		require.NoError(t, err)
+
+		// the first zone of the layout takes the whole nodes CIDR
+		layout := networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{22, 26, 26}}
+		_, err = NewInfrastructureConfig(givenVnetCidr, givenZoneNames, layout)
+		require.Error(t, err)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenVnetCidr, givenZoneNames, layout, existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		require.Len(t, infrastructureConfig.Networks.Zones, 3)
+		assert.Equal(t, "10.250.0.0/25", infrastructureConfig.Networks.Zones[0].CIDR)
+		assert.Equal(t, "10.250.0.128/26", infrastructureConfig.Networks.Zones[1].CIDR)
+		assert.Equal(t, "10.250.0.192/26", infrastructureConfig.Networks.Zones[2].CIDR)
+	})
+
+	t.Run("Fail to create Infrastructure config for patch when added zones do not fit after existing zones", func(t *testing.T) {
+		// given
+		existingConfig := existingInfrastructureConfig
+		existingConfig.Networks.Zones = []Zone{newZone(1, "10.250.0.0/23"), newZone(2, "10.250.2.0/23")}
+		existingInfrastructureConfigBytes, err := json.Marshal(existingConfig)
+		require.NoError(t, err)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(givenVnetCidr, givenZoneNames, networking.DefaultSubnetLayout(), existingInfrastructureConfigBytes)
+
+		// then
+		assert.ErrorContains(t, err, "failed to allocate subnet for added zone 3")
+		assert.Nil(t, infrastructureConfigBytes)
+	})
+
 	for tname, tcase := range map[string]struct {
 		givenVnetCidr  string
 		givenZoneNames []string
@@ -356,7 +466,7 @@ func TestInfrastructureConfigPatch(t *testing.T) {
 			// when
 			existingInfrastructureConfigBytes, err := json.Marshal(existingInfrastructureConfig)
 			require.NoError(t, err)
-			infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(tcase.givenVnetCidr, tcase.givenZoneNames, existingInfrastructureConfigBytes)
+			infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(tcase.givenVnetCidr, tcase.givenZoneNames, networking.DefaultSubnetLayout(), existingInfrastructureConfigBytes)
 
 			// then
 			assert.Error(t, err)
@@ -371,3 +481,69 @@ func assertAzureZoneCidrs(t *testing.T, expectedZone Zone, actualZone Zone) {
 	assert.Equal(t, expectedZone.NatGateway.Enabled, actualZone.NatGateway.Enabled)
 	assert.Equal(t, expectedZone.NatGateway.IdleConnectionTimeoutMinutes, actualZone.NatGateway.IdleConnectionTimeoutMinutes)
 }
+
+func TestWorkerConfig(t *testing.T) {
+	t.Run("Merge data volume tuning into existing worker config", func(t *testing.T) {
+		// given
+		existingConfigBytes := []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","nodeTemplate":{"capacity":{"cpu":"2"}},"dataVolumes":[{"name":"data","imageRef":{"urn":"publisher:offer:sku:1.0.0"}}]}`)
+		tuning := workers.Tuning{
+			DataVolumes: []workers.DataVolumeTuning{
+				{Name: "data", SourceImage: ptr.To("publisher:offer:sku:2.0.0")},
+				{Name: "cache", SourceImage: ptr.To("publisher:offer:sku:3.0.0")},
+			},
+		}
+
+		// when
+		configBytes, err := GetWorkerConfig(existingConfigBytes, tuning)
+
+		// then
+		require.NoError(t, err)
+
+		var config WorkerConfig
+		err = json.Unmarshal(configBytes, &config)
+		require.NoError(t, err)
+
+		assert.Equal(t, workerConfigKind, config.Kind)
+		assert.Equal(t, apiVersion, config.APIVersion)
+		assert.Equal(t, "2", config.NodeTemplate.Capacity.Cpu().String())
+		require.Len(t, config.DataVolumes, 2)
+		assert.Equal(t, "data", config.DataVolumes[0].Name)
+		assert.Equal(t, "publisher:offer:sku:2.0.0", *config.DataVolumes[0].ImageRef.URN)
+		assert.Equal(t, "cache", config.DataVolumes[1].Name)
+		assert.Equal(t, "publisher:offer:sku:3.0.0", *config.DataVolumes[1].ImageRef.URN)
+	})
+
+	for tname, tc := range map[string]struct {
+		existingConfig string
+		tuning         workers.Tuning
+		expectedError  string
+	}{
+		"Reject root volume tuning": {
+			tuning:        workers.Tuning{Volume: &workers.VolumeTuning{IOPS: ptr.To(int64(3000))}},
+			expectedError: "root volume tuning is not supported on Azure",
+		},
+		"Reject local SSD interface": {
+			tuning:        workers.Tuning{LocalSSDInterface: ptr.To("NVME")},
+			expectedError: "local SSD interface is not supported on Azure",
+		},
+		"Reject data volume throughput": {
+			tuning: workers.Tuning{DataVolumes: []workers.DataVolumeTuning{
+				{Name: "data", VolumeTuning: workers.VolumeTuning{Throughput: ptr.To(int64(200))}},
+			}},
+			expectedError: "IOPS and throughput of data volume data are not supported on Azure",
+		},
+		"Reject worker config with settings unknown to KIM": {
+			existingConfig: `{"dataVolumes":[{"name":"data"}],"unknown":true}`,
+			tuning:         workers.Tuning{DataVolumes: []workers.DataVolumeTuning{{Name: "data"}}},
+			expectedError:  "failed to decode worker config",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			_, err := NewWorkerConfig([]byte(tc.existingConfig), tc.tuning)
+
+			// then
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
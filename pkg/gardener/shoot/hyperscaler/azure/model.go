@@ -1,6 +1,9 @@
 package azure
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // This types are copied from https://github.com/gardener/gardener-extensions/blob/master/controllers/provider-azure/pkg/apis/azure/types_infrastructure.go as it does not contain json tags
 
@@ -102,3 +105,54 @@ type CloudControllerManagerConfig struct {
 	// FeatureGates contains information about enabled feature gates.
 	FeatureGates map[string]bool
 }
+
+// This types are copied from https://github.com/gardener/gardener-extension-provider-azure/blob/master/pkg/apis/azure/v1alpha1/types_worker.go
+
+// WorkerConfig contains configuration settings for the worker nodes.
+type WorkerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// NodeTemplate contains resource information of the machine which is used by Cluster Autoscaler to generate nodeTemplate during scaling a nodeGroup from zero.
+	// +optional
+	NodeTemplate *extensionsv1alpha1.NodeTemplate `json:"nodeTemplate,omitempty"`
+	// DiagnosticsProfile specifies boot diagnostic options.
+	// +optional
+	DiagnosticsProfile *DiagnosticsProfile `json:"diagnosticsProfile,omitempty"`
+	// DataVolumes contains configuration for the additional disks attached to VMs.
+	// +optional
+	DataVolumes []DataVolume `json:"dataVolumes,omitempty"`
+}
+
+// DiagnosticsProfile specifies boot diagnostic options.
+type DiagnosticsProfile struct {
+	// Enabled configures boot diagnostics to be stored or not.
+	Enabled bool `json:"enabled,omitempty"`
+	// StorageURI is the URI of the storage account to use for storing console output and screenshot.
+	// If not specified azure managed storage will be used.
+	// +optional
+	StorageURI *string `json:"storageURI,omitempty"`
+}
+
+// DataVolume contains configuration for data volumes attached to VMs.
+type DataVolume struct {
+	// Name is the name of the data volume this configuration applies to.
+	Name string `json:"name"`
+	// ImageRef defines the dataVolume source image.
+	// +optional
+	ImageRef *Image `json:"imageRef,omitempty"`
+}
+
+// Image identifies the azure image.
+type Image struct {
+	// URN is the uniform resource name of the image, it has the format 'publisher:offer:sku:version'.
+	// +optional
+	URN *string `json:"urn,omitempty"`
+	// ID is the VM image ID
+	// +optional
+	ID *string `json:"id,omitempty"`
+	// CommunityGalleryImageID is the Community Image Gallery image id, it has the format '/CommunityGalleries/myGallery/Images/myImage/Versions/myVersion'
+	// +optional
+	CommunityGalleryImageID *string `json:"communityGalleryImageID,omitempty"`
+	// SharedGalleryImageID is the Shared Image Gallery image id, it has the format '/SharedGalleries/sharedGalleryName/Images/sharedGalleryImageName/Versions/sharedGalleryImageVersionName'
+	// +optional
+	SharedGalleryImageID *string `json:"sharedGalleryImageID,omitempty"`
+}
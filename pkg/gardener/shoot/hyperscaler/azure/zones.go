@@ -1,9 +1,11 @@
 package azure
 
 import (
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
 	"github.com/pkg/errors"
 	"math/big"
 	"net/netip"
+	"slices"
 	"strconv"
 )
 
@@ -15,6 +17,150 @@ const (
 	minNumberOfZones                = 1
 )
 
+// generateZones creates zones according to the subnet layout.
+// Azure zones have a single subnet, so kyma-weighted and uniform layouts are the same: every zone gets 1/8 of the nodes CIDR.
+func generateZones(workerCidr string, zoneNames []string, layout networking.SubnetLayout) ([]Zone, error) {
+	if err := layout.Validate(len(zoneNames)); err != nil {
+		return nil, err
+	}
+
+	if layout.Strategy == networking.SubnetLayoutCustom {
+		return generateAzureZonesFromPrefixes(workerCidr, zoneNames, layout.ZonePrefixLengths[:len(zoneNames)])
+	}
+
+	return generateAzureZones(workerCidr, zoneNames)
+}
+
+// generateZonesForPatch keeps the existing zones, subnets of the added zones are allocated according to the subnet layout after the highest existing subnet.
+// Existing zones may have been built with another layout, so the layout is not applied to the full list of zones.
+func generateZonesForPatch(workerCidr string, zoneNames []string, layout networking.SubnetLayout, existingZones []Zone) ([]Zone, error) {
+	// prefix lengths of the custom layout are verified for the added zones only
+	if err := layout.Validate(0); err != nil {
+		return nil, err
+	}
+
+	cidr, err := netip.ParsePrefix(workerCidr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse worker network CIDR")
+	}
+
+	if cidr.Bits() > 24 {
+		return nil, errors.New("CIDR prefix length must be less than or equal to 24")
+	}
+
+	if cidr.Bits() < 16 {
+		return nil, errors.New("CIDR prefix length must be bigger than or equal to 16")
+	}
+
+	convertedZones, err := convertZoneNames(zoneNames)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert zone names")
+	}
+
+	var taken []netip.Prefix
+	for _, zone := range existingZones {
+		subnets, err := networking.ParsePrefixes(zone.CIDR)
+		if err != nil {
+			return nil, err
+		}
+		taken = append(taken, subnets...)
+	}
+
+	var zones []Zone
+	processed := make(map[int]bool)
+
+	for i, name := range convertedZones {
+		if _, ok := processed[name]; ok {
+			return nil, errors.Errorf("zone name %d is duplicated", name)
+		}
+		processed[name] = true
+
+		existingZoneIndex := slices.IndexFunc(existingZones, func(zone Zone) bool {
+			return zone.Name == name
+		})
+
+		if existingZoneIndex != -1 {
+			zones = append(zones, existingZones[existingZoneIndex])
+			continue
+		}
+
+		prefixLength := cidr.Bits() + subNetworkBitsSize
+		if layout.Strategy == networking.SubnetLayoutCustom {
+			if i >= len(layout.ZonePrefixLengths) {
+				return nil, errors.Errorf("custom subnet layout defines prefix lengths for %d zones, but %d zones are used", len(layout.ZonePrefixLengths), i+1)
+			}
+			prefixLength = layout.ZonePrefixLengths[i]
+		}
+
+		subnets, err := networking.AllocateBlocksAfter(cidr, taken, []int{prefixLength})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to allocate subnet for added zone %d", name)
+		}
+		taken = append(taken, subnets...)
+
+		zones = append(zones, newZone(name, subnets[0].String()))
+	}
+
+	return zones, nil
+}
+
+func generateAzureZonesFromPrefixes(workerCidr string, zoneNames []string, prefixLengths []int) ([]Zone, error) {
+	if len(zoneNames) > maxNumberOfZones {
+		return nil, errors.New("Number of networking zones must be between 0 and 8")
+	}
+
+	cidr, err := netip.ParsePrefix(workerCidr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse worker network CIDR")
+	}
+
+	if cidr.Bits() > 24 {
+		return nil, errors.New("CIDR prefix length must be less than or equal to 24")
+	}
+
+	if cidr.Bits() < 16 {
+		return nil, errors.New("CIDR prefix length must be bigger than or equal to 16")
+	}
+
+	convertedZones, err := convertZoneNames(zoneNames)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert zone names")
+	}
+
+	blocks, err := networking.AllocateBlocks(cidr, prefixLengths)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to allocate zone subnets")
+	}
+
+	var zones []Zone
+	processed := make(map[int]bool)
+
+	for i, name := range convertedZones {
+		if _, ok := processed[name]; ok {
+			return nil, errors.Errorf("zone name %d is duplicated", name)
+		}
+		processed[name] = true
+
+		zones = append(zones, newZone(name, blocks[i].String()))
+	}
+
+	return zones, nil
+}
+
+func newZone(name int, cidr string) Zone {
+	return Zone{
+		Name: name,
+		CIDR: cidr,
+		NatGateway: &NatGateway{
+			// There are existing Azure clusters which were created before NAT gateway support,
+			// and they were migrated to HA with all zones having enableNatGateway: false .
+			// But for new Azure runtimes, enableNatGateway for all zones is always true
+			Enabled:                      true,
+			IdleConnectionTimeoutMinutes: defaultConnectionTimeOutMinutes,
+		},
+	}
+}
+
 func generateAzureZones(workerCidr string, zoneNames []string) ([]Zone, error) {
 	numZones := len(zoneNames)
 	// old Azure lite clusters have no zones in InfrastructureConfig
@@ -71,17 +217,7 @@ func generateAzureZones(workerCidr string, zoneNames []string) ([]Zone, error) {
 		}
 
 		zoneIPValue.Add(zoneIPValue, delta)
-		zones = append(zones, Zone{
-			Name: name,
-			CIDR: zoneWorkerCidr.String(),
-			NatGateway: &NatGateway{
-				// There are existing Azure clusters which were created before NAT gateway support,
-				// and they were migrated to HA with all zones having enableNatGateway: false .
-				// But for new Azure runtimes, enableNatGateway for all zones is always true
-				Enabled:                      true,
-				IdleConnectionTimeoutMinutes: defaultConnectionTimeOutMinutes,
-			},
-		})
+		zones = append(zones, newZone(name, zoneWorkerCidr.String()))
 	}
 	return zones, nil
 }
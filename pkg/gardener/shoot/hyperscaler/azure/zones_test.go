@@ -466,3 +466,70 @@ func assertAzureZone(t *testing.T, nodesCIDR string, expected Zone, verified Zon
 	assert.Equal(t, true, verified.NatGateway.Enabled)
 	assert.Equal(t, defaultConnectionTimeOutMinutes, verified.NatGateway.IdleConnectionTimeoutMinutes)
 }
+
+func TestAzureZonesWithSubnetLayout(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenNodesCidr     string
+		givenZoneNames     []string
+		givenLayout        networking.SubnetLayout
+		expectedAzureZones []Zone
+	}{
+		"Azure kyma-weighted layout and 10.250.0.0/22": {
+			givenNodesCidr: "10.250.0.0/22",
+			givenZoneNames: []string{"1", "2"},
+			givenLayout:    networking.DefaultSubnetLayout(),
+			expectedAzureZones: []Zone{
+				{Name: 1, CIDR: "10.250.0.0/25"},
+				{Name: 2, CIDR: "10.250.0.128/25"},
+			},
+		},
+		"Azure uniform layout is the same as kyma-weighted": {
+			givenNodesCidr: "10.250.0.0/22",
+			givenZoneNames: []string{"1", "2"},
+			givenLayout:    networking.SubnetLayout{Strategy: networking.SubnetLayoutUniform},
+			expectedAzureZones: []Zone{
+				{Name: 1, CIDR: "10.250.0.0/25"},
+				{Name: 2, CIDR: "10.250.0.128/25"},
+			},
+		},
+		"Azure custom layout and 10.250.0.0/22": {
+			givenNodesCidr: "10.250.0.0/22",
+			givenZoneNames: []string{"1", "2", "3"},
+			givenLayout:    networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{24, 26, 24}},
+			expectedAzureZones: []Zone{
+				{Name: 1, CIDR: "10.250.0.0/24"},
+				{Name: 2, CIDR: "10.250.1.0/26"},
+				{Name: 3, CIDR: "10.250.2.0/24"},
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			zones, err := generateZones(tcase.givenNodesCidr, tcase.givenZoneNames, tcase.givenLayout)
+
+			assert.NoError(t, err)
+			require.Equal(t, len(tcase.expectedAzureZones), len(zones))
+
+			for i, expectedZone := range tcase.expectedAzureZones {
+				assertAzureZone(t, tcase.givenNodesCidr, expectedZone, zones[i])
+			}
+		})
+	}
+
+	t.Run("Azure should return error when custom zones do not fit into nodes CIDR", func(t *testing.T) {
+		layout := networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom, ZonePrefixLengths: []int{23, 23, 23}}
+
+		zones, err := generateZones("10.250.0.0/22", []string{"1", "2", "3"}, layout)
+
+		assert.ErrorContains(t, err, "failed to allocate zone subnets")
+		assert.Equal(t, 0, len(zones))
+	})
+
+	t.Run("Azure should return error when custom layout misses prefix lengths", func(t *testing.T) {
+		layout := networking.SubnetLayout{Strategy: networking.SubnetLayoutCustom}
+
+		zones, err := generateZones("10.250.0.0/22", []string{"1"}, layout)
+
+		assert.ErrorContains(t, err, "custom subnet layout defines prefix lengths for 0 zones, but 1 zones are used")
+		assert.Equal(t, 0, len(zones))
+	})
+}
@@ -5,4 +5,7 @@ const (
 	TypeAzure     = "azure"
 	TypeGCP       = "gcp"
 	TypeOpenStack = "openstack"
+	TypeAlicloud  = "alicloud"
+	// TypeLocal is Gardener's provider-local, used only on development and test landscapes
+	TypeLocal = "local"
 )
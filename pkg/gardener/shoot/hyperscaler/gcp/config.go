@@ -2,8 +2,10 @@ package gcp
 
 import (
 	"encoding/json"
+	"slices"
 
 	"github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/workers"
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -11,23 +13,96 @@ import (
 const (
 	infrastructureConfigKind = "InfrastructureConfig"
 	controlPlaneConfigKind   = "ControlPlaneConfig"
+	workerConfigKind         = "WorkerConfig"
 	apiVersion               = "gcp.provider.extensions.gardener.cloud/v1alpha1"
 )
 
-func GetInfrastructureConfig(workerCIDR string, _ []string) ([]byte, error) {
-	return json.Marshal(NewInfrastructureConfig(workerCIDR))
+// Settings contain GCP specific options set on the Runtime CR
+type Settings struct {
+	// ControlPlaneZone must be one of the worker zones, the first worker zone is used when not set
+	ControlPlaneZone string
+	CloudNAT         *CloudNATSettings
 }
 
-func GetControlPlaneConfig(zones []string) ([]byte, error) {
+type CloudNATSettings struct {
+	MinPortsPerVM              *int32
+	EndpointIndependentMapping *bool
+	NatIPNames                 []string
+}
+
+func GetInfrastructureConfig(workerCIDR string, settings Settings) ([]byte, error) {
+	return json.Marshal(NewInfrastructureConfig(workerCIDR, settings.CloudNAT))
+}
+
+func GetControlPlaneConfig(zones []string, settings Settings) ([]byte, error) {
 	if len(zones) == 0 {
 		return nil, errors.New("zones list is empty")
 	}
 
-	return json.Marshal(NewControlPlaneConfig(zones))
+	zone := zones[0]
+
+	if settings.ControlPlaneZone != "" {
+		if !slices.Contains(zones, settings.ControlPlaneZone) {
+			return nil, errors.Errorf("control plane zone %s is not one of the worker zones %v", settings.ControlPlaneZone, zones)
+		}
+		zone = settings.ControlPlaneZone
+	}
+
+	return json.Marshal(NewControlPlaneConfig(zone))
+}
+
+// GetInfrastructureConfigForPatch applies the requested CloudNAT settings to the existing config, CloudNAT settings which are not requested are kept
+func GetInfrastructureConfigForPatch(cloudNAT *CloudNATSettings, existingInfrastructureConfigBytes []byte) ([]byte, error) {
+	if cloudNAT == nil {
+		return existingInfrastructureConfigBytes, nil
+	}
+
+	var infrastructureConfig v1alpha1.InfrastructureConfig
+	if err := json.Unmarshal(existingInfrastructureConfigBytes, &infrastructureConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to decode existing infrastructure config")
+	}
+
+	if infrastructureConfig.Networks.CloudNAT == nil {
+		infrastructureConfig.Networks.CloudNAT = &v1alpha1.CloudNAT{}
+	}
+	setCloudNAT(infrastructureConfig.Networks.CloudNAT, *cloudNAT)
+
+	return json.Marshal(infrastructureConfig)
 }
 
-func NewInfrastructureConfig(workerCIDR string) v1alpha1.InfrastructureConfig {
-	return v1alpha1.InfrastructureConfig{
+// GetControlPlaneConfigForPatch keeps the existing config, the control plane zone is immutable on GCP
+func GetControlPlaneConfigForPatch(zones []string, controlPlaneZone string, existingControlPlaneConfigBytes []byte) ([]byte, error) {
+	if controlPlaneZone == "" {
+		return existingControlPlaneConfigBytes, nil
+	}
+
+	existingControlPlaneConfig, err := DecodeControlPlaneConfig(existingControlPlaneConfigBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode existing control plane config")
+	}
+
+	if existingControlPlaneConfig.Zone != controlPlaneZone {
+		return nil, errors.Errorf("control plane zone cannot be changed from %s to %s", existingControlPlaneConfig.Zone, controlPlaneZone)
+	}
+
+	if !slices.Contains(zones, controlPlaneZone) {
+		return nil, errors.Errorf("control plane zone %s is not one of the worker zones %v", controlPlaneZone, zones)
+	}
+
+	return existingControlPlaneConfigBytes, nil
+}
+
+func GetWorkerConfig(existingWorkerConfigBytes []byte, tuning workers.Tuning) ([]byte, error) {
+	config, err := NewWorkerConfig(existingWorkerConfigBytes, tuning)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(config)
+}
+
+func NewInfrastructureConfig(workerCIDR string, cloudNAT *CloudNATSettings) v1alpha1.InfrastructureConfig {
+	infrastructureConfig := v1alpha1.InfrastructureConfig{
 		TypeMeta: v1.TypeMeta{
 			Kind:       infrastructureConfigKind,
 			APIVersion: apiVersion,
@@ -39,15 +114,22 @@ func NewInfrastructureConfig(workerCIDR string) v1alpha1.InfrastructureConfig {
 			Worker:  workerCIDR,
 		},
 	}
+
+	if cloudNAT != nil {
+		infrastructureConfig.Networks.CloudNAT = &v1alpha1.CloudNAT{}
+		setCloudNAT(infrastructureConfig.Networks.CloudNAT, *cloudNAT)
+	}
+
+	return infrastructureConfig
 }
 
-func NewControlPlaneConfig(zones []string) *v1alpha1.ControlPlaneConfig {
+func NewControlPlaneConfig(zone string) *v1alpha1.ControlPlaneConfig {
 	return &v1alpha1.ControlPlaneConfig{
 		TypeMeta: v1.TypeMeta{
 			Kind:       controlPlaneConfigKind,
 			APIVersion: apiVersion,
 		},
-		Zone: zones[0],
+		Zone: zone,
 	}
 }
 
@@ -59,3 +141,74 @@ func DecodeControlPlaneConfig(data []byte) (*v1alpha1.ControlPlaneConfig, error)
 	}
 	return controlPlaneConfig, nil
 }
+
+func setCloudNAT(cloudNAT *v1alpha1.CloudNAT, settings CloudNATSettings) {
+	if settings.MinPortsPerVM != nil {
+		cloudNAT.MinPortsPerVM = settings.MinPortsPerVM
+	}
+
+	if settings.EndpointIndependentMapping != nil {
+		cloudNAT.EndpointIndependentMapping = &v1alpha1.EndpointIndependentMapping{
+			Enabled: *settings.EndpointIndependentMapping,
+		}
+	}
+
+	if settings.NatIPNames != nil {
+		cloudNAT.NatIPNames = nil
+		for _, name := range settings.NatIPNames {
+			cloudNAT.NatIPNames = append(cloudNAT.NatIPNames, v1alpha1.NatIPName{Name: name})
+		}
+	}
+}
+
+// NewWorkerConfig merges the worker tuning into the worker config set on the Runtime CR, all other settings are kept
+func NewWorkerConfig(existingWorkerConfigBytes []byte, tuning workers.Tuning) (*v1alpha1.WorkerConfig, error) {
+	if tuning.Volume != nil {
+		return nil, errors.New("root volume tuning is not supported on GCP")
+	}
+
+	config := &v1alpha1.WorkerConfig{}
+
+	if len(existingWorkerConfigBytes) > 0 {
+		if err := json.Unmarshal(existingWorkerConfigBytes, config); err != nil {
+			return nil, errors.Wrap(err, "failed to decode worker config")
+		}
+	}
+
+	config.TypeMeta = v1.TypeMeta{
+		Kind:       workerConfigKind,
+		APIVersion: apiVersion,
+	}
+
+	if tuning.LocalSSDInterface != nil {
+		if config.Volume == nil {
+			config.Volume = &v1alpha1.Volume{}
+		}
+		config.Volume.LocalSSDInterface = tuning.LocalSSDInterface
+	}
+
+	for _, dataVolumeTuning := range tuning.DataVolumes {
+		index := slices.IndexFunc(config.DataVolumes, func(dataVolume v1alpha1.DataVolume) bool {
+			return dataVolume.Name == dataVolumeTuning.Name
+		})
+
+		if index == -1 {
+			config.DataVolumes = append(config.DataVolumes, v1alpha1.DataVolume{Name: dataVolumeTuning.Name})
+			index = len(config.DataVolumes) - 1
+		}
+
+		if dataVolumeTuning.IOPS != nil {
+			config.DataVolumes[index].ProvisionedIops = dataVolumeTuning.IOPS
+		}
+
+		if dataVolumeTuning.Throughput != nil {
+			config.DataVolumes[index].ProvisionedThroughput = dataVolumeTuning.Throughput
+		}
+
+		if dataVolumeTuning.SourceImage != nil {
+			config.DataVolumes[index].SourceImage = dataVolumeTuning.SourceImage
+		}
+	}
+
+	return config, nil
+}
@@ -5,14 +5,16 @@ import (
 	"testing"
 
 	"github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/workers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 )
 
 func TestControlPlaneConfig(t *testing.T) {
 	t.Run("Create Control Plane config", func(t *testing.T) {
 		// when
-		controlPlaneConfigBytes, err := GetControlPlaneConfig([]string{"europe-west3a"})
+		controlPlaneConfigBytes, err := GetControlPlaneConfig([]string{"europe-west3a"}, Settings{})
 
 		// then
 		require.NoError(t, err)
@@ -28,23 +30,63 @@ func TestControlPlaneConfig(t *testing.T) {
 
 	t.Run("Return error when zones not provided", func(t *testing.T) {
 		// when
-		_, err := GetControlPlaneConfig([]string{})
+		_, err := GetControlPlaneConfig([]string{}, Settings{})
 
 		// then
 		require.Error(t, err)
 
 		// when
-		_, err = GetControlPlaneConfig(nil)
+		_, err = GetControlPlaneConfig(nil, Settings{})
 
 		// then
 		require.Error(t, err)
 	})
+
+	t.Run("Create Control Plane config with selected zone", func(t *testing.T) {
+		// when
+		controlPlaneConfigBytes, err := GetControlPlaneConfig([]string{"europe-west3-a", "europe-west3-b"}, Settings{ControlPlaneZone: "europe-west3-b"})
+
+		// then
+		require.NoError(t, err)
+
+		var controlPlaneConfig v1alpha1.ControlPlaneConfig
+		err = json.Unmarshal(controlPlaneConfigBytes, &controlPlaneConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, "europe-west3-b", controlPlaneConfig.Zone)
+	})
+
+	t.Run("Return error when selected zone is not a worker zone", func(t *testing.T) {
+		// when
+		_, err := GetControlPlaneConfig([]string{"europe-west3-a"}, Settings{ControlPlaneZone: "europe-west3-c"})
+
+		// then
+		assert.EqualError(t, err, "control plane zone europe-west3-c is not one of the worker zones [europe-west3-a]")
+	})
+
+	t.Run("Keep existing Control Plane config on patch", func(t *testing.T) {
+		// given
+		existingControlPlaneConfigBytes, err := GetControlPlaneConfig([]string{"europe-west3-a"}, Settings{})
+		require.NoError(t, err)
+
+		// when
+		controlPlaneConfigBytes, err := GetControlPlaneConfigForPatch([]string{"europe-west3-a", "europe-west3-b"}, "europe-west3-a", existingControlPlaneConfigBytes)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, existingControlPlaneConfigBytes, controlPlaneConfigBytes)
+
+		// when
+		_, err = GetControlPlaneConfigForPatch([]string{"europe-west3-a", "europe-west3-b"}, "europe-west3-b", existingControlPlaneConfigBytes)
+
+		// then
+		assert.EqualError(t, err, "control plane zone cannot be changed from europe-west3-a to europe-west3-b")
+	})
 }
 
 func TestInfrastructureConfig(t *testing.T) {
 	t.Run("Create Infrastructure config", func(t *testing.T) {
 		// when
-		infrastructureConfigBytes, err := GetInfrastructureConfig("10.250.0.0/22", nil)
+		infrastructureConfigBytes, err := GetInfrastructureConfig("10.250.0.0/22", Settings{})
 
 		// then
 		require.NoError(t, err)
@@ -55,5 +97,81 @@ func TestInfrastructureConfig(t *testing.T) {
 
 		assert.Equal(t, "10.250.0.0/22", infrastructureConfig.Networks.Workers)
 		assert.Equal(t, "10.250.0.0/22", infrastructureConfig.Networks.Worker)
+		assert.Nil(t, infrastructureConfig.Networks.CloudNAT)
+	})
+
+	t.Run("Create Infrastructure config with CloudNAT settings", func(t *testing.T) {
+		// when
+		infrastructureConfig := NewInfrastructureConfig("10.250.0.0/22", &CloudNATSettings{
+			MinPortsPerVM:              ptr.To(int32(2048)),
+			EndpointIndependentMapping: ptr.To(true),
+			NatIPNames:                 []string{"nat-ip-1", "nat-ip-2"},
+		})
+
+		// then
+		require.NotNil(t, infrastructureConfig.Networks.CloudNAT)
+		assert.Equal(t, int32(2048), *infrastructureConfig.Networks.CloudNAT.MinPortsPerVM)
+		assert.True(t, infrastructureConfig.Networks.CloudNAT.EndpointIndependentMapping.Enabled)
+		assert.Equal(t, []v1alpha1.NatIPName{{Name: "nat-ip-1"}, {Name: "nat-ip-2"}}, infrastructureConfig.Networks.CloudNAT.NatIPNames)
+	})
+
+	t.Run("Apply requested CloudNAT settings on patch", func(t *testing.T) {
+		// given
+		existingInfrastructureConfigBytes := []byte(`{"apiVersion":"gcp.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","networks":{"worker":"10.250.0.0/22","workers":"10.250.0.0/22","cloudNAT":{"minPortsPerVM":1024,"natIPNames":[{"name":"nat-ip-1"}]}}}`)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(&CloudNATSettings{EndpointIndependentMapping: ptr.To(false)}, existingInfrastructureConfigBytes)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		require.NoError(t, json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig))
+		assert.Equal(t, int32(1024), *infrastructureConfig.Networks.CloudNAT.MinPortsPerVM)
+		assert.Equal(t, []v1alpha1.NatIPName{{Name: "nat-ip-1"}}, infrastructureConfig.Networks.CloudNAT.NatIPNames)
+		assert.False(t, infrastructureConfig.Networks.CloudNAT.EndpointIndependentMapping.Enabled)
+	})
+}
+
+func TestWorkerConfig(t *testing.T) {
+	t.Run("Merge tuning into existing worker config", func(t *testing.T) {
+		// given
+		existingConfigBytes := []byte(`{"apiVersion":"gcp.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","minCpuPlatform":"Intel Ice Lake","dataVolumes":[{"name":"data","sourceImage":"image-1","provisionedIops":3000,"provisionedThroughput":null}]}`)
+		tuning := workers.Tuning{
+			LocalSSDInterface: ptr.To("NVME"),
+			DataVolumes: []workers.DataVolumeTuning{
+				{Name: "data", VolumeTuning: workers.VolumeTuning{Throughput: ptr.To(int64(200))}},
+				{Name: "cache", SourceImage: ptr.To("image-2")},
+			},
+		}
+
+		// when
+		configBytes, err := GetWorkerConfig(existingConfigBytes, tuning)
+
+		// then
+		require.NoError(t, err)
+
+		var config v1alpha1.WorkerConfig
+		err = json.Unmarshal(configBytes, &config)
+		require.NoError(t, err)
+
+		assert.Equal(t, workerConfigKind, config.Kind)
+		assert.Equal(t, apiVersion, config.APIVersion)
+		assert.Equal(t, "Intel Ice Lake", *config.MinCpuPlatform)
+		assert.Equal(t, "NVME", *config.Volume.LocalSSDInterface)
+		require.Len(t, config.DataVolumes, 2)
+		assert.Equal(t, "image-1", *config.DataVolumes[0].SourceImage)
+		assert.Equal(t, int64(3000), *config.DataVolumes[0].ProvisionedIops)
+		assert.Equal(t, int64(200), *config.DataVolumes[0].ProvisionedThroughput)
+		assert.Equal(t, "cache", config.DataVolumes[1].Name)
+		assert.Equal(t, "image-2", *config.DataVolumes[1].SourceImage)
+	})
+
+	t.Run("Reject root volume tuning", func(t *testing.T) {
+		// when
+		_, err := NewWorkerConfig(nil, workers.Tuning{Volume: &workers.VolumeTuning{IOPS: ptr.To(int64(3000))}})
+
+		// then
+		assert.EqualError(t, err, "root volume tuning is not supported on GCP")
 	})
 }
@@ -0,0 +1,187 @@
+package networking
+
+import (
+	"math/big"
+	"net/netip"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SubnetLayoutKymaWeighted reserves bigger subnets for the first 3 zones (Kyma worker pool) and smaller ones for zones added later.
+	SubnetLayoutKymaWeighted = "kyma-weighted"
+	// SubnetLayoutUniform splits the nodes CIDR into 8 equally sized zone subnets.
+	SubnetLayoutUniform = "uniform"
+	// SubnetLayoutCustom uses the workers subnet prefix lengths given for each zone.
+	SubnetLayoutCustom = "custom"
+
+	ipv4BitsSize = 32
+)
+
+// SubnetLayout describes how the nodes CIDR is split into zone subnets.
+type SubnetLayout struct {
+	Strategy string
+	// ZonePrefixLengths contains the prefix length of the workers subnet for every zone in the order of zones, used only by the custom strategy.
+	ZonePrefixLengths []int
+}
+
+// DefaultSubnetLayout is the layout used by all shoots created before subnet layouts were configurable.
+func DefaultSubnetLayout() SubnetLayout {
+	return SubnetLayout{Strategy: SubnetLayoutKymaWeighted}
+}
+
+func (l SubnetLayout) Validate(numberOfZones int) error {
+	switch l.Strategy {
+	case SubnetLayoutKymaWeighted, SubnetLayoutUniform:
+		if len(l.ZonePrefixLengths) != 0 {
+			return errors.Errorf("zone prefix lengths are supported only by the %s subnet layout", SubnetLayoutCustom)
+		}
+		return nil
+	case SubnetLayoutCustom:
+		if len(l.ZonePrefixLengths) < numberOfZones {
+			return errors.Errorf("custom subnet layout defines prefix lengths for %d zones, but %d zones are used", len(l.ZonePrefixLengths), numberOfZones)
+		}
+		return nil
+	default:
+		return errors.Errorf("subnet layout %q is not supported", l.Strategy)
+	}
+}
+
+// AllocateBlocks carves consecutive, non-overlapping blocks with the given prefix lengths out of the cidr.
+// Every block is aligned to its own size, so the result depends only on the cidr and the prefix lengths.
+func AllocateBlocks(cidr netip.Prefix, prefixLengths []int) ([]netip.Prefix, error) {
+	if !cidr.Addr().Is4() {
+		return nil, errors.Errorf("CIDR %s is not an IPv4 CIDR", cidr.String())
+	}
+
+	return allocateBlocksFrom(cidr, new(big.Int).SetBytes(cidr.Masked().Addr().AsSlice()), prefixLengths)
+}
+
+// AllocateBlocksAfter carves blocks like AllocateBlocks, but starts after the highest address of the taken subnets.
+// It is used for zones added to an existing shoot, whose subnets may have been built with another layout.
+func AllocateBlocksAfter(cidr netip.Prefix, taken []netip.Prefix, prefixLengths []int) ([]netip.Prefix, error) {
+	if !cidr.Addr().Is4() {
+		return nil, errors.Errorf("CIDR %s is not an IPv4 CIDR", cidr.String())
+	}
+
+	base := new(big.Int).SetBytes(cidr.Masked().Addr().AsSlice())
+	for _, subnet := range taken {
+		subnetEnd := new(big.Int).SetBytes(subnet.Masked().Addr().AsSlice())
+		subnetEnd.Add(subnetEnd, blockSize(subnet.Bits()))
+		if subnetEnd.Cmp(base) > 0 {
+			base = subnetEnd
+		}
+	}
+
+	return allocateBlocksFrom(cidr, base, prefixLengths)
+}
+
+func allocateBlocksFrom(cidr netip.Prefix, base *big.Int, prefixLengths []int) ([]netip.Prefix, error) {
+	start := new(big.Int).SetBytes(cidr.Masked().Addr().AsSlice())
+	end := new(big.Int).Add(start, blockSize(cidr.Bits()))
+
+	var blocks []netip.Prefix
+
+	for _, prefixLength := range prefixLengths {
+		if prefixLength < cidr.Bits() || prefixLength > ipv4BitsSize {
+			return nil, errors.Errorf("prefix length %d must be between %d and %d", prefixLength, cidr.Bits(), ipv4BitsSize)
+		}
+
+		size := blockSize(prefixLength)
+
+		// align the start of the block to its size
+		remainder := new(big.Int).Mod(base, size)
+		if remainder.Sign() != 0 {
+			base.Add(base, size)
+			base.Sub(base, remainder)
+		}
+
+		next := new(big.Int).Add(base, size)
+		if next.Cmp(end) > 0 {
+			return nil, errors.Errorf("subnets with prefix lengths %v do not fit into CIDR %s", prefixLengths, cidr.String())
+		}
+
+		addr, _ := netip.AddrFromSlice(toIPv4Bytes(base))
+		blocks = append(blocks, netip.PrefixFrom(addr, prefixLength))
+
+		base = next
+	}
+
+	return blocks, nil
+}
+
+// SplitBlock returns the subnet with the given prefix length at the given position inside the block.
+func SplitBlock(block netip.Prefix, prefixLength, index int) (netip.Prefix, error) {
+	if prefixLength < block.Bits() || prefixLength > ipv4BitsSize {
+		return netip.Prefix{}, errors.Errorf("prefix length %d must be between %d and %d", prefixLength, block.Bits(), ipv4BitsSize)
+	}
+
+	offset := new(big.Int).Mul(blockSize(prefixLength), big.NewInt(int64(index)))
+	if offset.Cmp(blockSize(block.Bits())) >= 0 {
+		return netip.Prefix{}, errors.Errorf("subnet %d with prefix length %d does not fit into %s", index, prefixLength, block.String())
+	}
+
+	base := new(big.Int).SetBytes(block.Masked().Addr().AsSlice())
+	base.Add(base, offset)
+
+	addr, _ := netip.AddrFromSlice(toIPv4Bytes(base))
+	return netip.PrefixFrom(addr, prefixLength), nil
+}
+
+// Overlaps verifies if the given CIDRs share any address.
+func Overlaps(firstCIDR, secondCIDR string) (bool, error) {
+	first, err := netip.ParsePrefix(firstCIDR)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse CIDR %s", firstCIDR)
+	}
+
+	second, err := netip.ParsePrefix(secondCIDR)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse CIDR %s", secondCIDR)
+	}
+
+	return first.Overlaps(second), nil
+}
+
+// OverlapsAny verifies if any of the subnets shares an address with any of the taken subnets.
+func OverlapsAny(subnets, taken []netip.Prefix) bool {
+	for _, subnet := range subnets {
+		for _, takenSubnet := range taken {
+			if subnet.Overlaps(takenSubnet) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParsePrefixes parses the CIDRs of subnets.
+func ParsePrefixes(cidrs ...string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse CIDR %s", cidr)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// PrefixLengths returns the prefix lengths of the subnets.
+func PrefixLengths(subnets []netip.Prefix) []int {
+	prefixLengths := make([]int, 0, len(subnets))
+	for _, subnet := range subnets {
+		prefixLengths = append(prefixLengths, subnet.Bits())
+	}
+	return prefixLengths
+}
+
+func blockSize(prefixLength int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(ipv4BitsSize-prefixLength))
+}
+
+// big.Int drops leading zero bytes, they must be restored to build a valid IPv4 address
+func toIPv4Bytes(value *big.Int) []byte {
+	return value.FillBytes(make([]byte, 4))
+}
@@ -0,0 +1,197 @@
+package networking
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnetLayoutValidate(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenLayout        SubnetLayout
+		givenNumberOfZones int
+		expectedError      string
+	}{
+		"Should accept default layout": {
+			givenLayout:        DefaultSubnetLayout(),
+			givenNumberOfZones: 3,
+		},
+		"Should accept uniform layout": {
+			givenLayout:        SubnetLayout{Strategy: SubnetLayoutUniform},
+			givenNumberOfZones: 3,
+		},
+		"Should accept custom layout with prefix length for every zone": {
+			givenLayout:        SubnetLayout{Strategy: SubnetLayoutCustom, ZonePrefixLengths: []int{19, 20, 21}},
+			givenNumberOfZones: 3,
+		},
+		"Should reject custom layout with missing prefix lengths": {
+			givenLayout:        SubnetLayout{Strategy: SubnetLayoutCustom, ZonePrefixLengths: []int{19}},
+			givenNumberOfZones: 2,
+			expectedError:      "custom subnet layout defines prefix lengths for 1 zones, but 2 zones are used",
+		},
+		"Should reject prefix lengths for uniform layout": {
+			givenLayout:        SubnetLayout{Strategy: SubnetLayoutUniform, ZonePrefixLengths: []int{19}},
+			givenNumberOfZones: 1,
+			expectedError:      "zone prefix lengths are supported only by the custom subnet layout",
+		},
+		"Should reject unknown layout": {
+			givenLayout:        SubnetLayout{Strategy: "unknown"},
+			givenNumberOfZones: 1,
+			expectedError:      `subnet layout "unknown" is not supported`,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			err := tcase.givenLayout.Validate(tcase.givenNumberOfZones)
+
+			if tcase.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tcase.expectedError)
+			}
+		})
+	}
+}
+
+func TestAllocateBlocks(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenCidr           string
+		givenPrefixLengths  []int
+		expectedBlocks      []string
+		expectedErrorPrefix string
+	}{
+		"Should allocate equal blocks one after another": {
+			givenCidr:          "10.250.0.0/16",
+			givenPrefixLengths: []int{18, 18, 18},
+			expectedBlocks:     []string{"10.250.0.0/18", "10.250.64.0/18", "10.250.128.0/18"},
+		},
+		"Should align bigger block following smaller one": {
+			givenCidr:          "10.250.0.0/16",
+			givenPrefixLengths: []int{22, 18},
+			expectedBlocks:     []string{"10.250.0.0/22", "10.250.64.0/18"},
+		},
+		"Should allocate blocks for low addresses": {
+			givenCidr:          "0.0.0.0/24",
+			givenPrefixLengths: []int{26, 26},
+			expectedBlocks:     []string{"0.0.0.0/26", "0.0.0.64/26"},
+		},
+		"Should fail when blocks do not fit into CIDR": {
+			givenCidr:           "10.250.0.0/22",
+			givenPrefixLengths:  []int{23, 23, 23},
+			expectedErrorPrefix: "subnets with prefix lengths [23 23 23] do not fit into CIDR 10.250.0.0/22",
+		},
+		"Should fail when block is bigger than CIDR": {
+			givenCidr:           "10.250.0.0/22",
+			givenPrefixLengths:  []int{21},
+			expectedErrorPrefix: "prefix length 21 must be between 22 and 32",
+		},
+		"Should fail for IPv6 CIDR": {
+			givenCidr:           "2001:db8::/48",
+			givenPrefixLengths:  []int{64},
+			expectedErrorPrefix: "CIDR 2001:db8::/48 is not an IPv4 CIDR",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			blocks, err := AllocateBlocks(netip.MustParsePrefix(tcase.givenCidr), tcase.givenPrefixLengths)
+
+			if tcase.expectedErrorPrefix != "" {
+				assert.ErrorContains(t, err, tcase.expectedErrorPrefix)
+				assert.Nil(t, blocks)
+				return
+			}
+
+			require.NoError(t, err)
+			var actual []string
+			for _, block := range blocks {
+				actual = append(actual, block.String())
+			}
+			assert.Equal(t, tcase.expectedBlocks, actual)
+		})
+	}
+}
+
+func TestSplitBlock(t *testing.T) {
+	block := netip.MustParsePrefix("10.250.0.0/18")
+
+	t.Run("Should return subnet at the given position", func(t *testing.T) {
+		subnet, err := SplitBlock(block, 20, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, "10.250.48.0/20", subnet.String())
+	})
+
+	t.Run("Should fail when subnet is outside of the block", func(t *testing.T) {
+		_, err := SplitBlock(block, 20, 4)
+
+		assert.ErrorContains(t, err, "subnet 4 with prefix length 20 does not fit into 10.250.0.0/18")
+	})
+
+	t.Run("Should fail when subnet is bigger than the block", func(t *testing.T) {
+		_, err := SplitBlock(block, 17, 0)
+
+		assert.ErrorContains(t, err, "prefix length 17 must be between 18 and 32")
+	})
+}
+
+func TestOverlaps(t *testing.T) {
+	overlaps, err := Overlaps("10.250.0.0/19", "10.250.16.0/20")
+	require.NoError(t, err)
+	assert.True(t, overlaps)
+
+	overlaps, err = Overlaps("10.250.0.0/19", "10.250.32.0/20")
+	require.NoError(t, err)
+	assert.False(t, overlaps)
+
+	_, err = Overlaps("invalidCIDR", "10.250.32.0/20")
+	assert.ErrorContains(t, err, "failed to parse CIDR invalidCIDR")
+}
+
+func TestAllocateBlocksAfter(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenTaken          []string
+		givenPrefixLengths  []int
+		expectedBlocks      []string
+		expectedErrorPrefix string
+	}{
+		"Should allocate blocks after the highest taken subnet": {
+			givenTaken:         []string{"10.250.64.0/19", "10.250.0.0/18"},
+			givenPrefixLengths: []int{20, 21},
+			expectedBlocks:     []string{"10.250.96.0/20", "10.250.112.0/21"},
+		},
+		"Should align blocks after the highest taken subnet": {
+			givenTaken:         []string{"10.250.0.0/22"},
+			givenPrefixLengths: []int{19},
+			expectedBlocks:     []string{"10.250.32.0/19"},
+		},
+		"Should allocate blocks from the start of CIDR when nothing is taken": {
+			givenPrefixLengths: []int{19},
+			expectedBlocks:     []string{"10.250.0.0/19"},
+		},
+		"Should fail when blocks do not fit after the taken subnets": {
+			givenTaken:          []string{"10.250.0.0/17", "10.250.128.0/18"},
+			givenPrefixLengths:  []int{18, 18},
+			expectedErrorPrefix: "subnets with prefix lengths [18 18] do not fit into CIDR 10.250.0.0/16",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			taken, err := ParsePrefixes(tcase.givenTaken...)
+			require.NoError(t, err)
+
+			blocks, err := AllocateBlocksAfter(netip.MustParsePrefix("10.250.0.0/16"), taken, tcase.givenPrefixLengths)
+
+			if tcase.expectedErrorPrefix != "" {
+				assert.ErrorContains(t, err, tcase.expectedErrorPrefix)
+				return
+			}
+
+			require.NoError(t, err)
+			var actual []string
+			for _, block := range blocks {
+				actual = append(actual, block.String())
+			}
+			assert.Equal(t, tcase.expectedBlocks, actual)
+			assert.False(t, OverlapsAny(blocks, taken))
+		})
+	}
+}
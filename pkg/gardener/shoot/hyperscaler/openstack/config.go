@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,33 +16,89 @@ const (
 	defaultLoadBalancerProvider = "f5"
 )
 
-func GetInfrastructureConfig(workerCIDR string, _ []string) ([]byte, error) {
-	return json.Marshal(NewInfrastructureConfig(workerCIDR))
+// Settings contain values which differ between the Converged Cloud regions, empty values are replaced with the defaults
+type Settings struct {
+	FloatingPoolName     string
+	LoadBalancerProvider string
 }
 
-func GetControlPlaneConfig(_ []string) ([]byte, error) {
-	return json.Marshal(NewControlPlaneConfig())
+func GetInfrastructureConfig(workerCIDR string, settings Settings) ([]byte, error) {
+	return json.Marshal(NewInfrastructureConfig(workerCIDR, settings))
 }
 
-func NewInfrastructureConfig(workerCIDR string) v1alpha1.InfrastructureConfig {
+func GetControlPlaneConfig(settings Settings) ([]byte, error) {
+	return json.Marshal(NewControlPlaneConfig(settings))
+}
+
+// GetInfrastructureConfigForPatch changes the floating pool of the existing config only when a new one is requested
+func GetInfrastructureConfigForPatch(floatingPoolName string, existingInfrastructureConfigBytes []byte) ([]byte, error) {
+	if floatingPoolName == "" {
+		return existingInfrastructureConfigBytes, nil
+	}
+
+	var infrastructureConfig v1alpha1.InfrastructureConfig
+	if err := json.Unmarshal(existingInfrastructureConfigBytes, &infrastructureConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to decode existing infrastructure config")
+	}
+
+	if infrastructureConfig.FloatingPoolName == floatingPoolName {
+		return existingInfrastructureConfigBytes, nil
+	}
+
+	infrastructureConfig.FloatingPoolName = floatingPoolName
+
+	return json.Marshal(infrastructureConfig)
+}
+
+// GetControlPlaneConfigForPatch changes the load balancer provider of the existing config only when a new one is requested
+func GetControlPlaneConfigForPatch(loadBalancerProvider string, existingControlPlaneConfigBytes []byte) ([]byte, error) {
+	if loadBalancerProvider == "" {
+		return existingControlPlaneConfigBytes, nil
+	}
+
+	var controlPlaneConfig v1alpha1.ControlPlaneConfig
+	if err := json.Unmarshal(existingControlPlaneConfigBytes, &controlPlaneConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to decode existing control plane config")
+	}
+
+	if controlPlaneConfig.LoadBalancerProvider == loadBalancerProvider {
+		return existingControlPlaneConfigBytes, nil
+	}
+
+	controlPlaneConfig.LoadBalancerProvider = loadBalancerProvider
+
+	return json.Marshal(controlPlaneConfig)
+}
+
+func NewInfrastructureConfig(workerCIDR string, settings Settings) v1alpha1.InfrastructureConfig {
+	floatingPoolName := settings.FloatingPoolName
+	if floatingPoolName == "" {
+		floatingPoolName = defaultFloatingPoolName
+	}
+
 	return v1alpha1.InfrastructureConfig{
 		TypeMeta: v1.TypeMeta{
 			Kind:       infrastructureConfigKind,
 			APIVersion: apiVersion,
 		},
-		FloatingPoolName: defaultFloatingPoolName,
+		FloatingPoolName: floatingPoolName,
 		Networks: v1alpha1.Networks{
 			Workers: workerCIDR,
 		},
 	}
 }
 
-func NewControlPlaneConfig() *v1alpha1.ControlPlaneConfig {
+func NewControlPlaneConfig(settings Settings) *v1alpha1.ControlPlaneConfig {
+	loadBalancerProvider := settings.LoadBalancerProvider
+	if loadBalancerProvider == "" {
+		loadBalancerProvider = defaultLoadBalancerProvider
+	}
+
 	return &v1alpha1.ControlPlaneConfig{
 		TypeMeta: v1.TypeMeta{
 			Kind:       controlPlaneConfigKind,
 			APIVersion: apiVersion,
 		},
-		LoadBalancerProvider: defaultLoadBalancerProvider,
+		LoadBalancerProvider: loadBalancerProvider,
 	}
 }
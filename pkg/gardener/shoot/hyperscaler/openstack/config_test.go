@@ -12,7 +12,7 @@ import (
 func TestControlPlaneConfig(t *testing.T) {
 	t.Run("Create Control Plane config", func(t *testing.T) {
 		// when
-		controlPlaneConfigBytes, err := GetControlPlaneConfig([]string{"europe-west3a"})
+		controlPlaneConfigBytes, err := GetControlPlaneConfig(Settings{})
 
 		// then
 		require.NoError(t, err)
@@ -30,7 +30,7 @@ func TestControlPlaneConfig(t *testing.T) {
 func TestInfrastructureConfig(t *testing.T) {
 	t.Run("Create Infrastructure config", func(t *testing.T) {
 		// when
-		infrastructureConfigBytes, err := GetInfrastructureConfig("10.250.0.0/22", nil)
+		infrastructureConfigBytes, err := GetInfrastructureConfig("10.250.0.0/22", Settings{})
 
 		// then
 		require.NoError(t, err)
@@ -45,3 +45,61 @@ func TestInfrastructureConfig(t *testing.T) {
 		assert.Equal(t, defaultFloatingPoolName, infrastructureConfig.FloatingPoolName)
 	})
 }
+
+func TestConfigWithRegionSettings(t *testing.T) {
+	settings := Settings{
+		FloatingPoolName:     "FloatingIP-external-kyma-02",
+		LoadBalancerProvider: "octavia",
+	}
+
+	t.Run("Create configs with region settings", func(t *testing.T) {
+		// when
+		infrastructureConfig := NewInfrastructureConfig("10.250.0.0/22", settings)
+		controlPlaneConfig := NewControlPlaneConfig(settings)
+
+		// then
+		assert.Equal(t, "FloatingIP-external-kyma-02", infrastructureConfig.FloatingPoolName)
+		assert.Equal(t, "octavia", controlPlaneConfig.LoadBalancerProvider)
+	})
+
+	t.Run("Keep existing configs when no change is requested", func(t *testing.T) {
+		// given
+		existingInfrastructureConfigBytes, err := GetInfrastructureConfig("10.250.0.0/22", Settings{})
+		require.NoError(t, err)
+		existingControlPlaneConfigBytes, err := GetControlPlaneConfig(Settings{})
+		require.NoError(t, err)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch("", existingInfrastructureConfigBytes)
+		require.NoError(t, err)
+		controlPlaneConfigBytes, err := GetControlPlaneConfigForPatch("", existingControlPlaneConfigBytes)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, existingInfrastructureConfigBytes, infrastructureConfigBytes)
+		assert.Equal(t, existingControlPlaneConfigBytes, controlPlaneConfigBytes)
+	})
+
+	t.Run("Change existing configs when requested", func(t *testing.T) {
+		// given
+		existingInfrastructureConfigBytes := []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","floatingPoolName":"FloatingIP-external-kyma-01","networks":{"workers":"10.250.0.0/22","router":{"id":"router-1"}}}`)
+		existingControlPlaneConfigBytes, err := GetControlPlaneConfig(Settings{})
+		require.NoError(t, err)
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigForPatch(settings.FloatingPoolName, existingInfrastructureConfigBytes)
+		require.NoError(t, err)
+		controlPlaneConfigBytes, err := GetControlPlaneConfigForPatch(settings.LoadBalancerProvider, existingControlPlaneConfigBytes)
+		require.NoError(t, err)
+
+		// then
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		require.NoError(t, json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig))
+		assert.Equal(t, "FloatingIP-external-kyma-02", infrastructureConfig.FloatingPoolName)
+		assert.Equal(t, "router-1", infrastructureConfig.Networks.Router.ID)
+
+		var controlPlaneConfig v1alpha1.ControlPlaneConfig
+		require.NoError(t, json.Unmarshal(controlPlaneConfigBytes, &controlPlaneConfig))
+		assert.Equal(t, "octavia", controlPlaneConfig.LoadBalancerProvider)
+	})
+}
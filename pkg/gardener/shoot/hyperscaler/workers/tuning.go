@@ -0,0 +1,24 @@
+package workers
+
+// Tuning contains provider neutral settings of the worker pool machines.
+// Every hyperscaler package merges them into its own worker config and rejects the settings it does not support.
+type Tuning struct {
+	Volume            *VolumeTuning
+	DataVolumes       []DataVolumeTuning
+	LocalSSDInterface *string
+}
+
+type VolumeTuning struct {
+	IOPS       *int64
+	Throughput *int64
+}
+
+type DataVolumeTuning struct {
+	Name string
+	VolumeTuning
+	SourceImage *string
+}
+
+func (t Tuning) IsEmpty() bool {
+	return t.Volume == nil && len(t.DataVolumes) == 0 && t.LocalSSDInterface == nil
+}
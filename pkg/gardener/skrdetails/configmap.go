@@ -63,15 +63,21 @@ func ToKymaProvisioningInfo(runtime imv1.Runtime, shoot *gardener.Shoot) KymaPro
 		}
 	}
 
-	return KymaProvisioningInfo{
+	provisioningInfo := KymaProvisioningInfo{
 		WorkerPools: WorkerPools{
 			Kyma:   kymaWorkerPool,
 			Custom: customWorkerPools,
 		},
-		GlobalAccountID:      runtime.Labels["kyma-project.io/global-account-id"],
-		SubaccountID:         runtime.Labels["kyma-project.io/subaccount-id"],
-		InfrastructureConfig: *shoot.Spec.Provider.InfrastructureConfig,
+		GlobalAccountID: runtime.Labels["kyma-project.io/global-account-id"],
+		SubaccountID:    runtime.Labels["kyma-project.io/subaccount-id"],
 	}
+
+	// shoots created with provider-local have no infrastructure config
+	if shoot.Spec.Provider.InfrastructureConfig != nil {
+		provisioningInfo.InfrastructureConfig = *shoot.Spec.Provider.InfrastructureConfig
+	}
+
+	return provisioningInfo
 }
 
 func ToKymaProvisioningInfoConfigMap(runtime imv1.Runtime, shoot *gardener.Shoot) (v1.ConfigMap, error) {
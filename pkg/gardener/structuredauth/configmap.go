@@ -2,8 +2,13 @@ package structuredauth
 
 import (
 	"context"
+	"maps"
+	"reflect"
+	"slices"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,23 +19,52 @@ import (
 )
 
 type JWTAuthenticator struct {
-	Issuer        Issuer        `json:"issuer"`
-	ClaimMappings ClaimMappings `json:"claimMappings"`
+	Issuer               Issuer                `json:"issuer"`
+	ClaimValidationRules []ClaimValidationRule `json:"claimValidationRules,omitempty"`
+	ClaimMappings        ClaimMappings         `json:"claimMappings"`
+	UserValidationRules  []UserValidationRule  `json:"userValidationRules,omitempty"`
 }
 
 type Issuer struct {
-	URL       string   `json:"url"`
-	Audiences []string `json:"audiences"`
+	URL                  string   `json:"url"`
+	CertificateAuthority string   `json:"certificateAuthority,omitempty"`
+	Audiences            []string `json:"audiences"`
+	AudienceMatchPolicy  string   `json:"audienceMatchPolicy,omitempty"`
+}
+
+type ClaimValidationRule struct {
+	Claim         string `json:"claim,omitempty"`
+	RequiredValue string `json:"requiredValue,omitempty"`
+	Expression    string `json:"expression,omitempty"`
+	Message       string `json:"message,omitempty"`
 }
 
 type ClaimMappings struct {
-	Username PrefixedClaim `json:"username"`
-	Groups   PrefixedClaim `json:"groups"`
+	Username PrefixedClaim      `json:"username"`
+	Groups   PrefixedClaim      `json:"groups"`
+	UID      *ClaimOrExpression `json:"uid,omitempty"`
+	Extra    []ExtraMapping     `json:"extra,omitempty"`
 }
 
 type PrefixedClaim struct {
-	Claim  string  `json:"claim"`
-	Prefix *string `json:"prefix,omitempty"`
+	Claim      string  `json:"claim"`
+	Prefix     *string `json:"prefix,omitempty"`
+	Expression string  `json:"expression,omitempty"`
+}
+
+type ClaimOrExpression struct {
+	Claim      string `json:"claim,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+type ExtraMapping struct {
+	Key             string `json:"key"`
+	ValueExpression string `json:"valueExpression"`
+}
+
+type UserValidationRule struct {
+	Expression string `json:"expression"`
+	Message    string `json:"message,omitempty"`
 }
 
 type AuthenticationConfiguration struct {
@@ -39,36 +73,62 @@ type AuthenticationConfiguration struct {
 	JWT []JWTAuthenticator `json:"jwt"`
 }
 
-func toAuthenticationConfiguration(oidcConfig gardener.OIDCConfig) AuthenticationConfiguration {
+const audienceMatchPolicyMatchAny = string(imv1.AudienceMatchPolicyMatchAny)
+
+// the kube-apiserver verifies JWT authenticator tokens signed with any of these algorithms, a narrower set of signing algorithms cannot be configured
+var supportedSigningAlgs = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}
 
-	toJWTAuthenticator := func(oidcConfig gardener.OIDCConfig) JWTAuthenticator {
-		// If Groups prefix is not set by the KEB, default is set as Gardener requires non-empty value
-		groupsPrefix := ptr.To("")
+// toAuthenticationConfiguration renders the primary OIDC config and every additional OIDC config as JWT authenticators.
+// The kube-apiserver requires unique issuer URLs, so configs sharing an issuer are rendered as one authenticator accepting all their client IDs.
+// Additional OIDC configs which cannot be expressed as JWT authenticators are returned, they are applied as OpenIDConnect resources in the SKR.
+func toAuthenticationConfiguration(oidcConfig gardener.OIDCConfig, additionalOidcConfigs []imv1.OIDCConfig) (AuthenticationConfiguration, []imv1.OIDCConfig, error) {
+	if err := validateSigningAlgs(imv1.OIDCConfig{OIDCConfig: oidcConfig}); err != nil {
+		return AuthenticationConfiguration{}, nil, err
+	}
+
+	jwtAuthenticators := make([]JWTAuthenticator, 0)
+	jwtAuthenticators = append(jwtAuthenticators, toJWTAuthenticator(imv1.OIDCConfig{OIDCConfig: oidcConfig}))
 
-		if oidcConfig.GroupsPrefix != nil {
-			groupsPrefix = oidcConfig.GroupsPrefix
+	var openIDConnectConfigs []imv1.OIDCConfig
+
+	for _, additionalOidcConfig := range additionalOidcConfigs {
+		if err := validateSigningAlgs(additionalOidcConfig); err != nil {
+			return AuthenticationConfiguration{}, nil, err
 		}
 
-		return JWTAuthenticator{
-			Issuer: Issuer{
-				URL:       ptr.Deref(oidcConfig.IssuerURL, ""),
-				Audiences: []string{ptr.Deref(oidcConfig.ClientID, "")},
-			},
-			ClaimMappings: ClaimMappings{
-				Username: PrefixedClaim{
-					Claim:  ptr.Deref(oidcConfig.UsernameClaim, ""),
-					Prefix: oidcConfig.UsernamePrefix,
-				},
-				Groups: PrefixedClaim{
-					Claim:  ptr.Deref(oidcConfig.GroupsClaim, ""),
-					Prefix: groupsPrefix,
-				},
-			},
+		// the kube-apiserver discovers the signing keys of JWT authenticators from the issuer, a key set cannot be set inline
+		if len(additionalOidcConfig.JWKS) > 0 || additionalOidcConfig.PinJWKS {
+			reason := "it has an inline key set"
+			if len(additionalOidcConfig.JWKS) == 0 {
+				reason = "its signing keys are pinned"
+			}
+
+			if err := validateOpenIDConnectConfig(additionalOidcConfig, reason); err != nil {
+				return AuthenticationConfiguration{}, nil, err
+			}
+			openIDConnectConfigs = append(openIDConnectConfigs, additionalOidcConfig)
+			continue
 		}
-	}
 
-	jwtAuthenticators := make([]JWTAuthenticator, 0)
-	jwtAuthenticators = append(jwtAuthenticators, toJWTAuthenticator(oidcConfig))
+		authenticator := toJWTAuthenticator(additionalOidcConfig)
+
+		index := slices.IndexFunc(jwtAuthenticators, func(a JWTAuthenticator) bool {
+			return a.Issuer.URL == authenticator.Issuer.URL
+		})
+
+		if index == -1 {
+			jwtAuthenticators = append(jwtAuthenticators, authenticator)
+			continue
+		}
+
+		// configs sharing an issuer with different settings were applied as separate OpenIDConnect resources before the migration to structured authentication
+		if !mergeAudiences(&jwtAuthenticators[index], authenticator) {
+			if err := validateOpenIDConnectConfig(additionalOidcConfig, "another OIDC config for the issuer differs in settings other than the client ID"); err != nil {
+				return AuthenticationConfiguration{}, nil, err
+			}
+			openIDConnectConfigs = append(openIDConnectConfigs, additionalOidcConfig)
+		}
+	}
 
 	return AuthenticationConfiguration{
 		TypeMeta: metav1.TypeMeta{
@@ -76,12 +136,170 @@ func toAuthenticationConfiguration(oidcConfig gardener.OIDCConfig) Authenticatio
 			APIVersion: "apiserver.config.k8s.io/v1beta1",
 		},
 		JWT: jwtAuthenticators,
+	}, openIDConnectConfigs, nil
+}
+
+// OpenIDConnectConfigs returns the additional OIDC configs which cannot be rendered as JWT authenticators of the structured authentication.
+// They are applied as OpenIDConnect resources in the SKR, which requires the shoot-oidc-service extension.
+func OpenIDConnectConfigs(oidcConfig gardener.OIDCConfig, additionalOidcConfigs []imv1.OIDCConfig) ([]imv1.OIDCConfig, error) {
+	_, openIDConnectConfigs, err := toAuthenticationConfiguration(oidcConfig, additionalOidcConfigs)
+	return openIDConnectConfigs, err
+}
+
+// validateOpenIDConnectConfig makes sure the config does not use settings of the structured authentication which cannot be set in an OpenIDConnect resource
+func validateOpenIDConnectConfig(oidcConfig imv1.OIDCConfig, reason string) error {
+	if len(oidcConfig.Audiences) > 0 || oidcConfig.AudienceMatchPolicy != nil || len(oidcConfig.ClaimValidationRules) > 0 ||
+		oidcConfig.ClaimMappings != nil || len(oidcConfig.UserValidationRules) > 0 {
+		return errors.Errorf("OIDC config for issuer %s is applied as OpenIDConnect resource because %s, audiences, claim validation rules, claim mappings and user validation rules are not supported", ptr.Deref(oidcConfig.IssuerURL, ""), reason)
 	}
+	return nil
+}
+
+func validateSigningAlgs(oidcConfig imv1.OIDCConfig) error {
+	for _, alg := range oidcConfig.SigningAlgs {
+		if !slices.Contains(supportedSigningAlgs, alg) {
+			return errors.Errorf("signing algorithm %s of OIDC config for issuer %s is not supported, supported: %v", alg, ptr.Deref(oidcConfig.IssuerURL, ""), supportedSigningAlgs)
+		}
+	}
+	return nil
 }
 
-func CreateOrUpdateStructuredAuthConfigMap(ctx context.Context, seedClient client.Client, cmKey types.NamespacedName, oidcConfig gardener.OIDCConfig) error {
+func toJWTAuthenticator(oidcConfig imv1.OIDCConfig) JWTAuthenticator {
+	// If Groups prefix is not set by the KEB, default is set as Gardener requires non-empty value
+	groupsPrefix := ptr.To("")
+
+	if oidcConfig.GroupsPrefix != nil {
+		groupsPrefix = oidcConfig.GroupsPrefix
+	}
+
+	audiences := []string{ptr.Deref(oidcConfig.ClientID, "")}
+	for _, audience := range oidcConfig.Audiences {
+		if !slices.Contains(audiences, audience) {
+			audiences = append(audiences, audience)
+		}
+	}
+
+	authenticator := JWTAuthenticator{
+		Issuer: Issuer{
+			URL:                  ptr.Deref(oidcConfig.IssuerURL, ""),
+			CertificateAuthority: ptr.Deref(oidcConfig.CABundle, ""),
+			Audiences:            audiences,
+			AudienceMatchPolicy:  string(ptr.Deref(oidcConfig.AudienceMatchPolicy, "")),
+		},
+		ClaimValidationRules: toClaimValidationRules(oidcConfig.RequiredClaims),
+		ClaimMappings: ClaimMappings{
+			Username: PrefixedClaim{
+				Claim:  ptr.Deref(oidcConfig.UsernameClaim, ""),
+				Prefix: oidcConfig.UsernamePrefix,
+			},
+			Groups: PrefixedClaim{
+				Claim:  ptr.Deref(oidcConfig.GroupsClaim, ""),
+				Prefix: groupsPrefix,
+			},
+		},
+	}
+
+	if len(audiences) > 1 && authenticator.Issuer.AudienceMatchPolicy == "" {
+		authenticator.Issuer.AudienceMatchPolicy = audienceMatchPolicyMatchAny
+	}
+
+	for _, rule := range oidcConfig.ClaimValidationRules {
+		authenticator.ClaimValidationRules = append(authenticator.ClaimValidationRules, ClaimValidationRule(rule))
+	}
+
+	for _, rule := range oidcConfig.UserValidationRules {
+		authenticator.UserValidationRules = append(authenticator.UserValidationRules, UserValidationRule(rule))
+	}
+
+	for _, rule := range reservedPrefixUserValidationRules {
+		if !slices.ContainsFunc(authenticator.UserValidationRules, func(r UserValidationRule) bool { return r.Expression == rule.Expression }) {
+			authenticator.UserValidationRules = append(authenticator.UserValidationRules, rule)
+		}
+	}
+
+	if oidcConfig.ClaimMappings != nil {
+		applyClaimMappings(&authenticator.ClaimMappings, *oidcConfig.ClaimMappings)
+	}
+
+	return authenticator
+}
+
+// applyClaimMappings overrides the mappings built from the username and groups claims with the mappings set on the Runtime
+func applyClaimMappings(mappings *ClaimMappings, runtimeMappings imv1.ClaimMappings) {
+	if runtimeMappings.Username != nil {
+		mappings.Username = PrefixedClaim{
+			Claim:      runtimeMappings.Username.Claim,
+			Prefix:     runtimeMappings.Username.Prefix,
+			Expression: runtimeMappings.Username.Expression,
+		}
+	}
+
+	if runtimeMappings.Groups != nil {
+		mappings.Groups = PrefixedClaim{
+			Claim:      runtimeMappings.Groups.Claim,
+			Prefix:     runtimeMappings.Groups.Prefix,
+			Expression: runtimeMappings.Groups.Expression,
+		}
+	}
+
+	if runtimeMappings.UID != nil {
+		mappings.UID = ptr.To(ClaimOrExpression(*runtimeMappings.UID))
+	}
+
+	for _, extra := range runtimeMappings.Extra {
+		mappings.Extra = append(mappings.Extra, ExtraMapping(extra))
+	}
+}
+
+// mergeAudiences adds the audiences of the authenticator to the existing one, false is returned when they differ in settings other than the client ID
+func mergeAudiences(existing *JWTAuthenticator, authenticator JWTAuthenticator) bool {
+	if existing.Issuer.CertificateAuthority != authenticator.Issuer.CertificateAuthority ||
+		!reflect.DeepEqual(existing.ClaimValidationRules, authenticator.ClaimValidationRules) ||
+		!reflect.DeepEqual(existing.ClaimMappings, authenticator.ClaimMappings) ||
+		!reflect.DeepEqual(existing.UserValidationRules, authenticator.UserValidationRules) {
+		return false
+	}
+
+	for _, audience := range authenticator.Issuer.Audiences {
+		if !slices.Contains(existing.Issuer.Audiences, audience) {
+			existing.Issuer.Audiences = append(existing.Issuer.Audiences, audience)
+		}
+	}
+
+	if len(existing.Issuer.Audiences) > 1 {
+		existing.Issuer.AudienceMatchPolicy = audienceMatchPolicyMatchAny
+	}
+
+	return true
+}
+
+func toClaimValidationRules(requiredClaims map[string]string) []ClaimValidationRule {
+	var rules []ClaimValidationRule
+	for _, claim := range slices.Sorted(maps.Keys(requiredClaims)) {
+		rules = append(rules, ClaimValidationRule{
+			Claim:         claim,
+			RequiredValue: requiredClaims[claim],
+		})
+	}
+	return rules
+}
+
+// CreateOrUpdateStructuredAuthConfigMap writes the structured authentication config map of the shoot,
+// the additional OIDC configs which have to be applied as OpenIDConnect resources in the SKR are returned.
+func CreateOrUpdateStructuredAuthConfigMap(ctx context.Context, seedClient client.Client, cmKey types.NamespacedName, oidcConfig gardener.OIDCConfig, additionalOidcConfigs []imv1.OIDCConfig) ([]imv1.OIDCConfig, error) {
+	var openIDConnectConfigs []imv1.OIDCConfig
+
 	creteConfigMapObject := func() (v1.ConfigMap, error) {
-		authenticationConfig := toAuthenticationConfiguration(oidcConfig)
+		authenticationConfig, configs, err := toAuthenticationConfiguration(oidcConfig, additionalOidcConfigs)
+		openIDConnectConfigs = configs
+		if err != nil {
+			return v1.ConfigMap{}, err
+		}
+
+		if err := validateAuthenticationConfiguration(authenticationConfig); err != nil {
+			return v1.ConfigMap{}, errors.Wrap(err, "invalid structured authentication configuration")
+		}
+
 		authConfigBytes, err := yaml.Marshal(authenticationConfig)
 		if err != nil {
 			return v1.ConfigMap{}, err
@@ -106,7 +324,7 @@ func CreateOrUpdateStructuredAuthConfigMap(ctx context.Context, seedClient clien
 	err := seedClient.Get(ctx, cmKey, &existingCM)
 
 	if err != nil && !k8serrors.IsNotFound(err) {
-		return err
+		return nil, err
 	}
 
 	configMapAlreadyExists := err == nil
@@ -114,15 +332,15 @@ func CreateOrUpdateStructuredAuthConfigMap(ctx context.Context, seedClient clien
 	newConfigMap, err := creteConfigMapObject()
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if configMapAlreadyExists {
 		existingCM.Data = newConfigMap.Data
-		return seedClient.Update(ctx, &existingCM)
+		return openIDConnectConfigs, seedClient.Update(ctx, &existingCM)
 	}
 
-	return seedClient.Create(ctx, &newConfigMap)
+	return openIDConnectConfigs, seedClient.Create(ctx, &newConfigMap)
 }
 
 func DeleteStructuredConfigMap(ctx context.Context, seedClient client.Client, shoot gardener.Shoot) error {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -119,7 +120,7 @@ func TestCreateOrUpdateConfigMap(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			err := CreateOrUpdateStructuredAuthConfigMap(context.Background(), fakeClient, types.NamespacedName{Namespace: "default", Name: tt.cmName}, tt.oidcConfig)
+			_, err := CreateOrUpdateStructuredAuthConfigMap(context.Background(), fakeClient, types.NamespacedName{Namespace: "default", Name: tt.cmName}, tt.oidcConfig, nil)
 			require.NoError(t, err)
 
 			cm := &corev1.ConfigMap{}
@@ -149,6 +150,254 @@ func TestCreateOrUpdateConfigMap(t *testing.T) {
 	}
 }
 
+func TestToAuthenticationConfigurationWithAdditionalOidcConfig(t *testing.T) {
+	primary := gardener.OIDCConfig{
+		ClientID:      ptr.To("primary-client"),
+		IssuerURL:     ptr.To("https://primary.issuer.com"),
+		UsernameClaim: ptr.To("sub"),
+		GroupsClaim:   ptr.To("groups"),
+	}
+
+	additional := func(clientID, issuerURL string) imv1.OIDCConfig {
+		return imv1.OIDCConfig{
+			OIDCConfig: gardener.OIDCConfig{
+				ClientID:       ptr.To(clientID),
+				IssuerURL:      ptr.To(issuerURL),
+				UsernameClaim:  ptr.To("email"),
+				UsernamePrefix: ptr.To("-"),
+				GroupsClaim:    ptr.To("groups"),
+			},
+		}
+	}
+
+	withCAAndRequiredClaims := additional("secured-client", "https://secured.issuer.com")
+	withCAAndRequiredClaims.CABundle = ptr.To("ca-bundle")
+	withCAAndRequiredClaims.RequiredClaims = map[string]string{"tenant": "kyma", "env": "prod"}
+
+	withOtherMappings := additional("other-client", "https://primary.issuer.com")
+	withOtherMappings.GroupsClaim = ptr.To("roles")
+
+	withOtherPrefix := additional("client-2", "https://issuer-1.com")
+	withOtherPrefix.UsernamePrefix = ptr.To("other-")
+
+	withInlineJWKS := additional("jwks-client", "https://private.issuer.com")
+	withInlineJWKS.JWKS = []byte(`{"keys":[]}`)
+	withInlineJWKS.SigningAlgs = []string{"ES256"}
+
+	withPinnedJWKS := additional("pinned-client", "https://pinned.issuer.com")
+	withPinnedJWKS.PinJWKS = true
+
+	withPinnedJWKSAndAudiences := withPinnedJWKS
+	withPinnedJWKSAndAudiences.Audiences = []string{"other-audience"}
+
+	withInlineJWKSAndRules := withInlineJWKS
+	withInlineJWKSAndRules.UserValidationRules = []imv1.UserValidationRule{{Expression: "!user.username.startsWith('system:')"}}
+
+	withUnsupportedSigningAlgs := additional("hmac-client", "https://hmac.issuer.com")
+	withUnsupportedSigningAlgs.SigningAlgs = []string{"RS256", "HS256"}
+
+	withRules := additional("rules-client", "https://rules.issuer.com")
+	withRules.RequiredClaims = map[string]string{"tenant": "kyma"}
+	withRules.Audiences = []string{"rules-client", "other-audience"}
+	withRules.ClaimValidationRules = []imv1.ClaimValidationRule{
+		{Expression: "claims.exp - claims.nbf <= 86400", Message: "token lifetime must not exceed one day"},
+	}
+	withRules.ClaimMappings = &imv1.ClaimMappings{
+		Username: &imv1.PrefixedClaimOrExpression{Expression: "'kyma:' + claims.sub"},
+		UID:      &imv1.ClaimOrExpression{Claim: "sub"},
+		Extra:    []imv1.ExtraMapping{{Key: "kyma.io/tenant", ValueExpression: "claims.tenant"}},
+	}
+	withRules.UserValidationRules = []imv1.UserValidationRule{
+		{Expression: "!user.username.startsWith('system:')", Message: "username cannot use reserved system: prefix"},
+	}
+
+	primaryAuthenticator := JWTAuthenticator{
+		Issuer:              Issuer{URL: "https://primary.issuer.com", Audiences: []string{"primary-client"}},
+		ClaimMappings:       ClaimMappings{Username: PrefixedClaim{Claim: "sub"}, Groups: PrefixedClaim{Claim: "groups", Prefix: ptr.To("")}},
+		UserValidationRules: reservedPrefixUserValidationRules,
+	}
+
+	for _, tc := range []struct {
+		name                         string
+		additionalOidcConfigs        []imv1.OIDCConfig
+		expectedAuthenticators       []JWTAuthenticator
+		expectedOpenIDConnectConfigs []imv1.OIDCConfig
+		expectedErrText              string
+	}{
+		{
+			name: "Should render only the primary OIDC config when no additional config is set",
+			expectedAuthenticators: []JWTAuthenticator{
+				primaryAuthenticator,
+			},
+		},
+		{
+			name: "Should render every additional OIDC config as its own authenticator",
+			additionalOidcConfigs: []imv1.OIDCConfig{
+				additional("client-1", "https://issuer-1.com"),
+				withCAAndRequiredClaims,
+			},
+			expectedAuthenticators: []JWTAuthenticator{
+				primaryAuthenticator,
+				{
+					Issuer:              Issuer{URL: "https://issuer-1.com", Audiences: []string{"client-1"}},
+					ClaimMappings:       ClaimMappings{Username: PrefixedClaim{Claim: "email", Prefix: ptr.To("-")}, Groups: PrefixedClaim{Claim: "groups", Prefix: ptr.To("")}},
+					UserValidationRules: reservedPrefixUserValidationRules,
+				},
+				{
+					Issuer: Issuer{URL: "https://secured.issuer.com", CertificateAuthority: "ca-bundle", Audiences: []string{"secured-client"}},
+					ClaimValidationRules: []ClaimValidationRule{
+						{Claim: "env", RequiredValue: "prod"},
+						{Claim: "tenant", RequiredValue: "kyma"},
+					},
+					ClaimMappings:       ClaimMappings{Username: PrefixedClaim{Claim: "email", Prefix: ptr.To("-")}, Groups: PrefixedClaim{Claim: "groups", Prefix: ptr.To("")}},
+					UserValidationRules: reservedPrefixUserValidationRules,
+				},
+			},
+		},
+		{
+			name: "Should merge the client IDs of additional OIDC configs sharing an issuer",
+			additionalOidcConfigs: []imv1.OIDCConfig{
+				additional("client-1", "https://issuer-1.com"),
+				additional("client-2", "https://issuer-1.com"),
+				additional("client-1", "https://issuer-1.com"),
+			},
+			expectedAuthenticators: []JWTAuthenticator{
+				primaryAuthenticator,
+				{
+					Issuer:              Issuer{URL: "https://issuer-1.com", Audiences: []string{"client-1", "client-2"}, AudienceMatchPolicy: "MatchAny"},
+					ClaimMappings:       ClaimMappings{Username: PrefixedClaim{Claim: "email", Prefix: ptr.To("-")}, Groups: PrefixedClaim{Claim: "groups", Prefix: ptr.To("")}},
+					UserValidationRules: reservedPrefixUserValidationRules,
+				},
+			},
+		},
+		{
+			name:                  "Should render audiences, claim validation rules, claim mappings and user validation rules",
+			additionalOidcConfigs: []imv1.OIDCConfig{withRules},
+			expectedAuthenticators: []JWTAuthenticator{
+				primaryAuthenticator,
+				{
+					Issuer: Issuer{URL: "https://rules.issuer.com", Audiences: []string{"rules-client", "other-audience"}, AudienceMatchPolicy: "MatchAny"},
+					ClaimValidationRules: []ClaimValidationRule{
+						{Claim: "tenant", RequiredValue: "kyma"},
+						{Expression: "claims.exp - claims.nbf <= 86400", Message: "token lifetime must not exceed one day"},
+					},
+					ClaimMappings: ClaimMappings{
+						Username: PrefixedClaim{Expression: "'kyma:' + claims.sub"},
+						Groups:   PrefixedClaim{Claim: "groups", Prefix: ptr.To("")},
+						UID:      &ClaimOrExpression{Claim: "sub"},
+						Extra:    []ExtraMapping{{Key: "kyma.io/tenant", ValueExpression: "claims.tenant"}},
+					},
+					UserValidationRules: []UserValidationRule{
+						{Expression: "!user.username.startsWith('system:')", Message: "username cannot use reserved system: prefix"},
+						{Expression: "user.groups.all(group, !group.startsWith('system:'))", Message: "groups cannot use reserved system: prefix"},
+					},
+				},
+			},
+		},
+		{
+			name:                         "Should apply OIDC config sharing an issuer with the primary OIDC config but with different claim mappings as OpenIDConnect resource",
+			additionalOidcConfigs:        []imv1.OIDCConfig{withOtherMappings},
+			expectedAuthenticators:       []JWTAuthenticator{primaryAuthenticator},
+			expectedOpenIDConnectConfigs: []imv1.OIDCConfig{withOtherMappings},
+		},
+		{
+			name: "Should keep existing OIDC configs sharing an issuer but with different prefixes as OpenIDConnect resources",
+			additionalOidcConfigs: []imv1.OIDCConfig{
+				additional("client-1", "https://issuer-1.com"),
+				withOtherPrefix,
+				additional("client-3", "https://issuer-1.com"),
+			},
+			expectedAuthenticators: []JWTAuthenticator{
+				primaryAuthenticator,
+				{
+					Issuer:              Issuer{URL: "https://issuer-1.com", Audiences: []string{"client-1", "client-3"}, AudienceMatchPolicy: "MatchAny"},
+					ClaimMappings:       ClaimMappings{Username: PrefixedClaim{Claim: "email", Prefix: ptr.To("-")}, Groups: PrefixedClaim{Claim: "groups", Prefix: ptr.To("")}},
+					UserValidationRules: reservedPrefixUserValidationRules,
+				},
+			},
+			expectedOpenIDConnectConfigs: []imv1.OIDCConfig{withOtherPrefix},
+		},
+		{
+			name:                         "Should apply OIDC config with inline key set as OpenIDConnect resource",
+			additionalOidcConfigs:        []imv1.OIDCConfig{additional("client-1", "https://issuer-1.com"), withInlineJWKS},
+			expectedOpenIDConnectConfigs: []imv1.OIDCConfig{withInlineJWKS},
+			expectedAuthenticators: []JWTAuthenticator{
+				primaryAuthenticator,
+				{
+					Issuer:              Issuer{URL: "https://issuer-1.com", Audiences: []string{"client-1"}},
+					ClaimMappings:       ClaimMappings{Username: PrefixedClaim{Claim: "email", Prefix: ptr.To("-")}, Groups: PrefixedClaim{Claim: "groups", Prefix: ptr.To("")}},
+					UserValidationRules: reservedPrefixUserValidationRules,
+				},
+			},
+		},
+		{
+			name:                         "Should apply OIDC config with pinned keys as OpenIDConnect resource",
+			additionalOidcConfigs:        []imv1.OIDCConfig{withPinnedJWKS},
+			expectedOpenIDConnectConfigs: []imv1.OIDCConfig{withPinnedJWKS},
+			expectedAuthenticators:       []JWTAuthenticator{primaryAuthenticator},
+		},
+		{
+			name:                  "Should fail when OIDC config with pinned keys uses audiences",
+			additionalOidcConfigs: []imv1.OIDCConfig{withPinnedJWKSAndAudiences},
+			expectedErrText:       "OIDC config for issuer https://pinned.issuer.com is applied as OpenIDConnect resource because its signing keys are pinned, audiences, claim validation rules, claim mappings and user validation rules are not supported",
+		},
+		{
+			name:                  "Should fail when OIDC config applied as OpenIDConnect resource uses settings of the structured authentication",
+			additionalOidcConfigs: []imv1.OIDCConfig{withInlineJWKSAndRules},
+			expectedErrText:       "OIDC config for issuer https://private.issuer.com is applied as OpenIDConnect resource because it has an inline key set, audiences, claim validation rules, claim mappings and user validation rules are not supported",
+		},
+		{
+			name:                  "Should fail when signing algorithms are not supported by the kube-apiserver",
+			additionalOidcConfigs: []imv1.OIDCConfig{withUnsupportedSigningAlgs},
+			expectedErrText:       "signing algorithm HS256 of OIDC config for issuer https://hmac.issuer.com is not supported, supported: [RS256 RS384 RS512 ES256 ES384 ES512 PS256 PS384 PS512]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			authenticationConfiguration, openIDConnectConfigs, err := toAuthenticationConfiguration(primary, tc.additionalOidcConfigs)
+
+			// then
+			if tc.expectedErrText != "" {
+				require.EqualError(t, err, tc.expectedErrText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAuthenticators, authenticationConfiguration.JWT)
+			assert.Equal(t, tc.expectedOpenIDConnectConfigs, openIDConnectConfigs)
+		})
+	}
+}
+
+func TestCreateOrUpdateConfigMapRejectsInvalidConfiguration(t *testing.T) {
+	// given
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	oidcConfig := gardener.OIDCConfig{
+		ClientID:      ptr.To("client"),
+		IssuerURL:     ptr.To("https://issuer.com"),
+		UsernameClaim: ptr.To("sub"),
+	}
+	additionalOidcConfigs := []imv1.OIDCConfig{
+		{
+			OIDCConfig: gardener.OIDCConfig{
+				ClientID:  ptr.To("additional-client"),
+				IssuerURL: ptr.To("https://additional.issuer.com"),
+			},
+			UserValidationRules: []imv1.UserValidationRule{{Message: "expression is missing"}},
+		},
+	}
+	cmKey := types.NamespacedName{Namespace: "default", Name: "invalid"}
+
+	// when
+	_, err := CreateOrUpdateStructuredAuthConfigMap(context.Background(), fakeClient, cmKey, oidcConfig, additionalOidcConfigs)
+
+	// then
+	require.EqualError(t, err, "invalid structured authentication configuration: jwt[1].userValidationRules[0].expression: Required value")
+	require.True(t, errors.IsNotFound(fakeClient.Get(context.Background(), cmKey, &corev1.ConfigMap{})))
+}
+
 func TestDeleteStructuredConfigMap(t *testing.T) {
 
 	scheme := runtime.NewScheme()
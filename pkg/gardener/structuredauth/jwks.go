@@ -0,0 +1,202 @@
+package structuredauth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	discoveryPath       = "/.well-known/openid-configuration"
+	jwksRequestTimeout  = 5 * time.Second
+	maxJWKSResponseSize = 1 << 20
+)
+
+// the kube-apiserver accepts RS256 signed tokens when no signing algorithms are configured
+var defaultSigningAlgs = []string{"RS256"}
+
+// ErrIssuerUnreachable is returned when the discovery document or the key set cannot be fetched from the issuer
+var ErrIssuerUnreachable = errors.New("issuer unreachable")
+
+// PinnedKeys identifies the signing keys published by an issuer
+type PinnedKeys struct {
+	KeyIDs []string
+	Hash   string
+	// JWKS is the key set containing only the signing keys
+	JWKS []byte
+}
+
+type discoveryDocument struct {
+	Issuer      string   `json:"issuer"`
+	JWKSURI     string   `json:"jwks_uri"`
+	SigningAlgs []string `json:"id_token_signing_alg_values_supported"`
+}
+
+type jsonWebKey struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+// DiscoverJWKS fetches the discovery document and the key set of the issuer, and validates the signing keys against the signing algorithms of the OIDC config
+func DiscoverJWKS(ctx context.Context, oidcConfig imv1.OIDCConfig) (PinnedKeys, error) {
+	httpClient, err := newHTTPClient(ptr.Deref(oidcConfig.CABundle, ""))
+	if err != nil {
+		return PinnedKeys{}, err
+	}
+
+	issuerURL := strings.TrimSuffix(ptr.Deref(oidcConfig.IssuerURL, ""), "/")
+
+	var discovery discoveryDocument
+	discoveryBytes, err := get(ctx, httpClient, issuerURL+discoveryPath)
+	if err != nil {
+		return PinnedKeys{}, err
+	}
+
+	if err := json.Unmarshal(discoveryBytes, &discovery); err != nil {
+		return PinnedKeys{}, errors.Wrap(err, "invalid discovery document")
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuerURL {
+		return PinnedKeys{}, errors.Errorf("discovery document issuer %s does not match %s", discovery.Issuer, issuerURL)
+	}
+
+	if discovery.JWKSURI == "" {
+		return PinnedKeys{}, errors.New("discovery document does not contain jwks_uri")
+	}
+
+	signingAlgs := oidcConfig.SigningAlgs
+	if len(signingAlgs) == 0 {
+		signingAlgs = defaultSigningAlgs
+	}
+
+	if len(discovery.SigningAlgs) > 0 && !slices.ContainsFunc(signingAlgs, func(alg string) bool { return slices.Contains(discovery.SigningAlgs, alg) }) {
+		return PinnedKeys{}, errors.Errorf("none of the signing algorithms %v is supported by the issuer, supported: %v", signingAlgs, discovery.SigningAlgs)
+	}
+
+	jwksBytes, err := get(ctx, httpClient, discovery.JWKSURI)
+	if err != nil {
+		return PinnedKeys{}, err
+	}
+
+	return pinSigningKeys(jwksBytes, signingAlgs)
+}
+
+func pinSigningKeys(jwksBytes []byte, signingAlgs []string) (PinnedKeys, error) {
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+
+	if err := json.Unmarshal(jwksBytes, &jwks); err != nil {
+		return PinnedKeys{}, errors.Wrap(err, "invalid key set")
+	}
+
+	type signingKey struct {
+		id  string
+		raw []byte
+	}
+
+	var signingKeys []signingKey
+	for _, raw := range jwks.Keys {
+		var key jsonWebKey
+		if err := json.Unmarshal(raw, &key); err != nil {
+			return PinnedKeys{}, errors.Wrap(err, "invalid key in key set")
+		}
+
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		if key.Algorithm != "" && !slices.Contains(signingAlgs, key.Algorithm) {
+			return PinnedKeys{}, errors.Errorf("key %s uses signing algorithm %s which is not in %v", key.KeyID, key.Algorithm, signingAlgs)
+		}
+
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, raw); err != nil {
+			return PinnedKeys{}, errors.Wrap(err, "invalid key in key set")
+		}
+
+		signingKeys = append(signingKeys, signingKey{id: key.KeyID, raw: compacted.Bytes()})
+	}
+
+	if len(signingKeys) == 0 {
+		return PinnedKeys{}, errors.New("key set does not contain signing keys")
+	}
+
+	// the order of the keys in the key set is not significant
+	slices.SortFunc(signingKeys, func(a, b signingKey) int {
+		return bytes.Compare(a.raw, b.raw)
+	})
+
+	hash := sha256.New()
+	keyIDs := make([]string, 0, len(signingKeys))
+	keys := make([]json.RawMessage, 0, len(signingKeys))
+	for _, key := range signingKeys {
+		hash.Write(key.raw)
+		keyIDs = append(keyIDs, key.id)
+		keys = append(keys, key.raw)
+	}
+
+	pinnedJWKS, err := json.Marshal(map[string][]json.RawMessage{"keys": keys})
+	if err != nil {
+		return PinnedKeys{}, errors.Wrap(err, "failed to marshal key set")
+	}
+
+	return PinnedKeys{
+		KeyIDs: keyIDs,
+		Hash:   hex.EncodeToString(hash.Sum(nil)),
+		JWKS:   pinnedJWKS,
+	}, nil
+}
+
+func newHTTPClient(caBundle string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if caBundle != "" {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM([]byte(caBundle)) {
+			return nil, errors.New("invalid CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+
+	return &http.Client{Transport: transport, Timeout: jwksRequestTimeout}, nil
+}
+
+func get(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid URL %s", url)
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, errors.Wrapf(ErrIssuerUnreachable, "failed to get %s: %v", url, err)
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Wrapf(ErrIssuerUnreachable, "failed to get %s: status %d", url, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxJWKSResponseSize))
+	if err != nil {
+		return nil, errors.Wrapf(ErrIssuerUnreachable, "failed to read %s: %v", url, err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,195 @@
+package structuredauth
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testIssuer struct {
+	server      *httptest.Server
+	issuer      string
+	signingAlgs []string
+	keys        []map[string]string
+}
+
+func newTestIssuer(t *testing.T, keys ...map[string]string) *testIssuer {
+	issuer := &testIssuer{keys: keys, signingAlgs: []string{"RS256"}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]any{
+			"issuer":                                issuer.issuer,
+			"jwks_uri":                              issuer.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": issuer.signingAlgs,
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]any{"keys": issuer.keys})
+	})
+
+	issuer.server = httptest.NewUnstartedServer(mux)
+	// handshakes rejected by clients not trusting the issuer are expected
+	issuer.server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	issuer.server.StartTLS()
+	issuer.issuer = issuer.server.URL
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+func (i *testIssuer) oidcConfig(signingAlgs ...string) imv1.OIDCConfig {
+	issuerURL := i.server.URL
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: i.server.Certificate().Raw}))
+
+	var oidcConfig imv1.OIDCConfig
+	oidcConfig.IssuerURL = &issuerURL
+	oidcConfig.CABundle = &caBundle
+	oidcConfig.SigningAlgs = signingAlgs
+
+	return oidcConfig
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func signingKey(kid, alg string) map[string]string {
+	return map[string]string{"kid": kid, "kty": "RSA", "alg": alg, "use": "sig", "n": "modulus-" + kid, "e": "AQAB"}
+}
+
+func TestDiscoverJWKS(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should pin signing keys of the issuer", func(t *testing.T) {
+		// given
+		encryptionKey := map[string]string{"kid": "enc", "kty": "RSA", "use": "enc", "n": "modulus-enc", "e": "AQAB"}
+		issuer := newTestIssuer(t, signingKey("key-1", "RS256"), encryptionKey, signingKey("key-2", "RS256"))
+
+		// when
+		keys, err := DiscoverJWKS(ctx, issuer.oidcConfig())
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"key-1", "key-2"}, keys.KeyIDs)
+		assert.NotEmpty(t, keys.Hash)
+
+		var pinned struct {
+			Keys []map[string]string `json:"keys"`
+		}
+		require.NoError(t, json.Unmarshal(keys.JWKS, &pinned))
+		assert.ElementsMatch(t, []map[string]string{signingKey("key-1", "RS256"), signingKey("key-2", "RS256")}, pinned.Keys)
+	})
+
+	t.Run("Should not change hash when keys are reordered and detect rotated keys", func(t *testing.T) {
+		// given
+		issuer := newTestIssuer(t, signingKey("key-1", "RS256"), signingKey("key-2", "RS256"))
+		keys, err := DiscoverJWKS(ctx, issuer.oidcConfig())
+		require.NoError(t, err)
+
+		// when
+		issuer.keys = []map[string]string{signingKey("key-2", "RS256"), signingKey("key-1", "RS256")}
+		reordered, err := DiscoverJWKS(ctx, issuer.oidcConfig())
+		require.NoError(t, err)
+
+		issuer.keys = []map[string]string{signingKey("key-2", "RS256"), signingKey("key-3", "RS256")}
+		rotated, err := DiscoverJWKS(ctx, issuer.oidcConfig())
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, keys.Hash, reordered.Hash)
+		assert.Equal(t, keys.JWKS, reordered.JWKS)
+		assert.NotEqual(t, keys.Hash, rotated.Hash)
+		assert.ElementsMatch(t, []string{"key-2", "key-3"}, rotated.KeyIDs)
+	})
+
+	t.Run("Should accept keys of the configured signing algorithms", func(t *testing.T) {
+		// given
+		issuer := newTestIssuer(t, signingKey("key-1", "ES256"), signingKey("key-2", "RS256"))
+		issuer.signingAlgs = []string{"RS256", "ES256"}
+
+		// when
+		keys, err := DiscoverJWKS(ctx, issuer.oidcConfig("RS256", "ES256"))
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, keys.KeyIDs, 2)
+	})
+
+	for _, testCase := range []struct {
+		name        string
+		modify      func(*testIssuer)
+		signingAlgs []string
+		errContains string
+		unreachable bool
+	}{
+		{
+			name:        "Should fail when a key uses a signing algorithm which is not configured",
+			modify:      func(i *testIssuer) { i.keys = append(i.keys, signingKey("key-2", "HS256")) },
+			errContains: "key key-2 uses signing algorithm HS256",
+		},
+		{
+			name:        "Should fail when the issuer supports none of the configured signing algorithms",
+			signingAlgs: []string{"ES512"},
+			errContains: "none of the signing algorithms [ES512] is supported by the issuer",
+		},
+		{
+			name:        "Should fail when the discovery document belongs to another issuer",
+			modify:      func(i *testIssuer) { i.issuer = "https://other.example.com" },
+			errContains: "does not match",
+		},
+		{
+			name:        "Should fail when the key set contains no signing keys",
+			modify:      func(i *testIssuer) { i.keys = nil },
+			errContains: "key set does not contain signing keys",
+		},
+		{
+			name:        "Should report unreachable issuer",
+			modify:      func(i *testIssuer) { i.server.Close() },
+			unreachable: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			issuer := newTestIssuer(t, signingKey("key-1", "RS256"))
+			oidcConfig := issuer.oidcConfig(testCase.signingAlgs...)
+			if testCase.modify != nil {
+				testCase.modify(issuer)
+			}
+
+			// when
+			_, err := DiscoverJWKS(ctx, oidcConfig)
+
+			// then
+			require.Error(t, err)
+			assert.Equal(t, testCase.unreachable, errors.Is(err, ErrIssuerUnreachable))
+			if testCase.errContains != "" {
+				assert.ErrorContains(t, err, testCase.errContains)
+			}
+		})
+	}
+
+	t.Run("Should report unreachable issuer when the issuer certificate is not trusted", func(t *testing.T) {
+		// given
+		issuer := newTestIssuer(t, signingKey("key-1", "RS256"))
+		oidcConfig := issuer.oidcConfig()
+		oidcConfig.CABundle = nil
+
+		// when
+		_, err := DiscoverJWKS(ctx, oidcConfig)
+
+		// then
+		assert.ErrorIs(t, err, ErrIssuerUnreachable)
+	})
+}
@@ -1,8 +1,11 @@
 package structuredauth
 
 import (
+	"slices"
+
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"k8s.io/utils/ptr"
 )
 
 func GetOIDCConfigOrDefault(runtime imv1.Runtime, defaultOIDC gardener.OIDCConfig) gardener.OIDCConfig {
@@ -15,6 +18,26 @@ func GetOIDCConfigOrDefault(runtime imv1.Runtime, defaultOIDC gardener.OIDCConfi
 	return oidcConfig
 }
 
+// GetAdditionalOIDCConfigsOrDefault returns the additional OIDC configs of the runtime rendered as JWT authenticators.
+// The default shared IAS tenant is used when the additional OIDC config is not set or contains incomplete entries, an empty list disables additional authenticators.
+func GetAdditionalOIDCConfigsOrDefault(runtime imv1.Runtime, defaultSharedIAS gardener.OIDCConfig) []imv1.OIDCConfig {
+	additionalOidcConfig := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig
+
+	additionalOIDCConfigUndefined := additionalOidcConfig == nil || slices.ContainsFunc(*additionalOidcConfig, func(oidcConfig imv1.OIDCConfig) bool {
+		return oidcConfig.ClientID == nil || oidcConfig.IssuerURL == nil
+	})
+
+	if !additionalOIDCConfigUndefined {
+		return *additionalOidcConfig
+	}
+
+	if ptr.Deref(defaultSharedIAS.IssuerURL, "") == "" || ptr.Deref(defaultSharedIAS.ClientID, "") == "" {
+		return nil
+	}
+
+	return []imv1.OIDCConfig{{OIDCConfig: defaultSharedIAS}}
+}
+
 func OIDCConfigured(shoot gardener.Shoot) bool {
 	if shoot.Spec.Kubernetes.KubeAPIServer == nil {
 		return false
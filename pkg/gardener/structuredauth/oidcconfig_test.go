@@ -0,0 +1,65 @@
+package structuredauth
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetAdditionalOIDCConfigsOrDefault(t *testing.T) {
+	defaultSharedIAS := gardener.OIDCConfig{
+		ClientID:  ptr.To("default-client"),
+		IssuerURL: ptr.To("https://default.issuer.com"),
+	}
+
+	configured := imv1.OIDCConfig{
+		OIDCConfig: gardener.OIDCConfig{
+			ClientID:  ptr.To("client"),
+			IssuerURL: ptr.To("https://issuer.com"),
+		},
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		additionalOidcConfig *[]imv1.OIDCConfig
+		defaultSharedIAS     gardener.OIDCConfig
+		expected             []imv1.OIDCConfig
+	}{
+		{
+			name:             "Should use the default shared IAS tenant when additional OIDC config is nil",
+			defaultSharedIAS: defaultSharedIAS,
+			expected:         []imv1.OIDCConfig{{OIDCConfig: defaultSharedIAS}},
+		},
+		{
+			name:                 "Should use the default shared IAS tenant when additional OIDC config contains an incomplete element",
+			additionalOidcConfig: &[]imv1.OIDCConfig{configured, {}},
+			defaultSharedIAS:     defaultSharedIAS,
+			expected:             []imv1.OIDCConfig{{OIDCConfig: defaultSharedIAS}},
+		},
+		{
+			name:                 "Should not add authenticators when additional OIDC config is an empty list",
+			additionalOidcConfig: &[]imv1.OIDCConfig{},
+			defaultSharedIAS:     defaultSharedIAS,
+			expected:             []imv1.OIDCConfig{},
+		},
+		{
+			name:                 "Should use additional OIDC config from the Runtime",
+			additionalOidcConfig: &[]imv1.OIDCConfig{configured},
+			defaultSharedIAS:     defaultSharedIAS,
+			expected:             []imv1.OIDCConfig{configured},
+		},
+		{
+			name: "Should not add authenticators when the default shared IAS tenant is not configured",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runtime := imv1.Runtime{}
+			runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = tc.additionalOidcConfig
+
+			assert.Equal(t, tc.expected, GetAdditionalOIDCConfigsOrDefault(runtime, tc.defaultSharedIAS))
+		})
+	}
+}
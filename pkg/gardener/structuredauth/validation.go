@@ -0,0 +1,175 @@
+package structuredauth
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	authenticationcel "k8s.io/apiserver/pkg/authentication/cel"
+)
+
+// systemPrefix is reserved for the Kubernetes components, mapped users and groups must not use it
+const systemPrefix = "system:"
+
+// reservedPrefixUserValidationRules are added to every JWT authenticator, as usernames and groups mapped from claims or expressions cannot be checked before a token is issued
+//
+//nolint:gochecknoglobals
+var reservedPrefixUserValidationRules = []UserValidationRule{
+	{Expression: "!user.username.startsWith('system:')", Message: "username cannot use reserved system: prefix"},
+	{Expression: "user.groups.all(group, !group.startsWith('system:'))", Message: "groups cannot use reserved system: prefix"},
+}
+
+// celCompiler compiles the expressions with the CEL environment of the kube-apiserver, it is built once as creating the environment is expensive
+//
+//nolint:gochecknoglobals
+var celCompiler = sync.OnceValue(authenticationcel.NewDefaultCompiler)
+
+// validateAuthenticationConfiguration checks the rules and mappings of the JWT authenticators before the config map is written,
+// so an invalid Runtime does not break the authentication of the kube-apiserver.
+// CEL expressions are compiled the same way the kube-apiserver compiles them when the configuration is loaded.
+func validateAuthenticationConfiguration(config AuthenticationConfiguration) error {
+	var allErrs field.ErrorList
+
+	for i, authenticator := range config.JWT {
+		fldPath := field.NewPath("jwt").Index(i)
+
+		allErrs = append(allErrs, validateIssuer(authenticator.Issuer, fldPath.Child("issuer"))...)
+		allErrs = append(allErrs, validateClaimValidationRules(authenticator.ClaimValidationRules, fldPath.Child("claimValidationRules"))...)
+		allErrs = append(allErrs, validateClaimMappings(authenticator.ClaimMappings, fldPath.Child("claimMappings"))...)
+		allErrs = append(allErrs, validateUserValidationRules(authenticator.UserValidationRules, fldPath.Child("userValidationRules"))...)
+	}
+
+	return allErrs.ToAggregate()
+}
+
+func validateIssuer(issuer Issuer, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if sets.New(issuer.Audiences...).Len() != len(issuer.Audiences) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("audiences"), issuer.Audiences, "duplicate audiences are not allowed"))
+	}
+
+	switch {
+	case issuer.AudienceMatchPolicy != "" && issuer.AudienceMatchPolicy != audienceMatchPolicyMatchAny:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("audienceMatchPolicy"), issuer.AudienceMatchPolicy, []string{audienceMatchPolicyMatchAny}))
+	case len(issuer.Audiences) > 1 && issuer.AudienceMatchPolicy != audienceMatchPolicyMatchAny:
+		allErrs = append(allErrs, field.Required(fldPath.Child("audienceMatchPolicy"), fmt.Sprintf("must be %s for multiple audiences", audienceMatchPolicyMatchAny)))
+	}
+
+	return allErrs
+}
+
+func validateClaimValidationRules(rules []ClaimValidationRule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	claims := sets.New[string]()
+
+	for i, rule := range rules {
+		rulePath := fldPath.Index(i)
+
+		switch {
+		case rule.Claim == "" && rule.Expression == "":
+			allErrs = append(allErrs, field.Required(rulePath, "claim or expression is required"))
+		case rule.Claim != "" && rule.Expression != "":
+			allErrs = append(allErrs, field.Invalid(rulePath, rule.Claim, "claim and expression cannot both be set"))
+		case rule.Claim != "":
+			if rule.Message != "" {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("message"), rule.Message, "message can only be set with expression"))
+			}
+			if claims.Has(rule.Claim) {
+				allErrs = append(allErrs, field.Duplicate(rulePath.Child("claim"), rule.Claim))
+			}
+			claims.Insert(rule.Claim)
+		case rule.RequiredValue != "":
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("requiredValue"), rule.RequiredValue, "requiredValue can only be set with claim"))
+		default:
+			allErrs = append(allErrs, compileClaimsExpression(&authenticationcel.ClaimValidationCondition{Expression: rule.Expression, Message: rule.Message}, rulePath.Child("expression"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateClaimMappings(mappings ClaimMappings, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validatePrefixedClaim(mappings.Username, fldPath.Child("username"))...)
+	allErrs = append(allErrs, validatePrefixedClaim(mappings.Groups, fldPath.Child("groups"))...)
+
+	if mappings.UID != nil {
+		if mappings.UID.Claim != "" && mappings.UID.Expression != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("uid"), mappings.UID.Claim, "claim and expression cannot both be set"))
+		} else if mappings.UID.Expression != "" {
+			allErrs = append(allErrs, compileClaimsExpression(&authenticationcel.ClaimMappingExpression{Expression: mappings.UID.Expression}, fldPath.Child("uid", "expression"))...)
+		}
+	}
+
+	keys := sets.New[string]()
+	for i, extra := range mappings.Extra {
+		extraPath := fldPath.Child("extra").Index(i)
+
+		if extra.Key == "" {
+			allErrs = append(allErrs, field.Required(extraPath.Child("key"), ""))
+		}
+		if extra.Key != strings.ToLower(extra.Key) {
+			allErrs = append(allErrs, field.Invalid(extraPath.Child("key"), extra.Key, "key must be lowercase"))
+		}
+		if keys.Has(extra.Key) {
+			allErrs = append(allErrs, field.Duplicate(extraPath.Child("key"), extra.Key))
+		}
+		keys.Insert(extra.Key)
+
+		if extra.ValueExpression == "" {
+			allErrs = append(allErrs, field.Required(extraPath.Child("valueExpression"), ""))
+		} else {
+			allErrs = append(allErrs, compileClaimsExpression(&authenticationcel.ExtraMappingExpression{Key: extra.Key, Expression: extra.ValueExpression}, extraPath.Child("valueExpression"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validatePrefixedClaim(mapping PrefixedClaim, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if mapping.Claim != "" && mapping.Expression != "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, mapping.Claim, "claim and expression cannot both be set"))
+	} else if mapping.Expression != "" {
+		allErrs = append(allErrs, compileClaimsExpression(&authenticationcel.ClaimMappingExpression{Expression: mapping.Expression}, fldPath.Child("expression"))...)
+	}
+
+	if mapping.Expression != "" && mapping.Prefix != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefix"), *mapping.Prefix, "prefix cannot be set with expression"))
+	}
+
+	if mapping.Prefix != nil && strings.HasPrefix(*mapping.Prefix, systemPrefix) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefix"), *mapping.Prefix, fmt.Sprintf("prefix cannot start with %s", systemPrefix)))
+	}
+
+	return allErrs
+}
+
+func validateUserValidationRules(rules []UserValidationRule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, rule := range rules {
+		if rule.Expression == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("expression"), ""))
+			continue
+		}
+
+		if _, err := celCompiler().CompileUserExpression(&authenticationcel.UserValidationCondition{Expression: rule.Expression, Message: rule.Message}); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("expression"), rule.Expression, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func compileClaimsExpression(expression authenticationcel.ExpressionAccessor, fldPath *field.Path) field.ErrorList {
+	if _, err := celCompiler().CompileClaimsExpression(expression); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, expression.GetExpression(), err.Error())}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package structuredauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateAuthenticationConfiguration(t *testing.T) {
+	validAuthenticator := func() JWTAuthenticator {
+		return JWTAuthenticator{
+			Issuer: Issuer{URL: "https://issuer.com", Audiences: []string{"client"}},
+			ClaimMappings: ClaimMappings{
+				Username: PrefixedClaim{Claim: "sub", Prefix: ptr.To("-")},
+				Groups:   PrefixedClaim{Claim: "groups", Prefix: ptr.To("")},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name            string
+		modify          func(*JWTAuthenticator)
+		expectedErrText string
+	}{
+		{
+			name:   "Should accept a valid authenticator",
+			modify: func(_ *JWTAuthenticator) {},
+		},
+		{
+			name: "Should accept rules and mappings based on expressions",
+			modify: func(a *JWTAuthenticator) {
+				a.Issuer.Audiences = []string{"client", "other"}
+				a.Issuer.AudienceMatchPolicy = "MatchAny"
+				a.ClaimValidationRules = []ClaimValidationRule{{Claim: "tenant", RequiredValue: "kyma"}, {Expression: "claims.aud != ''", Message: "audience required"}}
+				a.ClaimMappings.Username = PrefixedClaim{Expression: "claims.email"}
+				a.ClaimMappings.Extra = []ExtraMapping{{Key: "kyma.io/tenant", ValueExpression: "claims.tenant"}}
+				a.UserValidationRules = []UserValidationRule{{Expression: "!user.username.startsWith('system:')"}}
+			},
+		},
+		{
+			name: "Should accept user validation rules for the reserved system: prefix",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimMappings.Username = PrefixedClaim{Expression: "claims.email"}
+				a.UserValidationRules = reservedPrefixUserValidationRules
+			},
+		},
+		{
+			name: "Should reject claim validation expression which does not compile",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimValidationRules = []ClaimValidationRule{{Expression: "claims.tenant == tenant"}}
+			},
+			expectedErrText: "jwt[0].claimValidationRules[0].expression: Invalid value: \"claims.tenant == tenant\": compilation failed: ERROR: <input>:1:18: undeclared reference to 'tenant' (in container '')\n | claims.tenant == tenant\n | .................^",
+		},
+		{
+			name: "Should reject claim validation expression which does not evaluate to bool",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimValidationRules = []ClaimValidationRule{{Expression: "'kyma'"}}
+			},
+			expectedErrText: `jwt[0].claimValidationRules[0].expression: Invalid value: "'kyma'": must evaluate to bool`,
+		},
+		{
+			name: "Should reject username expression which does not compile",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimMappings.Username = PrefixedClaim{Expression: "user.username"}
+			},
+			expectedErrText: "jwt[0].claimMappings.username.expression: Invalid value: \"user.username\": compilation failed: ERROR: <input>:1:1: undeclared reference to 'user' (in container '')\n | user.username\n | ^",
+		},
+		{
+			name: "Should reject extra mapping expression which does not compile",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimMappings.Extra = []ExtraMapping{{Key: "kyma.io/tenant", ValueExpression: "tenant"}}
+			},
+			expectedErrText: "jwt[0].claimMappings.extra[0].valueExpression: Invalid value: \"tenant\": compilation failed: ERROR: <input>:1:1: undeclared reference to 'tenant' (in container '')\n | tenant\n | ^",
+		},
+		{
+			name: "Should reject user validation expression based on claims",
+			modify: func(a *JWTAuthenticator) {
+				a.UserValidationRules = []UserValidationRule{{Expression: "claims.email_verified"}}
+			},
+			expectedErrText: "jwt[0].userValidationRules[0].expression: Invalid value: \"claims.email_verified\": compilation failed: ERROR: <input>:1:1: undeclared reference to 'claims' (in container '')\n | claims.email_verified\n | ^",
+		},
+		{
+			name: "Should require MatchAny audience match policy for multiple audiences",
+			modify: func(a *JWTAuthenticator) {
+				a.Issuer.Audiences = []string{"client", "other"}
+			},
+			expectedErrText: "jwt[0].issuer.audienceMatchPolicy: Required value: must be MatchAny for multiple audiences",
+		},
+		{
+			name: "Should reject unsupported audience match policy",
+			modify: func(a *JWTAuthenticator) {
+				a.Issuer.AudienceMatchPolicy = "MatchAll"
+			},
+			expectedErrText: `jwt[0].issuer.audienceMatchPolicy: Unsupported value: "MatchAll": supported values: "MatchAny"`,
+		},
+		{
+			name: "Should reject claim validation rule with claim and expression",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimValidationRules = []ClaimValidationRule{{Claim: "tenant", Expression: "claims.tenant == 'kyma'"}}
+			},
+			expectedErrText: `jwt[0].claimValidationRules[0]: Invalid value: "tenant": claim and expression cannot both be set`,
+		},
+		{
+			name: "Should reject empty claim validation rule",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimValidationRules = []ClaimValidationRule{{}}
+			},
+			expectedErrText: "jwt[0].claimValidationRules[0]: Required value: claim or expression is required",
+		},
+		{
+			name: "Should reject duplicated claims in claim validation rules",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimValidationRules = []ClaimValidationRule{{Claim: "tenant", RequiredValue: "a"}, {Claim: "tenant", RequiredValue: "b"}}
+			},
+			expectedErrText: `jwt[0].claimValidationRules[1].claim: Duplicate value: "tenant"`,
+		},
+		{
+			name: "Should reject prefix together with username expression",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimMappings.Username = PrefixedClaim{Expression: "claims.email", Prefix: ptr.To("oidc:")}
+			},
+			expectedErrText: `jwt[0].claimMappings.username.prefix: Invalid value: "oidc:": prefix cannot be set with expression`,
+		},
+		{
+			name: "Should reject system: prefix for groups",
+			modify: func(a *JWTAuthenticator) {
+				a.ClaimMappings.Groups.Prefix = ptr.To("system:masters")
+			},
+			expectedErrText: `jwt[0].claimMappings.groups.prefix: Invalid value: "system:masters": prefix cannot start with system:`,
+		},
+		{
+			name: "Should reject user validation rule without expression",
+			modify: func(a *JWTAuthenticator) {
+				a.UserValidationRules = []UserValidationRule{{Message: "no expression"}}
+			},
+			expectedErrText: "jwt[0].userValidationRules[0].expression: Required value",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			authenticator := validAuthenticator()
+			tc.modify(&authenticator)
+
+			// when
+			err := validateAuthenticationConfiguration(AuthenticationConfiguration{JWT: []JWTAuthenticator{authenticator}})
+
+			// then
+			if tc.expectedErrText == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedErrText)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package structuredauthz
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	configMapNameFmt        = "structured-authz-config-%s"
+	kubeconfigSecretNameFmt = "structured-authz-kubeconfig-%s-%s"
+
+	// KubeconfigKey is the key of the webhook kubeconfig in the Runtime namespace secret and in the project secret
+	KubeconfigKey = "kubeconfig"
+
+	defaultFailurePolicy       = "NoOpinion"
+	subjectAccessReviewVersion = "v1"
+)
+
+type AuthorizationConfiguration struct {
+	metav1.TypeMeta
+
+	Authorizers []Authorizer `json:"authorizers"`
+}
+
+type Authorizer struct {
+	Type    string                `json:"type"`
+	Name    string                `json:"name"`
+	Webhook *WebhookConfiguration `json:"webhook,omitempty"`
+}
+
+type WebhookConfiguration struct {
+	Timeout                                  *metav1.Duration `json:"timeout,omitempty"`
+	AuthorizedTTL                            *metav1.Duration `json:"authorizedTTL,omitempty"`
+	UnauthorizedTTL                          *metav1.Duration `json:"unauthorizedTTL,omitempty"`
+	SubjectAccessReviewVersion               string           `json:"subjectAccessReviewVersion"`
+	MatchConditionSubjectAccessReviewVersion string           `json:"matchConditionSubjectAccessReviewVersion"`
+	FailurePolicy                            string           `json:"failurePolicy"`
+	ConnectionInfo                           ConnectionInfo   `json:"connectionInfo"`
+	MatchConditions                          []MatchCondition `json:"matchConditions,omitempty"`
+}
+
+// ConnectionInfo points to the kubeconfig of the webhook, Gardener mounts the referenced kubeconfig secrets and sets the file path
+type ConnectionInfo struct {
+	Type string `json:"type"`
+}
+
+type MatchCondition struct {
+	Expression string `json:"expression"`
+}
+
+// ConfigMapName returns the name of the ConfigMap in the project namespace containing the AuthorizationConfiguration of the shoot
+func ConfigMapName(shootName string) string {
+	return fmt.Sprintf(configMapNameFmt, shootName)
+}
+
+// KubeconfigSecretName returns the name of the secret in the project namespace containing the kubeconfig of the webhook authorizer
+func KubeconfigSecretName(shootName, authorizerName string) string {
+	return fmt.Sprintf(kubeconfigSecretNameFmt, shootName, authorizerName)
+}
+
+func toAuthorizationConfiguration(authorization imv1.StructuredAuthorization) (AuthorizationConfiguration, error) {
+	authorizers := make([]Authorizer, 0, len(authorization.Webhooks))
+
+	for _, webhook := range authorization.Webhooks {
+		if slices.ContainsFunc(authorizers, func(a Authorizer) bool { return a.Name == webhook.Name }) {
+			return AuthorizationConfiguration{}, errors.Errorf("duplicate webhook authorizer name %s", webhook.Name)
+		}
+
+		failurePolicy := webhook.FailurePolicy
+		if failurePolicy == "" {
+			failurePolicy = defaultFailurePolicy
+		}
+
+		var matchConditions []MatchCondition
+		for _, expression := range webhook.MatchConditions {
+			matchConditions = append(matchConditions, MatchCondition{Expression: expression})
+		}
+
+		authorizers = append(authorizers, Authorizer{
+			Type: "Webhook",
+			Name: webhook.Name,
+			Webhook: &WebhookConfiguration{
+				Timeout:                                  webhook.Timeout,
+				AuthorizedTTL:                            webhook.AuthorizedTTL,
+				UnauthorizedTTL:                          webhook.UnauthorizedTTL,
+				SubjectAccessReviewVersion:               subjectAccessReviewVersion,
+				MatchConditionSubjectAccessReviewVersion: subjectAccessReviewVersion,
+				FailurePolicy:                            failurePolicy,
+				ConnectionInfo:                           ConnectionInfo{Type: "KubeConfigFile"},
+				MatchConditions:                          matchConditions,
+			},
+		})
+	}
+
+	return AuthorizationConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "AuthorizationConfiguration",
+			APIVersion: "apiserver.config.k8s.io/v1beta1",
+		},
+		Authorizers: authorizers,
+	}, nil
+}
+
+// CreateOrUpdateStructuredAuthorizationConfigMap writes the AuthorizationConfiguration rendered from the webhook authorizers of the Runtime
+func CreateOrUpdateStructuredAuthorizationConfigMap(ctx context.Context, seedClient client.Client, cmKey types.NamespacedName, authorization imv1.StructuredAuthorization) error {
+	authorizationConfig, err := toAuthorizationConfiguration(authorization)
+	if err != nil {
+		return err
+	}
+
+	authzConfigBytes, err := yaml.Marshal(authorizationConfig)
+	if err != nil {
+		return err
+	}
+
+	var existingCM v1.ConfigMap
+	err = seedClient.Get(ctx, cmKey, &existingCM)
+
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	data := map[string]string{
+		"config.yaml": string(authzConfigBytes),
+	}
+
+	if err == nil {
+		existingCM.Data = data
+		return seedClient.Update(ctx, &existingCM)
+	}
+
+	return seedClient.Create(ctx, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmKey.Name,
+			Namespace: cmKey.Namespace,
+		},
+		Data: data,
+	})
+}
@@ -0,0 +1,135 @@
+package structuredauthz
+
+import (
+	"context"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const managedBy = "infrastructure-manager"
+
+// CreateOrUpdate copies the webhook kubeconfigs from the Runtime namespace to the project namespace and writes the AuthorizationConfiguration ConfigMap.
+// Nothing is written when the Runtime has no structured authorization.
+func CreateOrUpdate(ctx context.Context, kcpClient, seedClient client.Client, namespace string, runtime imv1.Runtime) error {
+	authorization := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.StructuredAuthorization
+	if authorization == nil {
+		return nil
+	}
+
+	shootName := runtime.Spec.Shoot.Name
+
+	for _, webhook := range authorization.Webhooks {
+		var source v1.Secret
+		if err := kcpClient.Get(ctx, types.NamespacedName{Name: webhook.KubeconfigSecretName, Namespace: runtime.Namespace}, &source); err != nil {
+			return errors.Wrapf(err, "failed to get kubeconfig secret %s of webhook authorizer %s", webhook.KubeconfigSecretName, webhook.Name)
+		}
+
+		kubeconfig, found := source.Data[KubeconfigKey]
+		if !found {
+			return errors.Errorf("kubeconfig secret %s of webhook authorizer %s does not contain the %s key", webhook.KubeconfigSecretName, webhook.Name, KubeconfigKey)
+		}
+
+		if err := createOrUpdateKubeconfigSecret(ctx, seedClient, types.NamespacedName{Name: KubeconfigSecretName(shootName, webhook.Name), Namespace: namespace}, shootName, kubeconfig); err != nil {
+			return errors.Wrapf(err, "failed to write kubeconfig secret of webhook authorizer %s", webhook.Name)
+		}
+	}
+
+	cmKey := types.NamespacedName{Name: ConfigMapName(shootName), Namespace: namespace}
+	return errors.Wrap(CreateOrUpdateStructuredAuthorizationConfigMap(ctx, seedClient, cmKey, *authorization), "failed to write structured authorization config map")
+}
+
+// DeleteUnused removes the kubeconfig secrets of webhook authorizers no longer configured on the Runtime, and the ConfigMap when structured authorization was removed.
+// It must be called after the shoot was patched, so the shoot does not reference the deleted objects anymore.
+func DeleteUnused(ctx context.Context, seedClient client.Client, namespace string, runtime imv1.Runtime) error {
+	shootName := runtime.Spec.Shoot.Name
+	authorization := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.StructuredAuthorization
+
+	used := sets.New[string]()
+	if authorization != nil {
+		for _, webhook := range authorization.Webhooks {
+			used.Insert(KubeconfigSecretName(shootName, webhook.Name))
+		}
+	}
+
+	if err := deleteKubeconfigSecrets(ctx, seedClient, namespace, shootName, used); err != nil {
+		return err
+	}
+
+	if authorization != nil {
+		return nil
+	}
+
+	return deleteConfigMap(ctx, seedClient, namespace, shootName)
+}
+
+// Delete removes the AuthorizationConfiguration ConfigMap and all webhook kubeconfig secrets of the shoot
+func Delete(ctx context.Context, seedClient client.Client, namespace, shootName string) error {
+	if err := deleteKubeconfigSecrets(ctx, seedClient, namespace, shootName, sets.New[string]()); err != nil {
+		return err
+	}
+
+	return deleteConfigMap(ctx, seedClient, namespace, shootName)
+}
+
+func createOrUpdateKubeconfigSecret(ctx context.Context, seedClient client.Client, key types.NamespacedName, shootName string, kubeconfig []byte) error {
+	secret := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, seedClient, &secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[imv1.LabelKymaManagedBy] = managedBy
+		secret.Labels[imv1.LabelKymaShootName] = shootName
+		secret.Data = map[string][]byte{KubeconfigKey: kubeconfig}
+		return nil
+	})
+
+	return err
+}
+
+func deleteKubeconfigSecrets(ctx context.Context, seedClient client.Client, namespace, shootName string, keep sets.Set[string]) error {
+	var secrets v1.SecretList
+	err := seedClient.List(ctx, &secrets, client.InNamespace(namespace), client.MatchingLabels{
+		imv1.LabelKymaManagedBy: managedBy,
+		imv1.LabelKymaShootName: shootName,
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "failed to list webhook kubeconfig secrets")
+	}
+
+	for i := range secrets.Items {
+		if keep.Has(secrets.Items[i].Name) {
+			continue
+		}
+
+		if err := client.IgnoreNotFound(seedClient.Delete(ctx, &secrets.Items[i])); err != nil {
+			return errors.Wrapf(err, "failed to delete webhook kubeconfig secret %s", secrets.Items[i].Name)
+		}
+	}
+
+	return nil
+}
+
+func deleteConfigMap(ctx context.Context, seedClient client.Client, namespace, shootName string) error {
+	err := seedClient.Delete(ctx, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(shootName),
+			Namespace: namespace,
+		},
+	})
+
+	return errors.Wrap(client.IgnoreNotFound(err), "failed to delete structured authorization config map")
+}
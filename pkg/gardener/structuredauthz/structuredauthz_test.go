@@ -0,0 +1,243 @@
+package structuredauthz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	projectNamespace = "garden-kyma"
+	runtimeNamespace = "kcp-system"
+	shootName        = "shoot"
+)
+
+func TestToAuthorizationConfiguration(t *testing.T) {
+	t.Run("Should render webhook authorizers with defaults", func(t *testing.T) {
+		// when
+		config, err := toAuthorizationConfiguration(imv1.StructuredAuthorization{
+			Webhooks: []imv1.WebhookAuthorizer{
+				{
+					Name:            "policy",
+					Timeout:         &metav1.Duration{Duration: 3 * time.Second},
+					FailurePolicy:   "Deny",
+					MatchConditions: []string{"request.resource != 'secrets'"},
+				},
+				{Name: "audit"},
+			},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "AuthorizationConfiguration", config.Kind)
+		assert.Equal(t, "apiserver.config.k8s.io/v1beta1", config.APIVersion)
+		assert.Equal(t, []Authorizer{
+			{
+				Type: "Webhook",
+				Name: "policy",
+				Webhook: &WebhookConfiguration{
+					Timeout:                                  &metav1.Duration{Duration: 3 * time.Second},
+					SubjectAccessReviewVersion:               "v1",
+					MatchConditionSubjectAccessReviewVersion: "v1",
+					FailurePolicy:                            "Deny",
+					ConnectionInfo:                           ConnectionInfo{Type: "KubeConfigFile"},
+					MatchConditions:                          []MatchCondition{{Expression: "request.resource != 'secrets'"}},
+				},
+			},
+			{
+				Type: "Webhook",
+				Name: "audit",
+				Webhook: &WebhookConfiguration{
+					SubjectAccessReviewVersion:               "v1",
+					MatchConditionSubjectAccessReviewVersion: "v1",
+					FailurePolicy:                            "NoOpinion",
+					ConnectionInfo:                           ConnectionInfo{Type: "KubeConfigFile"},
+				},
+			},
+		}, config.Authorizers)
+	})
+
+	t.Run("Should fail for duplicate authorizer names", func(t *testing.T) {
+		_, err := toAuthorizationConfiguration(imv1.StructuredAuthorization{
+			Webhooks: []imv1.WebhookAuthorizer{{Name: "policy"}, {Name: "policy"}},
+		})
+
+		require.EqualError(t, err, "duplicate webhook authorizer name policy")
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	t.Run("Should write webhook kubeconfig secrets and the config map", func(t *testing.T) {
+		// given
+		fakeClient := newFakeClient(t, fixSourceSecret("policy-kubeconfig", "policy-config"))
+		runtime := fixRuntime("policy")
+
+		// when
+		err := CreateOrUpdate(context.Background(), fakeClient, fakeClient, projectNamespace, runtime)
+
+		// then
+		require.NoError(t, err)
+
+		var secret corev1.Secret
+		require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: "structured-authz-kubeconfig-shoot-policy", Namespace: projectNamespace}, &secret))
+		assert.Equal(t, []byte("policy-config"), secret.Data[KubeconfigKey])
+		assert.Equal(t, shootName, secret.Labels[imv1.LabelKymaShootName])
+
+		var cm corev1.ConfigMap
+		require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: "structured-authz-config-shoot", Namespace: projectNamespace}, &cm))
+
+		var config AuthorizationConfiguration
+		require.NoError(t, yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &config))
+		require.Len(t, config.Authorizers, 1)
+		assert.Equal(t, "policy", config.Authorizers[0].Name)
+	})
+
+	t.Run("Should update the copied kubeconfig when the source secret changes", func(t *testing.T) {
+		// given
+		fakeClient := newFakeClient(t, fixSourceSecret("policy-kubeconfig", "new-config"), fixProjectSecret("policy", "old-config"))
+
+		// when
+		err := CreateOrUpdate(context.Background(), fakeClient, fakeClient, projectNamespace, fixRuntime("policy"))
+
+		// then
+		require.NoError(t, err)
+		var secret corev1.Secret
+		require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: "structured-authz-kubeconfig-shoot-policy", Namespace: projectNamespace}, &secret))
+		assert.Equal(t, []byte("new-config"), secret.Data[KubeconfigKey])
+	})
+
+	t.Run("Should fail when the kubeconfig secret does not exist in the Runtime namespace", func(t *testing.T) {
+		fakeClient := newFakeClient(t)
+
+		err := CreateOrUpdate(context.Background(), fakeClient, fakeClient, projectNamespace, fixRuntime("policy"))
+
+		require.ErrorContains(t, err, "failed to get kubeconfig secret policy-kubeconfig of webhook authorizer policy")
+	})
+
+	t.Run("Should not write anything without structured authorization", func(t *testing.T) {
+		fakeClient := newFakeClient(t)
+
+		err := CreateOrUpdate(context.Background(), fakeClient, fakeClient, projectNamespace, imv1.Runtime{})
+
+		require.NoError(t, err)
+		var cms corev1.ConfigMapList
+		require.NoError(t, fakeClient.List(context.Background(), &cms))
+		assert.Empty(t, cms.Items)
+	})
+}
+
+func TestDeleteUnused(t *testing.T) {
+	t.Run("Should delete kubeconfig secrets of removed authorizers", func(t *testing.T) {
+		// given
+		fakeClient := newFakeClient(t, fixProjectSecret("policy", "config"), fixProjectSecret("removed", "config"), fixConfigMap())
+
+		// when
+		err := DeleteUnused(context.Background(), fakeClient, projectNamespace, fixRuntime("policy"))
+
+		// then
+		require.NoError(t, err)
+		assertExists(t, fakeClient, &corev1.Secret{}, "structured-authz-kubeconfig-shoot-policy", true)
+		assertExists(t, fakeClient, &corev1.Secret{}, "structured-authz-kubeconfig-shoot-removed", false)
+		assertExists(t, fakeClient, &corev1.ConfigMap{}, "structured-authz-config-shoot", true)
+	})
+
+	t.Run("Should delete the config map when structured authorization was removed", func(t *testing.T) {
+		// given
+		fakeClient := newFakeClient(t, fixProjectSecret("policy", "config"), fixConfigMap())
+		runtime := imv1.Runtime{}
+		runtime.Spec.Shoot.Name = shootName
+
+		// when
+		err := DeleteUnused(context.Background(), fakeClient, projectNamespace, runtime)
+
+		// then
+		require.NoError(t, err)
+		assertExists(t, fakeClient, &corev1.Secret{}, "structured-authz-kubeconfig-shoot-policy", false)
+		assertExists(t, fakeClient, &corev1.ConfigMap{}, "structured-authz-config-shoot", false)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	// given
+	otherShootSecret := fixProjectSecret("policy", "config")
+	otherShootSecret.Name = "structured-authz-kubeconfig-other-policy"
+	otherShootSecret.Labels[imv1.LabelKymaShootName] = "other"
+	fakeClient := newFakeClient(t, fixProjectSecret("policy", "config"), otherShootSecret, fixConfigMap())
+
+	// when
+	err := Delete(context.Background(), fakeClient, projectNamespace, shootName)
+
+	// then
+	require.NoError(t, err)
+	assertExists(t, fakeClient, &corev1.Secret{}, "structured-authz-kubeconfig-shoot-policy", false)
+	assertExists(t, fakeClient, &corev1.Secret{}, "structured-authz-kubeconfig-other-policy", true)
+	assertExists(t, fakeClient, &corev1.ConfigMap{}, "structured-authz-config-shoot", false)
+
+	// deleting again does not fail
+	require.NoError(t, Delete(context.Background(), fakeClient, projectNamespace, shootName))
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func fixRuntime(webhookNames ...string) imv1.Runtime {
+	runtime := imv1.Runtime{ObjectMeta: metav1.ObjectMeta{Name: "runtime", Namespace: runtimeNamespace}}
+	runtime.Spec.Shoot.Name = shootName
+
+	authorization := &imv1.StructuredAuthorization{}
+	for _, name := range webhookNames {
+		authorization.Webhooks = append(authorization.Webhooks, imv1.WebhookAuthorizer{Name: name, KubeconfigSecretName: name + "-kubeconfig"})
+	}
+	runtime.Spec.Shoot.Kubernetes.KubeAPIServer.StructuredAuthorization = authorization
+
+	return runtime
+}
+
+func fixSourceSecret(name, kubeconfig string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: runtimeNamespace},
+		Data:       map[string][]byte{KubeconfigKey: []byte(kubeconfig)},
+	}
+}
+
+func fixProjectSecret(authorizerName, kubeconfig string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      KubeconfigSecretName(shootName, authorizerName),
+			Namespace: projectNamespace,
+			Labels: map[string]string{
+				imv1.LabelKymaManagedBy: managedBy,
+				imv1.LabelKymaShootName: shootName,
+			},
+		},
+		Data: map[string][]byte{KubeconfigKey: []byte(kubeconfig)},
+	}
+}
+
+func fixConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName(shootName), Namespace: projectNamespace}}
+}
+
+func assertExists(t *testing.T, c client.Client, obj client.Object, name string, expected bool) {
+	err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: projectNamespace}, obj)
+	if expected {
+		assert.NoError(t, err)
+		return
+	}
+	assert.True(t, k8serrors.IsNotFound(err), "%s should not exist", name)
+}
@@ -1,10 +1,29 @@
 package reconciler
 
+import (
+	"slices"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+)
+
 const (
-	ForceReconcileAnnotation   = "operator.kyma-project.io/force-patch-reconciliation"
-	SuspendReconcileAnnotation = "operator.kyma-project.io/suspend-patch-reconciliation"
+	ForceReconcileAnnotation    = "operator.kyma-project.io/force-patch-reconciliation"
+	SuspendReconcileAnnotation  = "operator.kyma-project.io/suspend-patch-reconciliation"
+	RotateCredentialsAnnotation = "operator.kyma-project.io/rotate-credentials"
+	GardenerOperationAnnotation = "operator.kyma-project.io/gardener-operation"
 )
 
+// AllowedGardenerOperations lists the Gardener operations which can be forwarded to the shoot with the GardenerOperationAnnotation.
+// Credentials rotations running in two phases are not listed, they are driven with the RotateCredentialsAnnotation, which also rotates the observability credentials.
+// The observability credentials can be rotated on their own with the rotate-observability-credentials operation.
+var AllowedGardenerOperations = []string{
+	v1beta1constants.GardenerOperationReconcile,
+	v1beta1constants.ShootOperationRetry,
+	v1beta1constants.ShootOperationMaintain,
+	v1beta1constants.ShootOperationRotateSSHKeypair,
+	v1beta1constants.OperationRotateObservabilityCredentials,
+}
+
 func ShouldSuspendReconciliation(annotations map[string]string) bool {
 	suspendValue, found := annotations[SuspendReconcileAnnotation]
 	if found && suspendValue == "true" {
@@ -20,3 +39,17 @@ func ShouldForceReconciliation(annotations map[string]string) bool {
 	}
 	return false
 }
+
+func ShouldRotateCredentials(annotations map[string]string) bool {
+	rotateCredentials, found := annotations[RotateCredentialsAnnotation]
+	if found && rotateCredentials == "true" {
+		return true
+	}
+	return false
+}
+
+// GetGardenerOperation returns the Gardener operation requested with the annotation and whether it is allowed to be forwarded
+func GetGardenerOperation(annotations map[string]string) (operation string, found bool, allowed bool) {
+	operation, found = annotations[GardenerOperationAnnotation]
+	return operation, found, slices.Contains(AllowedGardenerOperations, operation)
+}
@@ -72,3 +72,76 @@ func TestShouldSuspendReconciliation(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldRotateCredentials(t *testing.T) {
+	for _, testCase := range []struct {
+		name           string
+		annotations    map[string]string
+		expectedResult bool
+	}{
+		{
+			name:           "Should rotate credentials for `operator.kyma-project.io/rotate-credentials` set to `true",
+			annotations:    map[string]string{"operator.kyma-project.io/rotate-credentials": "true"},
+			expectedResult: true,
+		},
+		{
+			name:           "Should not rotate credentials for `operator.kyma-project.io/rotate-credentials` set to `false",
+			annotations:    map[string]string{"operator.kyma-project.io/rotate-credentials": "false"},
+			expectedResult: false,
+		},
+		{
+			name:           "Should not rotate credentials for nil annotations",
+			annotations:    nil,
+			expectedResult: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// when
+			rotateCredentials := ShouldRotateCredentials(testCase.annotations)
+
+			// then
+			assert.Equal(t, testCase.expectedResult, rotateCredentials)
+		})
+	}
+}
+
+func TestGetGardenerOperation(t *testing.T) {
+	for _, testCase := range []struct {
+		name              string
+		annotations       map[string]string
+		expectedOperation string
+		expectedFound     bool
+		expectedAllowed   bool
+	}{
+		{
+			name:              "Should allow `maintain` operation",
+			annotations:       map[string]string{"operator.kyma-project.io/gardener-operation": "maintain"},
+			expectedOperation: "maintain",
+			expectedFound:     true,
+			expectedAllowed:   true,
+		},
+		{
+			name:              "Should not allow `rotate-ca-start` operation",
+			annotations:       map[string]string{"operator.kyma-project.io/gardener-operation": "rotate-ca-start"},
+			expectedOperation: "rotate-ca-start",
+			expectedFound:     true,
+			expectedAllowed:   false,
+		},
+		{
+			name:            "Should not find operation for nil annotations",
+			annotations:     nil,
+			expectedFound:   false,
+			expectedAllowed: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// when
+			operation, found, allowed := GetGardenerOperation(testCase.annotations)
+
+			// then
+			assert.Equal(t, testCase.expectedOperation, operation)
+			assert.Equal(t, testCase.expectedFound, found)
+			assert.Equal(t, testCase.expectedAllowed, allowed)
+		})
+	}
+}